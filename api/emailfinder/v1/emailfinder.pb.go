@@ -0,0 +1,1939 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.3
+// source: api/emailfinder/v1/emailfinder.proto
+
+package emailfinderv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FindEmailRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FirstName string `protobuf:"bytes,1,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName  string `protobuf:"bytes,2,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	Company   string `protobuf:"bytes,3,opt,name=company,proto3" json:"company,omitempty"`
+	// Extra is_reachable statuses to report in found_emails: risky, unknown, invalid
+	Include []string `protobuf:"bytes,4,rep,name=include,proto3" json:"include,omitempty"`
+	// Verify again instead of reusing a recent lookup of the same person at the same domain
+	Refresh bool `protobuf:"varint,5,opt,name=refresh,proto3" json:"refresh,omitempty"`
+	// Return the address built from the domain's inferred pattern without SMTP
+	// verification; has no effect when no pattern has been inferred
+	SkipVerification bool `protobuf:"varint,6,opt,name=skip_verification,json=skipVerification,proto3" json:"skip_verification,omitempty"`
+}
+
+func (x *FindEmailRequest) Reset() {
+	*x = FindEmailRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindEmailRequest) ProtoMessage() {}
+
+func (x *FindEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindEmailRequest.ProtoReflect.Descriptor instead.
+func (*FindEmailRequest) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FindEmailRequest) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *FindEmailRequest) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *FindEmailRequest) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+func (x *FindEmailRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
+func (x *FindEmailRequest) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
+	}
+	return false
+}
+
+func (x *FindEmailRequest) GetSkipVerification() bool {
+	if x != nil {
+		return x.SkipVerification
+	}
+	return false
+}
+
+type FindEmailResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FoundEmails      []*EmailResult `protobuf:"bytes,1,rep,name=found_emails,json=foundEmails,proto3" json:"found_emails,omitempty"`
+	TotalChecked     int32          `protobuf:"varint,2,opt,name=total_checked,json=totalChecked,proto3" json:"total_checked,omitempty"`
+	TotalFound       int32          `protobuf:"varint,3,opt,name=total_found,json=totalFound,proto3" json:"total_found,omitempty"`
+	Domain           string         `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty"`
+	DomainResolved   bool           `protobuf:"varint,5,opt,name=domain_resolved,json=domainResolved,proto3" json:"domain_resolved,omitempty"`
+	InferredPatterns []string       `protobuf:"bytes,6,rep,name=inferred_patterns,json=inferredPatterns,proto3" json:"inferred_patterns,omitempty"`
+	Verified         bool           `protobuf:"varint,7,opt,name=verified,proto3" json:"verified,omitempty"`
+	// Every checked email counted by its is_reachable status
+	StatusBreakdown map[string]int32 `protobuf:"bytes,8,rep,name=status_breakdown,json=statusBreakdown,proto3" json:"status_breakdown,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// The most likely address when no email could be verified
+	BestGuess *EmailResult `protobuf:"bytes,9,opt,name=best_guess,json=bestGuess,proto3" json:"best_guess,omitempty"`
+	// When the addresses were verified, if a recent lookup's results were reused
+	CachedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=cached_at,json=cachedAt,proto3" json:"cached_at,omitempty"`
+}
+
+func (x *FindEmailResponse) Reset() {
+	*x = FindEmailResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindEmailResponse) ProtoMessage() {}
+
+func (x *FindEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindEmailResponse.ProtoReflect.Descriptor instead.
+func (*FindEmailResponse) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FindEmailResponse) GetFoundEmails() []*EmailResult {
+	if x != nil {
+		return x.FoundEmails
+	}
+	return nil
+}
+
+func (x *FindEmailResponse) GetTotalChecked() int32 {
+	if x != nil {
+		return x.TotalChecked
+	}
+	return 0
+}
+
+func (x *FindEmailResponse) GetTotalFound() int32 {
+	if x != nil {
+		return x.TotalFound
+	}
+	return 0
+}
+
+func (x *FindEmailResponse) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *FindEmailResponse) GetDomainResolved() bool {
+	if x != nil {
+		return x.DomainResolved
+	}
+	return false
+}
+
+func (x *FindEmailResponse) GetInferredPatterns() []string {
+	if x != nil {
+		return x.InferredPatterns
+	}
+	return nil
+}
+
+func (x *FindEmailResponse) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *FindEmailResponse) GetStatusBreakdown() map[string]int32 {
+	if x != nil {
+		return x.StatusBreakdown
+	}
+	return nil
+}
+
+func (x *FindEmailResponse) GetBestGuess() *EmailResult {
+	if x != nil {
+		return x.BestGuess
+	}
+	return nil
+}
+
+func (x *FindEmailResponse) GetCachedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CachedAt
+	}
+	return nil
+}
+
+type FindEmailStreamResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*FindEmailStreamResponse_Verification
+	//	*FindEmailStreamResponse_Summary
+	Event isFindEmailStreamResponse_Event `protobuf_oneof:"event"`
+}
+
+func (x *FindEmailStreamResponse) Reset() {
+	*x = FindEmailStreamResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FindEmailStreamResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindEmailStreamResponse) ProtoMessage() {}
+
+func (x *FindEmailStreamResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindEmailStreamResponse.ProtoReflect.Descriptor instead.
+func (*FindEmailStreamResponse) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{2}
+}
+
+func (m *FindEmailStreamResponse) GetEvent() isFindEmailStreamResponse_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *FindEmailStreamResponse) GetVerification() *VerificationResult {
+	if x, ok := x.GetEvent().(*FindEmailStreamResponse_Verification); ok {
+		return x.Verification
+	}
+	return nil
+}
+
+func (x *FindEmailStreamResponse) GetSummary() *FindEmailResponse {
+	if x, ok := x.GetEvent().(*FindEmailStreamResponse_Summary); ok {
+		return x.Summary
+	}
+	return nil
+}
+
+type isFindEmailStreamResponse_Event interface {
+	isFindEmailStreamResponse_Event()
+}
+
+type FindEmailStreamResponse_Verification struct {
+	// A verification that completed
+	Verification *VerificationResult `protobuf:"bytes,1,opt,name=verification,proto3,oneof"`
+}
+
+type FindEmailStreamResponse_Summary struct {
+	// The final result, sent once all verifications are done
+	Summary *FindEmailResponse `protobuf:"bytes,2,opt,name=summary,proto3,oneof"`
+}
+
+func (*FindEmailStreamResponse_Verification) isFindEmailStreamResponse_Event() {}
+
+func (*FindEmailStreamResponse_Summary) isFindEmailStreamResponse_Event() {}
+
+type EmailResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Email         string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Pattern       string `protobuf:"bytes,2,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	IsReachable   string `protobuf:"bytes,3,opt,name=is_reachable,json=isReachable,proto3" json:"is_reachable,omitempty"`
+	IsValid       bool   `protobuf:"varint,4,opt,name=is_valid,json=isValid,proto3" json:"is_valid,omitempty"`
+	IsDeliverable bool   `protobuf:"varint,5,opt,name=is_deliverable,json=isDeliverable,proto3" json:"is_deliverable,omitempty"`
+	// high, medium or low, derived from score
+	Confidence string `protobuf:"bytes,6,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	// Probability-like confidence in the email, 0-100
+	Score          int32           `protobuf:"varint,7,opt,name=score,proto3" json:"score,omitempty"`
+	ScoreBreakdown *ScoreBreakdown `protobuf:"bytes,8,opt,name=score_breakdown,json=scoreBreakdown,proto3" json:"score_breakdown,omitempty"`
+	MxRecords      []string        `protobuf:"bytes,9,rep,name=mx_records,json=mxRecords,proto3" json:"mx_records,omitempty"`
+	CanConnectSmtp bool            `protobuf:"varint,10,opt,name=can_connect_smtp,json=canConnectSmtp,proto3" json:"can_connect_smtp,omitempty"`
+	IsCatchAll     bool            `protobuf:"varint,11,opt,name=is_catch_all,json=isCatchAll,proto3" json:"is_catch_all,omitempty"`
+	IsDisabled     bool            `protobuf:"varint,12,opt,name=is_disabled,json=isDisabled,proto3" json:"is_disabled,omitempty"`
+	HasFullInbox   bool            `protobuf:"varint,13,opt,name=has_full_inbox,json=hasFullInbox,proto3" json:"has_full_inbox,omitempty"`
+	IsDisposable   bool            `protobuf:"varint,14,opt,name=is_disposable,json=isDisposable,proto3" json:"is_disposable,omitempty"`
+	IsRoleAccount  bool            `protobuf:"varint,15,opt,name=is_role_account,json=isRoleAccount,proto3" json:"is_role_account,omitempty"`
+	SmtpError      *CheckError     `protobuf:"bytes,16,opt,name=smtp_error,json=smtpError,proto3" json:"smtp_error,omitempty"`
+	MxError        *CheckError     `protobuf:"bytes,17,opt,name=mx_error,json=mxError,proto3" json:"mx_error,omitempty"`
+}
+
+func (x *EmailResult) Reset() {
+	*x = EmailResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmailResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmailResult) ProtoMessage() {}
+
+func (x *EmailResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmailResult.ProtoReflect.Descriptor instead.
+func (*EmailResult) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EmailResult) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *EmailResult) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *EmailResult) GetIsReachable() string {
+	if x != nil {
+		return x.IsReachable
+	}
+	return ""
+}
+
+func (x *EmailResult) GetIsValid() bool {
+	if x != nil {
+		return x.IsValid
+	}
+	return false
+}
+
+func (x *EmailResult) GetIsDeliverable() bool {
+	if x != nil {
+		return x.IsDeliverable
+	}
+	return false
+}
+
+func (x *EmailResult) GetConfidence() string {
+	if x != nil {
+		return x.Confidence
+	}
+	return ""
+}
+
+func (x *EmailResult) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *EmailResult) GetScoreBreakdown() *ScoreBreakdown {
+	if x != nil {
+		return x.ScoreBreakdown
+	}
+	return nil
+}
+
+func (x *EmailResult) GetMxRecords() []string {
+	if x != nil {
+		return x.MxRecords
+	}
+	return nil
+}
+
+func (x *EmailResult) GetCanConnectSmtp() bool {
+	if x != nil {
+		return x.CanConnectSmtp
+	}
+	return false
+}
+
+func (x *EmailResult) GetIsCatchAll() bool {
+	if x != nil {
+		return x.IsCatchAll
+	}
+	return false
+}
+
+func (x *EmailResult) GetIsDisabled() bool {
+	if x != nil {
+		return x.IsDisabled
+	}
+	return false
+}
+
+func (x *EmailResult) GetHasFullInbox() bool {
+	if x != nil {
+		return x.HasFullInbox
+	}
+	return false
+}
+
+func (x *EmailResult) GetIsDisposable() bool {
+	if x != nil {
+		return x.IsDisposable
+	}
+	return false
+}
+
+func (x *EmailResult) GetIsRoleAccount() bool {
+	if x != nil {
+		return x.IsRoleAccount
+	}
+	return false
+}
+
+func (x *EmailResult) GetSmtpError() *CheckError {
+	if x != nil {
+		return x.SmtpError
+	}
+	return nil
+}
+
+func (x *EmailResult) GetMxError() *CheckError {
+	if x != nil {
+		return x.MxError
+	}
+	return nil
+}
+
+type ScoreBreakdown struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status           int32 `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	Deliverability   int32 `protobuf:"varint,2,opt,name=deliverability,proto3" json:"deliverability,omitempty"`
+	CatchAll         int32 `protobuf:"varint,3,opt,name=catch_all,json=catchAll,proto3" json:"catch_all,omitempty"`
+	PatternPrior     int32 `protobuf:"varint,4,opt,name=pattern_prior,json=patternPrior,proto3" json:"pattern_prior,omitempty"`
+	DomainResolution int32 `protobuf:"varint,5,opt,name=domain_resolution,json=domainResolution,proto3" json:"domain_resolution,omitempty"`
+}
+
+func (x *ScoreBreakdown) Reset() {
+	*x = ScoreBreakdown{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScoreBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreBreakdown) ProtoMessage() {}
+
+func (x *ScoreBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreBreakdown.ProtoReflect.Descriptor instead.
+func (*ScoreBreakdown) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ScoreBreakdown) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetDeliverability() int32 {
+	if x != nil {
+		return x.Deliverability
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetCatchAll() int32 {
+	if x != nil {
+		return x.CatchAll
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetPatternPrior() int32 {
+	if x != nil {
+		return x.PatternPrior
+	}
+	return 0
+}
+
+func (x *ScoreBreakdown) GetDomainResolution() int32 {
+	if x != nil {
+		return x.DomainResolution
+	}
+	return 0
+}
+
+type VerifyEmailRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+}
+
+func (x *VerifyEmailRequest) Reset() {
+	*x = VerifyEmailRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailRequest) ProtoMessage() {}
+
+func (x *VerifyEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailRequest) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *VerifyEmailRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type VerifyEmailsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Emails []string `protobuf:"bytes,1,rep,name=emails,proto3" json:"emails,omitempty"`
+}
+
+func (x *VerifyEmailsRequest) Reset() {
+	*x = VerifyEmailsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyEmailsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailsRequest) ProtoMessage() {}
+
+func (x *VerifyEmailsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailsRequest.ProtoReflect.Descriptor instead.
+func (*VerifyEmailsRequest) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VerifyEmailsRequest) GetEmails() []string {
+	if x != nil {
+		return x.Emails
+	}
+	return nil
+}
+
+type VerifyEmailsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// One result per requested email, in request order
+	Results []*VerificationResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *VerifyEmailsResponse) Reset() {
+	*x = VerifyEmailsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerifyEmailsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailsResponse) ProtoMessage() {}
+
+func (x *VerifyEmailsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailsResponse.ProtoReflect.Descriptor instead.
+func (*VerifyEmailsResponse) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VerifyEmailsResponse) GetResults() []*VerificationResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type VerificationResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Email string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	// safe, risky, invalid or unknown
+	IsReachable   string         `protobuf:"bytes,2,opt,name=is_reachable,json=isReachable,proto3" json:"is_reachable,omitempty"`
+	IsValid       bool           `protobuf:"varint,3,opt,name=is_valid,json=isValid,proto3" json:"is_valid,omitempty"`
+	IsDeliverable bool           `protobuf:"varint,4,opt,name=is_deliverable,json=isDeliverable,proto3" json:"is_deliverable,omitempty"`
+	Syntax        *SyntaxDetails `protobuf:"bytes,5,opt,name=syntax,proto3" json:"syntax,omitempty"`
+	Mx            *MXDetails     `protobuf:"bytes,6,opt,name=mx,proto3" json:"mx,omitempty"`
+	Smtp          *SMTPDetails   `protobuf:"bytes,7,opt,name=smtp,proto3" json:"smtp,omitempty"`
+	Misc          *MiscDetails   `protobuf:"bytes,8,opt,name=misc,proto3" json:"misc,omitempty"`
+	MxError       *CheckError    `protobuf:"bytes,9,opt,name=mx_error,json=mxError,proto3" json:"mx_error,omitempty"`
+	SmtpError     *CheckError    `protobuf:"bytes,10,opt,name=smtp_error,json=smtpError,proto3" json:"smtp_error,omitempty"`
+	MiscError     *CheckError    `protobuf:"bytes,11,opt,name=misc_error,json=miscError,proto3" json:"misc_error,omitempty"`
+	// Why the result is unknown; unset when the check completed
+	Failure *Failure `protobuf:"bytes,12,opt,name=failure,proto3" json:"failure,omitempty"`
+}
+
+func (x *VerificationResult) Reset() {
+	*x = VerificationResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VerificationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerificationResult) ProtoMessage() {}
+
+func (x *VerificationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerificationResult.ProtoReflect.Descriptor instead.
+func (*VerificationResult) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VerificationResult) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *VerificationResult) GetIsReachable() string {
+	if x != nil {
+		return x.IsReachable
+	}
+	return ""
+}
+
+func (x *VerificationResult) GetIsValid() bool {
+	if x != nil {
+		return x.IsValid
+	}
+	return false
+}
+
+func (x *VerificationResult) GetIsDeliverable() bool {
+	if x != nil {
+		return x.IsDeliverable
+	}
+	return false
+}
+
+func (x *VerificationResult) GetSyntax() *SyntaxDetails {
+	if x != nil {
+		return x.Syntax
+	}
+	return nil
+}
+
+func (x *VerificationResult) GetMx() *MXDetails {
+	if x != nil {
+		return x.Mx
+	}
+	return nil
+}
+
+func (x *VerificationResult) GetSmtp() *SMTPDetails {
+	if x != nil {
+		return x.Smtp
+	}
+	return nil
+}
+
+func (x *VerificationResult) GetMisc() *MiscDetails {
+	if x != nil {
+		return x.Misc
+	}
+	return nil
+}
+
+func (x *VerificationResult) GetMxError() *CheckError {
+	if x != nil {
+		return x.MxError
+	}
+	return nil
+}
+
+func (x *VerificationResult) GetSmtpError() *CheckError {
+	if x != nil {
+		return x.SmtpError
+	}
+	return nil
+}
+
+func (x *VerificationResult) GetMiscError() *CheckError {
+	if x != nil {
+		return x.MiscError
+	}
+	return nil
+}
+
+func (x *VerificationResult) GetFailure() *Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type SyntaxDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address       string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Domain        string `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	Username      string `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	IsValidSyntax bool   `protobuf:"varint,4,opt,name=is_valid_syntax,json=isValidSyntax,proto3" json:"is_valid_syntax,omitempty"`
+}
+
+func (x *SyntaxDetails) Reset() {
+	*x = SyntaxDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyntaxDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyntaxDetails) ProtoMessage() {}
+
+func (x *SyntaxDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyntaxDetails.ProtoReflect.Descriptor instead.
+func (*SyntaxDetails) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SyntaxDetails) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *SyntaxDetails) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *SyntaxDetails) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *SyntaxDetails) GetIsValidSyntax() bool {
+	if x != nil {
+		return x.IsValidSyntax
+	}
+	return false
+}
+
+type MXDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AcceptsMail bool     `protobuf:"varint,1,opt,name=accepts_mail,json=acceptsMail,proto3" json:"accepts_mail,omitempty"`
+	Records     []string `protobuf:"bytes,2,rep,name=records,proto3" json:"records,omitempty"`
+}
+
+func (x *MXDetails) Reset() {
+	*x = MXDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MXDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MXDetails) ProtoMessage() {}
+
+func (x *MXDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MXDetails.ProtoReflect.Descriptor instead.
+func (*MXDetails) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MXDetails) GetAcceptsMail() bool {
+	if x != nil {
+		return x.AcceptsMail
+	}
+	return false
+}
+
+func (x *MXDetails) GetRecords() []string {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type SMTPDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CanConnectSmtp bool `protobuf:"varint,1,opt,name=can_connect_smtp,json=canConnectSmtp,proto3" json:"can_connect_smtp,omitempty"`
+	HasFullInbox   bool `protobuf:"varint,2,opt,name=has_full_inbox,json=hasFullInbox,proto3" json:"has_full_inbox,omitempty"`
+	IsCatchAll     bool `protobuf:"varint,3,opt,name=is_catch_all,json=isCatchAll,proto3" json:"is_catch_all,omitempty"`
+	IsDeliverable  bool `protobuf:"varint,4,opt,name=is_deliverable,json=isDeliverable,proto3" json:"is_deliverable,omitempty"`
+	IsDisabled     bool `protobuf:"varint,5,opt,name=is_disabled,json=isDisabled,proto3" json:"is_disabled,omitempty"`
+}
+
+func (x *SMTPDetails) Reset() {
+	*x = SMTPDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SMTPDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SMTPDetails) ProtoMessage() {}
+
+func (x *SMTPDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SMTPDetails.ProtoReflect.Descriptor instead.
+func (*SMTPDetails) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SMTPDetails) GetCanConnectSmtp() bool {
+	if x != nil {
+		return x.CanConnectSmtp
+	}
+	return false
+}
+
+func (x *SMTPDetails) GetHasFullInbox() bool {
+	if x != nil {
+		return x.HasFullInbox
+	}
+	return false
+}
+
+func (x *SMTPDetails) GetIsCatchAll() bool {
+	if x != nil {
+		return x.IsCatchAll
+	}
+	return false
+}
+
+func (x *SMTPDetails) GetIsDeliverable() bool {
+	if x != nil {
+		return x.IsDeliverable
+	}
+	return false
+}
+
+func (x *SMTPDetails) GetIsDisabled() bool {
+	if x != nil {
+		return x.IsDisabled
+	}
+	return false
+}
+
+type MiscDetails struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IsDisposable  bool   `protobuf:"varint,1,opt,name=is_disposable,json=isDisposable,proto3" json:"is_disposable,omitempty"`
+	IsRoleAccount bool   `protobuf:"varint,2,opt,name=is_role_account,json=isRoleAccount,proto3" json:"is_role_account,omitempty"`
+	GravatarUrl   string `protobuf:"bytes,3,opt,name=gravatar_url,json=gravatarUrl,proto3" json:"gravatar_url,omitempty"`
+}
+
+func (x *MiscDetails) Reset() {
+	*x = MiscDetails{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MiscDetails) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MiscDetails) ProtoMessage() {}
+
+func (x *MiscDetails) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MiscDetails.ProtoReflect.Descriptor instead.
+func (*MiscDetails) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MiscDetails) GetIsDisposable() bool {
+	if x != nil {
+		return x.IsDisposable
+	}
+	return false
+}
+
+func (x *MiscDetails) GetIsRoleAccount() bool {
+	if x != nil {
+		return x.IsRoleAccount
+	}
+	return false
+}
+
+func (x *MiscDetails) GetGravatarUrl() string {
+	if x != nil {
+		return x.GravatarUrl
+	}
+	return ""
+}
+
+type CheckError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type    string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// SMTP reply code, when the message contains one
+	Code int32 `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+}
+
+func (x *CheckError) Reset() {
+	*x = CheckError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckError) ProtoMessage() {}
+
+func (x *CheckError) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckError.ProtoReflect.Descriptor instead.
+func (*CheckError) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CheckError) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CheckError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CheckError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+type Failure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// timeout, backend or smtp
+	Kind       string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Message    string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Transient  bool   `protobuf:"varint,3,opt,name=transient,proto3" json:"transient,omitempty"`
+	Greylisted bool   `protobuf:"varint,4,opt,name=greylisted,proto3" json:"greylisted,omitempty"`
+}
+
+func (x *Failure) Reset() {
+	*x = Failure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Failure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Failure) ProtoMessage() {}
+
+func (x *Failure) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Failure.ProtoReflect.Descriptor instead.
+func (*Failure) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Failure) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Failure) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Failure) GetTransient() bool {
+	if x != nil {
+		return x.Transient
+	}
+	return false
+}
+
+func (x *Failure) GetGreylisted() bool {
+	if x != nil {
+		return x.Greylisted
+	}
+	return false
+}
+
+type ResolveDomainRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Company string `protobuf:"bytes,1,opt,name=company,proto3" json:"company,omitempty"`
+}
+
+func (x *ResolveDomainRequest) Reset() {
+	*x = ResolveDomainRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResolveDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveDomainRequest) ProtoMessage() {}
+
+func (x *ResolveDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveDomainRequest.ProtoReflect.Descriptor instead.
+func (*ResolveDomainRequest) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ResolveDomainRequest) GetCompany() string {
+	if x != nil {
+		return x.Company
+	}
+	return ""
+}
+
+type DomainResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Domain     string   `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Resolved   bool     `protobuf:"varint,2,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	Method     string   `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	Candidates []string `protobuf:"bytes,4,rep,name=candidates,proto3" json:"candidates,omitempty"`
+}
+
+func (x *DomainResult) Reset() {
+	*x = DomainResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainResult) ProtoMessage() {}
+
+func (x *DomainResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_emailfinder_v1_emailfinder_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainResult.ProtoReflect.Descriptor instead.
+func (*DomainResult) Descriptor() ([]byte, []int) {
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DomainResult) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *DomainResult) GetResolved() bool {
+	if x != nil {
+		return x.Resolved
+	}
+	return false
+}
+
+func (x *DomainResult) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *DomainResult) GetCandidates() []string {
+	if x != nil {
+		return x.Candidates
+	}
+	return nil
+}
+
+var File_api_emailfinder_v1_emailfinder_proto protoreflect.FileDescriptor
+
+var file_api_emailfinder_v1_emailfinder_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x61, 0x70, 0x69, 0x2f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65,
+	0x72, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e,
+	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc9, 0x01, 0x0a, 0x10, 0x46, 0x69, 0x6e, 0x64,
+	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x66, 0x69, 0x72, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6c, 0x61, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61,
+	0x6e, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72,
+	0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x12, 0x2b, 0x0a, 0x11, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x76,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x10, 0x73, 0x6b, 0x69, 0x70, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0xbf, 0x04, 0x0a, 0x11, 0x46, 0x69, 0x6e, 0x64, 0x45, 0x6d, 0x61, 0x69,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0c, 0x66, 0x6f, 0x75,
+	0x6e, 0x64, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0b, 0x66, 0x6f,
+	0x75, 0x6e, 0x64, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x46, 0x6f, 0x75, 0x6e, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0e, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64,
+	0x12, 0x2b, 0x0a, 0x11, 0x69, 0x6e, 0x66, 0x65, 0x72, 0x72, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x69, 0x6e, 0x66,
+	0x65, 0x72, 0x72, 0x65, 0x64, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x65, 0x64, 0x12, 0x61, 0x0a, 0x10, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x72, 0x65,
+	0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x3a, 0x0a, 0x0a,
+	0x62, 0x65, 0x73, 0x74, 0x5f, 0x67, 0x75, 0x65, 0x73, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x09, 0x62,
+	0x65, 0x73, 0x74, 0x47, 0x75, 0x65, 0x73, 0x73, 0x12, 0x37, 0x0a, 0x09, 0x63, 0x61, 0x63, 0x68,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x41,
+	0x74, 0x1a, 0x42, 0x0a, 0x14, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x72, 0x65, 0x61, 0x6b,
+	0x64, 0x6f, 0x77, 0x6e, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xab, 0x01, 0x0a, 0x17, 0x46, 0x69, 0x6e, 0x64, 0x45, 0x6d,
+	0x61, 0x69, 0x6c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x48, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66,
+	0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x0c, 0x76,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x07, 0x73,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69,
+	0x6e, 0x64, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x00, 0x52, 0x07, 0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x22, 0x92, 0x05, 0x0a, 0x0b, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x73, 0x5f, 0x72, 0x65, 0x61, 0x63, 0x68, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x73, 0x52, 0x65, 0x61,
+	0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x56, 0x61, 0x6c, 0x69,
+	0x64, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x61,
+	0x62, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x73, 0x44, 0x65, 0x6c,
+	0x69, 0x76, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x47,
+	0x0a, 0x0f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77,
+	0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66,
+	0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x72,
+	0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x0e, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x42, 0x72,
+	0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x78, 0x5f, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x6d, 0x78, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x63, 0x61, 0x6e, 0x5f, 0x63, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x5f, 0x73, 0x6d, 0x74, 0x70, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0e, 0x63, 0x61, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x53, 0x6d, 0x74, 0x70,
+	0x12, 0x20, 0x0a, 0x0c, 0x69, 0x73, 0x5f, 0x63, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x61, 0x6c, 0x6c,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x43, 0x61, 0x74, 0x63, 0x68, 0x41,
+	0x6c, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x44, 0x69, 0x73, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x68, 0x61, 0x73, 0x5f, 0x66, 0x75, 0x6c, 0x6c, 0x5f,
+	0x69, 0x6e, 0x62, 0x6f, 0x78, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x68, 0x61, 0x73,
+	0x46, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x62, 0x6f, 0x78, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x73, 0x5f,
+	0x64, 0x69, 0x73, 0x70, 0x6f, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0c, 0x69, 0x73, 0x44, 0x69, 0x73, 0x70, 0x6f, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x26,
+	0x0a, 0x0f, 0x69, 0x73, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x73, 0x52, 0x6f, 0x6c, 0x65, 0x41,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x6d, 0x74, 0x70, 0x5f, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65, 0x6d, 0x61,
+	0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x09, 0x73, 0x6d, 0x74, 0x70, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x35, 0x0a, 0x08, 0x6d, 0x78, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
+	0x07, 0x6d, 0x78, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xbf, 0x01, 0x0a, 0x0e, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x64, 0x65, 0x6c,
+	0x69, 0x76, 0x65, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x63,
+	0x61, 0x74, 0x63, 0x68, 0x5f, 0x61, 0x6c, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x63, 0x61, 0x74, 0x63, 0x68, 0x41, 0x6c, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x6e, 0x5f, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0c, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x12, 0x2b, 0x0a,
+	0x11, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2a, 0x0a, 0x12, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0x2d, 0x0a, 0x13, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x73, 0x22, 0x54, 0x0a, 0x14, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x45,
+	0x6d, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a,
+	0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22,
+	0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xb3, 0x04, 0x0a, 0x12,
+	0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x73, 0x5f, 0x72,
+	0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x69, 0x73, 0x52, 0x65, 0x61, 0x63, 0x68, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69,
+	0x73, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69,
+	0x73, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x6c,
+	0x69, 0x76, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
+	0x69, 0x73, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x35, 0x0a,
+	0x06, 0x73, 0x79, 0x6e, 0x74, 0x61, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x79, 0x6e, 0x74, 0x61, 0x78, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x06, 0x73, 0x79,
+	0x6e, 0x74, 0x61, 0x78, 0x12, 0x29, 0x0a, 0x02, 0x6d, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x4d, 0x58, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x02, 0x6d, 0x78, 0x12,
+	0x2f, 0x0a, 0x04, 0x73, 0x6d, 0x74, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x4d, 0x54, 0x50, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x04, 0x73, 0x6d, 0x74, 0x70,
+	0x12, 0x2f, 0x0a, 0x04, 0x6d, 0x69, 0x73, 0x63, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x4d, 0x69, 0x73, 0x63, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x04, 0x6d, 0x69, 0x73,
+	0x63, 0x12, 0x35, 0x0a, 0x08, 0x6d, 0x78, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
+	0x07, 0x6d, 0x78, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x6d, 0x74, 0x70,
+	0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x09, 0x73, 0x6d, 0x74, 0x70, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x39, 0x0a, 0x0a, 0x6d, 0x69, 0x73, 0x63, 0x5f, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66,
+	0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x52, 0x09, 0x6d, 0x69, 0x73, 0x63, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x31,
+	0x0a, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x22, 0x85, 0x01, 0x0a, 0x0d, 0x53, 0x79, 0x6e, 0x74, 0x61, 0x78, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x26, 0x0a, 0x0f, 0x69, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x73, 0x79,
+	0x6e, 0x74, 0x61, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x73, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x53, 0x79, 0x6e, 0x74, 0x61, 0x78, 0x22, 0x48, 0x0a, 0x09, 0x4d, 0x58, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74,
+	0x73, 0x5f, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x63,
+	0x63, 0x65, 0x70, 0x74, 0x73, 0x4d, 0x61, 0x69, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x22, 0xc7, 0x01, 0x0a, 0x0b, 0x53, 0x4d, 0x54, 0x50, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x63, 0x61, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x5f, 0x73, 0x6d, 0x74, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x63,
+	0x61, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x53, 0x6d, 0x74, 0x70, 0x12, 0x24, 0x0a,
+	0x0e, 0x68, 0x61, 0x73, 0x5f, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x69, 0x6e, 0x62, 0x6f, 0x78, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x68, 0x61, 0x73, 0x46, 0x75, 0x6c, 0x6c, 0x49, 0x6e,
+	0x62, 0x6f, 0x78, 0x12, 0x20, 0x0a, 0x0c, 0x69, 0x73, 0x5f, 0x63, 0x61, 0x74, 0x63, 0x68, 0x5f,
+	0x61, 0x6c, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x43, 0x61, 0x74,
+	0x63, 0x68, 0x41, 0x6c, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x6c, 0x69,
+	0x76, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69,
+	0x73, 0x44, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
+	0x69, 0x73, 0x5f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x69, 0x73, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x7d, 0x0a,
+	0x0b, 0x4d, 0x69, 0x73, 0x63, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x23, 0x0a, 0x0d,
+	0x69, 0x73, 0x5f, 0x64, 0x69, 0x73, 0x70, 0x6f, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x73, 0x44, 0x69, 0x73, 0x70, 0x6f, 0x73, 0x61, 0x62, 0x6c,
+	0x65, 0x12, 0x26, 0x0a, 0x0f, 0x69, 0x73, 0x5f, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x73, 0x52, 0x6f,
+	0x6c, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x72, 0x61,
+	0x76, 0x61, 0x74, 0x61, 0x72, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x67, 0x72, 0x61, 0x76, 0x61, 0x74, 0x61, 0x72, 0x55, 0x72, 0x6c, 0x22, 0x4e, 0x0a, 0x0a,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x22, 0x75, 0x0a, 0x07,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69, 0x65,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x69,
+	0x65, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x72, 0x65, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x67, 0x72, 0x65, 0x79, 0x6c, 0x69, 0x73,
+	0x74, 0x65, 0x64, 0x22, 0x30, 0x0a, 0x14, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x22, 0x7a, 0x0a, 0x0c, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x65,
+	0x73, 0x32, 0xcd, 0x03, 0x0a, 0x12, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x46, 0x69, 0x6e, 0x64, 0x65,
+	0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x09, 0x46, 0x69, 0x6e, 0x64,
+	0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x20, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e,
+	0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x45, 0x6d, 0x61, 0x69, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66,
+	0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x45, 0x6d, 0x61,
+	0x69, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e, 0x0a, 0x0f, 0x46, 0x69,
+	0x6e, 0x64, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x20, 0x2e,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46,
+	0x69, 0x6e, 0x64, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x27, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x69, 0x6e, 0x64, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x55, 0x0a, 0x0b, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x22, 0x2e, 0x65, 0x6d, 0x61, 0x69,
+	0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66,
+	0x79, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x59, 0x0a, 0x0c, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x45, 0x6d, 0x61, 0x69, 0x6c,
+	0x73, 0x12, 0x23, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69,
+	0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x45, 0x6d,
+	0x61, 0x69, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x0d,
+	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x24, 0x2e,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x42, 0x2f, 0x5a, 0x2d, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x2d, 0x66, 0x69, 0x6e, 0x64, 0x65,
+	0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65,
+	0x72, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x66, 0x69, 0x6e, 0x64, 0x65, 0x72,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_api_emailfinder_v1_emailfinder_proto_rawDescOnce sync.Once
+	file_api_emailfinder_v1_emailfinder_proto_rawDescData = file_api_emailfinder_v1_emailfinder_proto_rawDesc
+)
+
+func file_api_emailfinder_v1_emailfinder_proto_rawDescGZIP() []byte {
+	file_api_emailfinder_v1_emailfinder_proto_rawDescOnce.Do(func() {
+		file_api_emailfinder_v1_emailfinder_proto_rawDescData = protoimpl.X.CompressGZIP(file_api_emailfinder_v1_emailfinder_proto_rawDescData)
+	})
+	return file_api_emailfinder_v1_emailfinder_proto_rawDescData
+}
+
+var file_api_emailfinder_v1_emailfinder_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_api_emailfinder_v1_emailfinder_proto_goTypes = []interface{}{
+	(*FindEmailRequest)(nil),        // 0: emailfinder.v1.FindEmailRequest
+	(*FindEmailResponse)(nil),       // 1: emailfinder.v1.FindEmailResponse
+	(*FindEmailStreamResponse)(nil), // 2: emailfinder.v1.FindEmailStreamResponse
+	(*EmailResult)(nil),             // 3: emailfinder.v1.EmailResult
+	(*ScoreBreakdown)(nil),          // 4: emailfinder.v1.ScoreBreakdown
+	(*VerifyEmailRequest)(nil),      // 5: emailfinder.v1.VerifyEmailRequest
+	(*VerifyEmailsRequest)(nil),     // 6: emailfinder.v1.VerifyEmailsRequest
+	(*VerifyEmailsResponse)(nil),    // 7: emailfinder.v1.VerifyEmailsResponse
+	(*VerificationResult)(nil),      // 8: emailfinder.v1.VerificationResult
+	(*SyntaxDetails)(nil),           // 9: emailfinder.v1.SyntaxDetails
+	(*MXDetails)(nil),               // 10: emailfinder.v1.MXDetails
+	(*SMTPDetails)(nil),             // 11: emailfinder.v1.SMTPDetails
+	(*MiscDetails)(nil),             // 12: emailfinder.v1.MiscDetails
+	(*CheckError)(nil),              // 13: emailfinder.v1.CheckError
+	(*Failure)(nil),                 // 14: emailfinder.v1.Failure
+	(*ResolveDomainRequest)(nil),    // 15: emailfinder.v1.ResolveDomainRequest
+	(*DomainResult)(nil),            // 16: emailfinder.v1.DomainResult
+	nil,                             // 17: emailfinder.v1.FindEmailResponse.StatusBreakdownEntry
+	(*timestamppb.Timestamp)(nil),   // 18: google.protobuf.Timestamp
+}
+var file_api_emailfinder_v1_emailfinder_proto_depIdxs = []int32{
+	3,  // 0: emailfinder.v1.FindEmailResponse.found_emails:type_name -> emailfinder.v1.EmailResult
+	17, // 1: emailfinder.v1.FindEmailResponse.status_breakdown:type_name -> emailfinder.v1.FindEmailResponse.StatusBreakdownEntry
+	3,  // 2: emailfinder.v1.FindEmailResponse.best_guess:type_name -> emailfinder.v1.EmailResult
+	18, // 3: emailfinder.v1.FindEmailResponse.cached_at:type_name -> google.protobuf.Timestamp
+	8,  // 4: emailfinder.v1.FindEmailStreamResponse.verification:type_name -> emailfinder.v1.VerificationResult
+	1,  // 5: emailfinder.v1.FindEmailStreamResponse.summary:type_name -> emailfinder.v1.FindEmailResponse
+	4,  // 6: emailfinder.v1.EmailResult.score_breakdown:type_name -> emailfinder.v1.ScoreBreakdown
+	13, // 7: emailfinder.v1.EmailResult.smtp_error:type_name -> emailfinder.v1.CheckError
+	13, // 8: emailfinder.v1.EmailResult.mx_error:type_name -> emailfinder.v1.CheckError
+	8,  // 9: emailfinder.v1.VerifyEmailsResponse.results:type_name -> emailfinder.v1.VerificationResult
+	9,  // 10: emailfinder.v1.VerificationResult.syntax:type_name -> emailfinder.v1.SyntaxDetails
+	10, // 11: emailfinder.v1.VerificationResult.mx:type_name -> emailfinder.v1.MXDetails
+	11, // 12: emailfinder.v1.VerificationResult.smtp:type_name -> emailfinder.v1.SMTPDetails
+	12, // 13: emailfinder.v1.VerificationResult.misc:type_name -> emailfinder.v1.MiscDetails
+	13, // 14: emailfinder.v1.VerificationResult.mx_error:type_name -> emailfinder.v1.CheckError
+	13, // 15: emailfinder.v1.VerificationResult.smtp_error:type_name -> emailfinder.v1.CheckError
+	13, // 16: emailfinder.v1.VerificationResult.misc_error:type_name -> emailfinder.v1.CheckError
+	14, // 17: emailfinder.v1.VerificationResult.failure:type_name -> emailfinder.v1.Failure
+	0,  // 18: emailfinder.v1.EmailFinderService.FindEmail:input_type -> emailfinder.v1.FindEmailRequest
+	0,  // 19: emailfinder.v1.EmailFinderService.FindEmailStream:input_type -> emailfinder.v1.FindEmailRequest
+	5,  // 20: emailfinder.v1.EmailFinderService.VerifyEmail:input_type -> emailfinder.v1.VerifyEmailRequest
+	6,  // 21: emailfinder.v1.EmailFinderService.VerifyEmails:input_type -> emailfinder.v1.VerifyEmailsRequest
+	15, // 22: emailfinder.v1.EmailFinderService.ResolveDomain:input_type -> emailfinder.v1.ResolveDomainRequest
+	1,  // 23: emailfinder.v1.EmailFinderService.FindEmail:output_type -> emailfinder.v1.FindEmailResponse
+	2,  // 24: emailfinder.v1.EmailFinderService.FindEmailStream:output_type -> emailfinder.v1.FindEmailStreamResponse
+	8,  // 25: emailfinder.v1.EmailFinderService.VerifyEmail:output_type -> emailfinder.v1.VerificationResult
+	7,  // 26: emailfinder.v1.EmailFinderService.VerifyEmails:output_type -> emailfinder.v1.VerifyEmailsResponse
+	16, // 27: emailfinder.v1.EmailFinderService.ResolveDomain:output_type -> emailfinder.v1.DomainResult
+	23, // [23:28] is the sub-list for method output_type
+	18, // [18:23] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_api_emailfinder_v1_emailfinder_proto_init() }
+func file_api_emailfinder_v1_emailfinder_proto_init() {
+	if File_api_emailfinder_v1_emailfinder_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindEmailRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindEmailResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FindEmailStreamResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmailResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScoreBreakdown); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyEmailRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyEmailsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerifyEmailsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VerificationResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyntaxDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MXDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SMTPDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MiscDetails); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Failure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveDomainRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_emailfinder_v1_emailfinder_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_api_emailfinder_v1_emailfinder_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*FindEmailStreamResponse_Verification)(nil),
+		(*FindEmailStreamResponse_Summary)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_api_emailfinder_v1_emailfinder_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_emailfinder_v1_emailfinder_proto_goTypes,
+		DependencyIndexes: file_api_emailfinder_v1_emailfinder_proto_depIdxs,
+		MessageInfos:      file_api_emailfinder_v1_emailfinder_proto_msgTypes,
+	}.Build()
+	File_api_emailfinder_v1_emailfinder_proto = out.File
+	file_api_emailfinder_v1_emailfinder_proto_rawDesc = nil
+	file_api_emailfinder_v1_emailfinder_proto_goTypes = nil
+	file_api_emailfinder_v1_emailfinder_proto_depIdxs = nil
+}
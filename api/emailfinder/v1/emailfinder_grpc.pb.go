@@ -0,0 +1,301 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.25.3
+// source: api/emailfinder/v1/emailfinder.proto
+
+package emailfinderv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	EmailFinderService_FindEmail_FullMethodName       = "/emailfinder.v1.EmailFinderService/FindEmail"
+	EmailFinderService_FindEmailStream_FullMethodName = "/emailfinder.v1.EmailFinderService/FindEmailStream"
+	EmailFinderService_VerifyEmail_FullMethodName     = "/emailfinder.v1.EmailFinderService/VerifyEmail"
+	EmailFinderService_VerifyEmails_FullMethodName    = "/emailfinder.v1.EmailFinderService/VerifyEmails"
+	EmailFinderService_ResolveDomain_FullMethodName   = "/emailfinder.v1.EmailFinderService/ResolveDomain"
+)
+
+// EmailFinderServiceClient is the client API for EmailFinderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EmailFinderServiceClient interface {
+	// FindEmail resolves the company's domain, generates the likely addresses
+	// and verifies them, like POST /api/v1/find-email.
+	FindEmail(ctx context.Context, in *FindEmailRequest, opts ...grpc.CallOption) (*FindEmailResponse, error)
+	// FindEmailStream is FindEmail, sending each verification result as soon as
+	// it completes and the summary last.
+	FindEmailStream(ctx context.Context, in *FindEmailRequest, opts ...grpc.CallOption) (EmailFinderService_FindEmailStreamClient, error)
+	// VerifyEmail verifies one address as-is.
+	VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerificationResult, error)
+	// VerifyEmails verifies up to 100 addresses as-is, like POST /api/v1/verify.
+	VerifyEmails(ctx context.Context, in *VerifyEmailsRequest, opts ...grpc.CallOption) (*VerifyEmailsResponse, error)
+	// ResolveDomain looks up the email domain of a company, like
+	// POST /api/v1/resolve-domain.
+	ResolveDomain(ctx context.Context, in *ResolveDomainRequest, opts ...grpc.CallOption) (*DomainResult, error)
+}
+
+type emailFinderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEmailFinderServiceClient(cc grpc.ClientConnInterface) EmailFinderServiceClient {
+	return &emailFinderServiceClient{cc}
+}
+
+func (c *emailFinderServiceClient) FindEmail(ctx context.Context, in *FindEmailRequest, opts ...grpc.CallOption) (*FindEmailResponse, error) {
+	out := new(FindEmailResponse)
+	err := c.cc.Invoke(ctx, EmailFinderService_FindEmail_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailFinderServiceClient) FindEmailStream(ctx context.Context, in *FindEmailRequest, opts ...grpc.CallOption) (EmailFinderService_FindEmailStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EmailFinderService_ServiceDesc.Streams[0], EmailFinderService_FindEmailStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &emailFinderServiceFindEmailStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EmailFinderService_FindEmailStreamClient interface {
+	Recv() (*FindEmailStreamResponse, error)
+	grpc.ClientStream
+}
+
+type emailFinderServiceFindEmailStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *emailFinderServiceFindEmailStreamClient) Recv() (*FindEmailStreamResponse, error) {
+	m := new(FindEmailStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *emailFinderServiceClient) VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerificationResult, error) {
+	out := new(VerificationResult)
+	err := c.cc.Invoke(ctx, EmailFinderService_VerifyEmail_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailFinderServiceClient) VerifyEmails(ctx context.Context, in *VerifyEmailsRequest, opts ...grpc.CallOption) (*VerifyEmailsResponse, error) {
+	out := new(VerifyEmailsResponse)
+	err := c.cc.Invoke(ctx, EmailFinderService_VerifyEmails_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *emailFinderServiceClient) ResolveDomain(ctx context.Context, in *ResolveDomainRequest, opts ...grpc.CallOption) (*DomainResult, error) {
+	out := new(DomainResult)
+	err := c.cc.Invoke(ctx, EmailFinderService_ResolveDomain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EmailFinderServiceServer is the server API for EmailFinderService service.
+// All implementations must embed UnimplementedEmailFinderServiceServer
+// for forward compatibility
+type EmailFinderServiceServer interface {
+	// FindEmail resolves the company's domain, generates the likely addresses
+	// and verifies them, like POST /api/v1/find-email.
+	FindEmail(context.Context, *FindEmailRequest) (*FindEmailResponse, error)
+	// FindEmailStream is FindEmail, sending each verification result as soon as
+	// it completes and the summary last.
+	FindEmailStream(*FindEmailRequest, EmailFinderService_FindEmailStreamServer) error
+	// VerifyEmail verifies one address as-is.
+	VerifyEmail(context.Context, *VerifyEmailRequest) (*VerificationResult, error)
+	// VerifyEmails verifies up to 100 addresses as-is, like POST /api/v1/verify.
+	VerifyEmails(context.Context, *VerifyEmailsRequest) (*VerifyEmailsResponse, error)
+	// ResolveDomain looks up the email domain of a company, like
+	// POST /api/v1/resolve-domain.
+	ResolveDomain(context.Context, *ResolveDomainRequest) (*DomainResult, error)
+	mustEmbedUnimplementedEmailFinderServiceServer()
+}
+
+// UnimplementedEmailFinderServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedEmailFinderServiceServer struct {
+}
+
+func (UnimplementedEmailFinderServiceServer) FindEmail(context.Context, *FindEmailRequest) (*FindEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindEmail not implemented")
+}
+func (UnimplementedEmailFinderServiceServer) FindEmailStream(*FindEmailRequest, EmailFinderService_FindEmailStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method FindEmailStream not implemented")
+}
+func (UnimplementedEmailFinderServiceServer) VerifyEmail(context.Context, *VerifyEmailRequest) (*VerificationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
+}
+func (UnimplementedEmailFinderServiceServer) VerifyEmails(context.Context, *VerifyEmailsRequest) (*VerifyEmailsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmails not implemented")
+}
+func (UnimplementedEmailFinderServiceServer) ResolveDomain(context.Context, *ResolveDomainRequest) (*DomainResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveDomain not implemented")
+}
+func (UnimplementedEmailFinderServiceServer) mustEmbedUnimplementedEmailFinderServiceServer() {}
+
+// UnsafeEmailFinderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EmailFinderServiceServer will
+// result in compilation errors.
+type UnsafeEmailFinderServiceServer interface {
+	mustEmbedUnimplementedEmailFinderServiceServer()
+}
+
+func RegisterEmailFinderServiceServer(s grpc.ServiceRegistrar, srv EmailFinderServiceServer) {
+	s.RegisterService(&EmailFinderService_ServiceDesc, srv)
+}
+
+func _EmailFinderService_FindEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailFinderServiceServer).FindEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmailFinderService_FindEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailFinderServiceServer).FindEmail(ctx, req.(*FindEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailFinderService_FindEmailStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindEmailRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EmailFinderServiceServer).FindEmailStream(m, &emailFinderServiceFindEmailStreamServer{stream})
+}
+
+type EmailFinderService_FindEmailStreamServer interface {
+	Send(*FindEmailStreamResponse) error
+	grpc.ServerStream
+}
+
+type emailFinderServiceFindEmailStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *emailFinderServiceFindEmailStreamServer) Send(m *FindEmailStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _EmailFinderService_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailFinderServiceServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmailFinderService_VerifyEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailFinderServiceServer).VerifyEmail(ctx, req.(*VerifyEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailFinderService_VerifyEmails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailFinderServiceServer).VerifyEmails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmailFinderService_VerifyEmails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailFinderServiceServer).VerifyEmails(ctx, req.(*VerifyEmailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EmailFinderService_ResolveDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmailFinderServiceServer).ResolveDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmailFinderService_ResolveDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmailFinderServiceServer).ResolveDomain(ctx, req.(*ResolveDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EmailFinderService_ServiceDesc is the grpc.ServiceDesc for EmailFinderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EmailFinderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "emailfinder.v1.EmailFinderService",
+	HandlerType: (*EmailFinderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "FindEmail",
+			Handler:    _EmailFinderService_FindEmail_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _EmailFinderService_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "VerifyEmails",
+			Handler:    _EmailFinderService_VerifyEmails_Handler,
+		},
+		{
+			MethodName: "ResolveDomain",
+			Handler:    _EmailFinderService_ResolveDomain_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FindEmailStream",
+			Handler:       _EmailFinderService_FindEmailStream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/emailfinder/v1/emailfinder.proto",
+}
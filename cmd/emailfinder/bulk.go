@@ -0,0 +1,371 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"email-finder/config"
+	"email-finder/internal/service"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Bulk file formats
+const (
+	formatCSV   = "csv"
+	formatJSONL = "jsonl"
+)
+
+// bulkRow is one lookup read from the input
+type bulkRow struct {
+	Row     int
+	Request service.FindEmailRequest
+	Err     error // the row could not be parsed
+}
+
+// bulkResult is the outcome of one input row
+type bulkResult struct {
+	Row      int                        `json:"row"`
+	Request  service.FindEmailRequest   `json:"request"`
+	Response *service.FindEmailResponse `json:"response,omitempty"`
+	Error    string                     `json:"error,omitempty"`
+}
+
+// csvColumns are the columns of the CSV output
+var csvColumns = []string{
+	"row", "first_name", "last_name", "company", "domain",
+	"email", "pattern", "is_reachable", "score", "confidence", "best_guess",
+	"total_found", "error",
+}
+
+// runBulk looks up every row of a CSV or JSONL file and writes one result per row,
+// in input order
+func runBulk(ctx context.Context, args []string) error {
+	fs, flags := newFlagSet("bulk", "bulk [flags]")
+	input := fs.String("input", "-", "input file, - for stdin")
+	inputFormat := fs.String("input-format", "", "input format: csv or jsonl (default from the file extension, else csv)")
+	output := fs.String("output", "-", "output file, - for stdout")
+	outputFormat := fs.String("format", formatJSONL, "output format: jsonl or csv")
+	parallel := fs.Int("parallel", 4, "number of people looked up at once")
+	include := fs.String("include", "", "comma-separated extra statuses to report: risky, unknown, invalid")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *inputFormat == "" {
+		*inputFormat = formatCSV
+		if ext := strings.ToLower(filepath.Ext(*input)); ext == ".jsonl" || ext == ".ndjson" {
+			*inputFormat = formatJSONL
+		}
+	}
+	if *inputFormat != formatCSV && *inputFormat != formatJSONL {
+		return &usageError{fmt.Errorf("unsupported input format %q, expected csv or jsonl", *inputFormat)}
+	}
+	if *outputFormat != formatCSV && *outputFormat != formatJSONL {
+		return &usageError{fmt.Errorf("unsupported output format %q, expected csv or jsonl", *outputFormat)}
+	}
+	if *parallel <= 0 {
+		return &usageError{fmt.Errorf("parallel must be positive, got %d", *parallel)}
+	}
+	includes := config.SplitList(*include)
+	for _, status := range includes {
+		if !slices.Contains(service.IncludableStatuses, status) {
+			return &usageError{fmt.Errorf("unsupported include status %q, expected risky, unknown or invalid", status)}
+		}
+	}
+
+	env, err := setup(flags)
+	if err != nil {
+		return err
+	}
+	defer env.Close()
+
+	in := io.Reader(os.Stdin)
+	if *input != "-" {
+		file, err := os.Open(*input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		in = file
+	}
+
+	out := io.Writer(os.Stdout)
+	if *output != "-" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+	buffered := bufio.NewWriter(out)
+	defer buffered.Flush()
+
+	writer, err := newResultWriter(buffered, *outputFormat)
+	if err != nil {
+		return err
+	}
+
+	rows := make(chan bulkRow)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(rows)
+		readErr <- readRows(ctx, in, *inputFormat, rows)
+	}()
+
+	// Look up rows in parallel, then put the results back in input order
+	results := make(chan bulkResult)
+	var wg sync.WaitGroup
+	for i := 0; i < *parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for row := range rows {
+				if row.Err == nil && len(includes) > 0 {
+					row.Request.Include = includes
+				}
+				results <- lookup(ctx, env.app.Service, row)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var found, failed int
+	pending := make(map[int]bulkResult)
+	next := 1
+	for result := range results {
+		pending[result.Row] = result
+		for {
+			result, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+
+			if result.Error != "" {
+				failed++
+			} else if result.Response.TotalFound > 0 {
+				found++
+			}
+			if err := writer.Write(result); err != nil {
+				return fmt.Errorf("failed to write results: %w", err)
+			}
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to write results: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "processed %d rows: %d with emails found, %d failed\n", next-1, found, failed)
+
+	if err := <-readErr; err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+	return ctx.Err()
+}
+
+// lookup finds the emails for one row
+func lookup(ctx context.Context, svc *service.EmailFinderService, row bulkRow) bulkResult {
+	result := bulkResult{Row: row.Row, Request: row.Request}
+	if row.Err != nil {
+		result.Error = row.Err.Error()
+		return result
+	}
+	if err := row.Request.Validate(); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	response, err := svc.FindEmails(ctx, row.Request)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Response = response
+	return result
+}
+
+// readRows sends every lookup in r to rows, numbered from 1. Rows that can't be
+// parsed are sent with an error rather than stopping the run.
+func readRows(ctx context.Context, r io.Reader, format string, rows chan<- bulkRow) error {
+	send := func(row bulkRow) bool {
+		select {
+		case rows <- row:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	if format == formatJSONL {
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		n := 0
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			n++
+			row := bulkRow{Row: n}
+			if err := json.Unmarshal([]byte(line), &row.Request); err != nil {
+				row.Err = fmt.Errorf("invalid JSON: %w", err)
+			}
+			if !send(row) {
+				return nil
+			}
+		}
+		return scanner.Err()
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[csvColumnName(name)] = i
+	}
+	for _, required := range []string{"first_name", "last_name", "company"} {
+		if _, ok := columns[required]; !ok {
+			return fmt.Errorf("CSV header has no %s column", required)
+		}
+	}
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	for n := 1; ; n++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		row := bulkRow{Row: n}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			row.Err = fmt.Errorf("invalid CSV: %w", err)
+		} else if err != nil {
+			return err
+		} else {
+			row.Request = service.FindEmailRequest{
+				FirstName: field(record, "first_name"),
+				LastName:  field(record, "last_name"),
+				Company:   field(record, "company"),
+				Include:   config.SplitList(field(record, "include")),
+			}
+		}
+		if !send(row) {
+			return nil
+		}
+	}
+}
+
+// csvColumnName normalizes a CSV header, so "First Name" and "first-name" both
+// match first_name
+func csvColumnName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
+
+// resultWriter writes bulk results in an output format
+type resultWriter interface {
+	Write(result bulkResult) error
+	Flush() error
+}
+
+// newResultWriter creates a writer for format, writing the CSV header if needed
+func newResultWriter(w io.Writer, format string) (resultWriter, error) {
+	if format == formatJSONL {
+		return &jsonlWriter{encoder: json.NewEncoder(w)}, nil
+	}
+
+	writer := &csvWriter{writer: csv.NewWriter(w)}
+	if err := writer.writer.Write(csvColumns); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// jsonlWriter writes each result as a JSON line
+type jsonlWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *jsonlWriter) Write(result bulkResult) error {
+	return w.encoder.Encode(result)
+}
+
+func (w *jsonlWriter) Flush() error {
+	return nil
+}
+
+// csvWriter writes each result as a CSV record with the top email
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvWriter) Write(result bulkResult) error {
+	req := result.Request
+	record := make([]string, 0, len(csvColumns))
+	record = append(record, strconv.Itoa(result.Row), req.FirstName, req.LastName, req.Company)
+
+	var domain, totalFound string
+	var best *service.EmailResult
+	bestGuess := false
+	if resp := result.Response; resp != nil {
+		domain = resp.Domain
+		totalFound = strconv.Itoa(resp.TotalFound)
+		if len(resp.FoundEmails) > 0 {
+			best = &resp.FoundEmails[0]
+		} else if resp.BestGuess != nil {
+			best = resp.BestGuess
+			bestGuess = true
+		}
+	}
+	record = append(record, domain)
+
+	if best != nil {
+		record = append(record,
+			best.Email,
+			best.Pattern,
+			best.IsReachable,
+			strconv.Itoa(best.Score),
+			best.Confidence,
+			strconv.FormatBool(bestGuess),
+		)
+	} else {
+		record = append(record, "", "", "", "", "", "")
+	}
+
+	record = append(record, totalFound, result.Error)
+	return w.writer.Write(record)
+}
+
+func (w *csvWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
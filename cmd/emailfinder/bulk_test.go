@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"email-finder/internal/service"
+	"strings"
+	"testing"
+)
+
+// collectRows reads all rows of input
+func collectRows(t *testing.T, input, format string) ([]bulkRow, error) {
+	t.Helper()
+	rows := make(chan bulkRow)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(rows)
+		errc <- readRows(context.Background(), strings.NewReader(input), format, rows)
+	}()
+
+	collected := make([]bulkRow, 0)
+	for row := range rows {
+		collected = append(collected, row)
+	}
+	return collected, <-errc
+}
+
+func TestReadRows_CSV(t *testing.T) {
+	input := "Company,First Name,last-name,title\n" +
+		"Acme,John,Doe,CEO\n" +
+		"Globex, Jane ,Smith\n"
+
+	rows, err := collectRows(t, input, formatCSV)
+	if err != nil {
+		t.Fatalf("readRows() error = %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("readRows() returned %d rows, want 2", len(rows))
+	}
+
+	want := service.FindEmailRequest{FirstName: "Jane", LastName: "Smith", Company: "Globex"}
+	got := rows[1].Request
+	if rows[1].Row != 2 || got.FirstName != want.FirstName || got.LastName != want.LastName || got.Company != want.Company {
+		t.Errorf("row 2 = %d %+v, want %+v", rows[1].Row, got, want)
+	}
+}
+
+func TestReadRows_CSVMissingColumn(t *testing.T) {
+	if _, err := collectRows(t, "first_name,company\nJohn,Acme\n", formatCSV); err == nil {
+		t.Error("readRows() without a last_name column succeeded, want an error")
+	}
+}
+
+func TestReadRows_JSONL(t *testing.T) {
+	input := `{"first_name":"John","last_name":"Doe","company":"Acme"}
+
+not json
+{"first_name":"Jane","last_name":"Smith","company":"Globex","include":["risky"]}
+`
+	rows, err := collectRows(t, input, formatJSONL)
+	if err != nil {
+		t.Fatalf("readRows() error = %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("readRows() returned %d rows, want 3", len(rows))
+	}
+	if rows[1].Err == nil {
+		t.Error("row 2 has no error, want invalid JSON")
+	}
+	if rows[2].Row != 3 || rows[2].Request.Company != "Globex" || len(rows[2].Request.Include) != 1 {
+		t.Errorf("row 3 = %+v, want Globex with include risky", rows[2])
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := newResultWriter(&buf, formatCSV)
+	if err != nil {
+		t.Fatalf("newResultWriter() error = %v", err)
+	}
+
+	req := service.FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Acme"}
+	writer.Write(bulkResult{Row: 1, Request: req, Response: &service.FindEmailResponse{
+		Domain:    "acme.com",
+		BestGuess: &service.EmailResult{Email: "john@acme.com", Pattern: "{first}", IsReachable: "unknown", Score: 40, Confidence: "low"},
+	}})
+	writer.Write(bulkResult{Row: 2, Request: req, Error: "backend unavailable"})
+	if err := writer.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := []string{
+		strings.Join(csvColumns, ","),
+		"1,John,Doe,Acme,acme.com,john@acme.com,{first},unknown,40,low,true,0,",
+		"2,John,Doe,Acme,,,,,,,,,backend unavailable",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("CSV output = %q, want %d lines", buf.String(), len(want))
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i+1, lines[i], want[i])
+		}
+	}
+}
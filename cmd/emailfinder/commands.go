@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"email-finder/config"
+	"email-finder/internal/resolver"
+	"email-finder/internal/service"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// runFind looks up one person and prints the result as JSON
+func runFind(ctx context.Context, args []string) error {
+	fs, flags := newFlagSet("find", "find -first NAME -last NAME -company COMPANY [flags]")
+	firstName := fs.String("first", "", "first name")
+	lastName := fs.String("last", "", "last name")
+	company := fs.String("company", "", "company name or domain")
+	include := fs.String("include", "", "comma-separated extra statuses to report: risky, unknown, invalid")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	req := service.FindEmailRequest{
+		FirstName: *firstName,
+		LastName:  *lastName,
+		Company:   *company,
+		Include:   config.SplitList(*include),
+	}
+	if err := req.Validate(); err != nil {
+		return &usageError{err}
+	}
+
+	env, err := setup(flags)
+	if err != nil {
+		return err
+	}
+	defer env.Close()
+
+	result, err := env.app.Service.FindEmails(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(result)
+}
+
+// runVerify verifies the emails given as arguments, or one per line on stdin,
+// and prints one JSON result per line
+func runVerify(ctx context.Context, args []string) error {
+	fs, flags := newFlagSet("verify", "verify [flags] [EMAIL...]")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	emails := fs.Args()
+	if len(emails) == 0 {
+		var err error
+		if emails, err = readLines(os.Stdin); err != nil {
+			return fmt.Errorf("failed to read emails: %w", err)
+		}
+	}
+	if len(emails) == 0 {
+		return &usageError{errors.New("no emails given")}
+	}
+
+	env, err := setup(flags)
+	if err != nil {
+		return err
+	}
+	defer env.Close()
+
+	results, err := env.app.Verifier.VerifyEmailsBatch(ctx, emails)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	for _, result := range results {
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveOutput is the resolution of one company
+type resolveOutput struct {
+	Company string `json:"company"`
+	*resolver.DomainResult
+}
+
+// runResolveDomain resolves the companies given as arguments, or one per line on
+// stdin, and prints one JSON result per line
+func runResolveDomain(ctx context.Context, args []string) error {
+	fs, flags := newFlagSet("resolve-domain", "resolve-domain [flags] [COMPANY...]")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	companies := fs.Args()
+	if len(companies) == 0 {
+		var err error
+		if companies, err = readLines(os.Stdin); err != nil {
+			return fmt.Errorf("failed to read companies: %w", err)
+		}
+	}
+	if len(companies) == 0 {
+		return &usageError{errors.New("no companies given")}
+	}
+
+	env, err := setup(flags)
+	if err != nil {
+		return err
+	}
+	defer env.Close()
+
+	encoder := json.NewEncoder(os.Stdout)
+	for _, company := range companies {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		result := env.app.Resolver.ResolveDomain(ctx, company)
+		if err := encoder.Encode(resolveOutput{Company: company, DomainResult: result}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readLines reads the non-empty lines of r
+func readLines(r io.Reader) ([]string, error) {
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,140 @@
+// Command emailfinder runs email lookups from a shell or cron job, using the
+// same configuration and verification stack as the server
+package main
+
+import (
+	"context"
+	"email-finder/config"
+	"email-finder/internal/app"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap"
+)
+
+const usage = `Usage: emailfinder <command> [flags] [args]
+
+Commands:
+  find            Find the email address of a person at a company
+  verify          Verify email addresses
+  resolve-domain  Resolve company names to their email domain
+  bulk            Find emails for every row of a CSV or JSONL file
+
+Every command also accepts the server's configuration flags (-config, -cli-path,
+-api-url, -concurrency, -verification-timeout, -log-level, ...) and reads the
+same environment variables. Run "emailfinder <command> -h" for details.
+`
+
+// commands maps each subcommand to its implementation
+var commands = map[string]func(ctx context.Context, args []string) error{
+	"find":           runFind,
+	"verify":         runVerify,
+	"resolve-domain": runResolveDomain,
+	"bulk":           runBulk,
+}
+
+// usageError is a mistake in the command line; it exits with status 2
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "--help" || os.Args[1] == "help" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+	run, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "emailfinder: unknown command %q\n\n%s", name, usage)
+		os.Exit(2)
+	}
+
+	// Ctrl-C cancels the lookups in progress, including running CLI processes
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	err := run(ctx, os.Args[2:])
+	stop()
+
+	var usageErr *usageError
+	switch {
+	case err == nil:
+	case errors.Is(err, flag.ErrHelp):
+		os.Exit(0)
+	case errors.As(err, &usageErr):
+		fmt.Fprintf(os.Stderr, "emailfinder %s: %v\n", name, err)
+		os.Exit(2)
+	default:
+		fmt.Fprintf(os.Stderr, "emailfinder %s: %v\n", name, err)
+		os.Exit(1)
+	}
+}
+
+// newFlagSet creates the flag set of a command, with the configuration flags
+// already defined
+func newFlagSet(name, synopsis string) (*flag.FlagSet, *config.Flags) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: emailfinder %s\n\nFlags:\n", synopsis)
+		fs.PrintDefaults()
+	}
+	return fs, config.RegisterFlags(fs)
+}
+
+// parseFlags parses a command line, reporting mistakes as usage errors
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return &usageError{err}
+	}
+	return nil
+}
+
+// environment is what every command needs to run lookups
+type environment struct {
+	cfg    *config.Config
+	logger *zap.Logger
+	app    *app.App
+}
+
+// setup loads the configuration and builds the verification stack
+func setup(flags *config.Flags) (*environment, error) {
+	cfg, err := config.LoadWithFlags(flags)
+	if err != nil {
+		return nil, &usageError{fmt.Errorf("failed to load config: %w", err)}
+	}
+
+	// The process exits once its lookups are done, so greylisted emails can't be
+	// re-checked later
+	cfg.Retry.RecheckAttempts = 0
+
+	logger, err := cfg.GetLogger()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize logger: %w", err)
+	}
+
+	components, err := app.New(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &environment{
+		cfg:    cfg,
+		logger: logger,
+		app:    components,
+	}, nil
+}
+
+// Close waits for queued verifications and flushes the logs
+func (e *environment) Close() {
+	e.app.Close()
+	e.logger.Sync()
+}
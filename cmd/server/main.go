@@ -1,154 +1,71 @@
 package main
 
 import (
+	"context"
 	"email-finder/config"
-	"email-finder/internal/handler"
-	"email-finder/internal/resolver"
-	"email-finder/internal/service"
-	"email-finder/internal/verifier"
+	"email-finder/internal/server"
+	"email-finder/internal/tracing"
 	"fmt"
-	"net/http"
+	"os"
 	"time"
 
-	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
 func main() {
+	// "config print" shows the effective configuration and exits
+	if len(os.Args) > 2 && os.Args[1] == "config" && os.Args[2] == "print" {
+		printConfig(os.Args[3:])
+		return
+	}
+
 	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := config.Load(os.Args[1:])
 	if err != nil {
-		panic(fmt.Sprintf("failed to load config: %v", err))
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		os.Exit(2)
 	}
 
-	// Initialize logger
-	logger, err := cfg.GetLogger()
+	// Initialize logger. The level is atomic so it can be changed at runtime.
+	logLevel := zap.NewAtomicLevelAt(config.ParseLogLevel(cfg.Logging.Level))
+	logger, err := cfg.NewLogger(logLevel)
 	if err != nil {
 		panic(fmt.Sprintf("failed to initialize logger: %v", err))
 	}
 	defer logger.Sync()
 
-	logger.Info("starting email finder service",
-		zap.String("host", cfg.Server.Host),
-		zap.String("port", cfg.Server.Port),
-	)
-
-	// Initialize email verifier
-	var emailVerifier verifier.Verifier
-	if cfg.EmailVerification.UseCLI {
-		logger.Info("using CLI verifier",
-			zap.String("path", cfg.EmailVerification.CLIPath),
-			zap.Int("concurrency", cfg.VerificationConcurrency),
-		)
-		emailVerifier = verifier.NewCLIVerifier(
-			cfg.EmailVerification.CLIPath,
-			cfg.VerificationTimeout,
-			cfg.VerificationConcurrency,
-			logger,
-		)
-	} else {
-		logger.Info("using HTTP verifier",
-			zap.String("url", cfg.EmailVerification.APIURL),
-			zap.String("endpoint", cfg.EmailVerification.APIEndpoint),
-			zap.Int("concurrency", cfg.VerificationConcurrency),
-		)
-		emailVerifier = verifier.NewHTTPVerifier(
-			cfg.EmailVerification.APIURL,
-			cfg.EmailVerification.APIEndpoint,
-			cfg.VerificationTimeout,
-			cfg.VerificationConcurrency,
-			logger,
-		)
-	}
-
-	// Initialize domain resolver
-	domainResolver := resolver.NewDomainResolver(
-		logger,
-		cfg.VerificationTimeout,
-	)
-
-	// Initialize service
-	emailFinderService := service.NewEmailFinderService(
-		emailVerifier,
-		domainResolver,
-		logger,
-		cfg.MaxEmailPatterns,
-	)
-
-	// Initialize handler
-	emailHandler := handler.NewEmailHandler(emailFinderService, logger)
-
-	// Setup router
-	router := setupRouter(emailHandler, logger, cfg)
-
-	// Start server
-	addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
-	logger.Info("server starting", zap.String("address", addr))
-
-	if err := http.ListenAndServe(addr, router); err != nil {
-		logger.Fatal("server failed to start", zap.Error(err))
+	// Initialize tracing
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing, logger)
+	if err != nil {
+		logger.Fatal("failed to initialize tracing", zap.Error(err))
 	}
-}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Warn("failed to flush traces", zap.Error(err))
+		}
+	}()
 
-func setupRouter(emailHandler *handler.EmailHandler, logger *zap.Logger, cfg *config.Config) *gin.Engine {
-	// Set Gin mode
-	if cfg.Logging.Level == "debug" {
-		gin.SetMode(gin.DebugMode)
-	} else {
-		gin.SetMode(gin.ReleaseMode)
+	// Reloads read the same config file, environment and flags
+	load := func() (*config.Config, error) {
+		return config.Load(os.Args[1:])
 	}
-
-	router := gin.New()
-
-	// Middleware
-	router.Use(ginLogger(logger))
-	router.Use(gin.Recovery())
-	router.Use(corsMiddleware())
-
-	// Health check
-	router.GET("/health", emailHandler.HealthCheck)
-
-	// API routes
-	v1 := router.Group("/api/v1")
-	{
-		v1.POST("/find-email", emailHandler.FindEmail)
+	if err := server.Run(cfg, logLevel, load, logger); err != nil {
+		logger.Fatal("server failed", zap.Error(err))
 	}
-
-	return router
 }
 
-func ginLogger(logger *zap.Logger) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		start := time.Now()
-		path := c.Request.URL.Path
-		query := c.Request.URL.RawQuery
-
-		c.Next()
-
-		latency := time.Since(start)
-		logger.Info("HTTP request",
-			zap.Int("status", c.Writer.Status()),
-			zap.String("method", c.Request.Method),
-			zap.String("path", path),
-			zap.String("query", query),
-			zap.String("ip", c.ClientIP()),
-			zap.Duration("latency", latency),
-		)
+// printConfig writes the configuration merged from defaults, config file,
+// environment and flags to stdout
+func printConfig(args []string) {
+	cfg, err := config.Load(args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		os.Exit(2)
 	}
-}
-
-func corsMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
-
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
-			return
-		}
-
-		c.Next()
+	if err := cfg.Write(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to print config: %v\n", err)
+		os.Exit(1)
 	}
 }
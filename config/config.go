@@ -1,103 +1,476 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"os"
-	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Server                  ServerConfig
-	EmailVerification       EmailVerificationConfig
-	Logging                 LoggingConfig
-	RateLimit               int
-	VerificationTimeout     time.Duration
-	MaxEmailPatterns        int
-	VerificationConcurrency int
+	Server                  ServerConfig            `yaml:"server"`
+	EmailVerification       EmailVerificationConfig `yaml:"email_verification"`
+	Logging                 LoggingConfig           `yaml:"logging"`
+	RateLimit               int                     `yaml:"rate_limit"`
+	VerificationTimeout     time.Duration           `yaml:"verification_timeout"`
+	MaxEmailPatterns        int                     `yaml:"max_email_patterns"`
+	VerificationConcurrency int                     `yaml:"verification_concurrency"`
+	Retry                   RetryConfig             `yaml:"retry"`
+	Throttle                ThrottleConfig          `yaml:"throttle"`
+	Tracing                 TracingConfig           `yaml:"tracing"`
+	Health                  HealthConfig            `yaml:"health"`
+	Admin                   AdminConfig             `yaml:"admin"`
+	Webhook                 WebhookConfig           `yaml:"webhook"`
+	Jobs                    JobsConfig              `yaml:"jobs"`
+	History                 HistoryConfig           `yaml:"history"`
+	LookupCache             LookupCacheConfig       `yaml:"lookup_cache"`
+	Domains                 DomainsConfig           `yaml:"domains"`
 }
 
 type ServerConfig struct {
-	Port string
-	Host string
+	Port string `yaml:"port"`
+	Host string `yaml:"host"`
+	// GRPCPort serves the gRPC API on the same host; empty disables it
+	GRPCPort string `yaml:"grpc_port"`
+	// ShutdownTimeout is how long in-flight requests may take to finish on shutdown
+	// before their verifications are cancelled
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// ReadinessDelay is how long /health/ready reports not ready before the
+	// server stops accepting connections on shutdown
+	ReadinessDelay time.Duration `yaml:"readiness_delay"`
+	// TrustedProxies are the IPs and CIDR ranges whose X-Forwarded-For header
+	// is used as the client IP; by default no proxy is trusted
+	TrustedProxies []string `yaml:"trusted_proxies"`
 }
 
 type EmailVerificationConfig struct {
-	APIURL      string
-	APIEndpoint string
-	CLIPath     string
-	UseCLI      bool
+	APIURL string `yaml:"api_url"`
+	// APIURLs lists several HTTP backends; when empty, APIURL is the only one
+	APIURLs     []string `yaml:"api_urls"`
+	APIEndpoint string   `yaml:"api_endpoint"`
+	CLIPath     string   `yaml:"cli_path"`
+	// UseCLI and UseHTTP are derived from the settings above
+	UseCLI  bool `yaml:"-"`
+	UseHTTP bool `yaml:"-"`
+
+	// Load balancing across backends: round_robin or least_in_flight
+	Strategy       string        `yaml:"strategy"`
+	HealthInterval time.Duration `yaml:"health_interval"`
+
+	// Circuit breaker around the backend; a threshold of 0 disables it
+	BreakerThreshold   int           `yaml:"breaker_threshold"`
+	BreakerOpenTimeout time.Duration `yaml:"breaker_open_timeout"`
+}
+
+type RetryConfig struct {
+	MaxAttempts     int           `yaml:"max_attempts"`
+	BaseDelay       time.Duration `yaml:"base_delay"`
+	MaxDelay        time.Duration `yaml:"max_delay"`
+	Budget          time.Duration `yaml:"budget"`
+	RecheckDelay    time.Duration `yaml:"recheck_delay"`
+	RecheckAttempts int           `yaml:"recheck_attempts"`
+}
+
+// ThrottleConfig limits checks per recipient domain and MX host across all requests.
+// A limit of 0 disables it.
+type ThrottleConfig struct {
+	DomainConcurrency int     `yaml:"domain_concurrency"`
+	DomainRate        float64 `yaml:"domain_rate"`
+	MXConcurrency     int     `yaml:"mx_concurrency"`
+	MXRate            float64 `yaml:"mx_rate"`
+	// ProviderOverrides replaces the MX limits for MX hosts ending in the key
+	ProviderOverrides map[string]ThrottleLimit `yaml:"provider_overrides"`
+}
+
+type ThrottleLimit struct {
+	Concurrency int     `yaml:"concurrency"`
+	Rate        float64 `yaml:"rate"`
+}
+
+// TracingConfig configures OpenTelemetry trace export over OTLP/HTTP
+type TracingConfig struct {
+	Enabled      bool    `yaml:"enabled"`
+	OTLPEndpoint string  `yaml:"otlp_endpoint"`
+	Insecure     bool    `yaml:"insecure"`
+	SampleRatio  float64 `yaml:"sample_ratio"`
+	ServiceName  string  `yaml:"service_name"`
+}
+
+// HealthConfig configures the readiness probes
+type HealthConfig struct {
+	Timeout time.Duration `yaml:"timeout"`
+	// DNSProbeDomain is looked up to check that DNS resolution works
+	DNSProbeDomain string `yaml:"dns_probe_domain"`
+}
+
+// AdminConfig configures the admin endpoints; they are disabled without a token
+type AdminConfig struct {
+	Token string `yaml:"token"`
+}
+
+// WebhookConfig configures the callbacks sent when asynchronous lookups finish.
+// They are disabled without a signing secret.
+type WebhookConfig struct {
+	Secret string `yaml:"secret"`
+	// MaxAttempts is the total number of delivery attempts, including the first
+	MaxAttempts int           `yaml:"max_attempts"`
+	BaseDelay   time.Duration `yaml:"base_delay"`
+	MaxDelay    time.Duration `yaml:"max_delay"`
+	Timeout     time.Duration `yaml:"timeout"`
+	// History is how many deliveries are kept for the deliveries API
+	History int `yaml:"history"`
+	// Path is the delivery database file; empty keeps deliveries in memory, so
+	// callbacks not sent yet are lost on restart
+	Path string `yaml:"path"`
+	// AllowedNetworks are CIDR ranges callbacks may be sent to even though
+	// they are loopback, private or link-local, e.g. for in-cluster receivers
+	AllowedNetworks []string `yaml:"allowed_networks"`
+}
+
+// JobsConfig configures the durable queue for bulk enrichment jobs
+type JobsConfig struct {
+	// Path is the job database file; empty keeps jobs in memory, so they are
+	// lost on restart
+	Path    string `yaml:"path"`
+	Workers int    `yaml:"workers"`
+	// MaxRows is the most lookups a single job may contain
+	MaxRows int `yaml:"max_rows"`
+	// MaxAttempts is how often a row is run before it fails, counting runs cut
+	// short by a restart or an unavailable verification backend
+	MaxAttempts int           `yaml:"max_attempts"`
+	RetryDelay  time.Duration `yaml:"retry_delay"`
+}
+
+// HistoryConfig configures the record kept of every lookup
+type HistoryConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Path is the history database file; empty keeps records in memory, so
+	// they are lost on restart
+	Path string `yaml:"path"`
+	// Retention is how long records are kept; 0 keeps them forever
+	Retention     time.Duration `yaml:"retention"`
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+	// Buffer is how many records may wait to be written before new ones are dropped
+	Buffer int `yaml:"buffer"`
+}
+
+// LookupCacheConfig configures the reuse of recent lookups of the same person
+// at the same domain. Identical lookups running at the same time always share
+// one verification run.
+type LookupCacheConfig struct {
+	// TTL is how long results are reused; 0 disables the cache
+	TTL        time.Duration `yaml:"ttl"`
+	MaxEntries int           `yaml:"max_entries"`
+}
+
+// DomainsConfig configures the profiles kept of the domains lookups go to
+type DomainsConfig struct {
+	// Path is the domain database file; empty keeps profiles in memory, so
+	// they are lost on restart
+	Path string `yaml:"path"`
+	// DNSTTL is how long a domain found in DNS is trusted without looking it
+	// up again; 0 looks it up every time
+	DNSTTL time.Duration `yaml:"dns_ttl"`
+	// Buffer is how many profile updates may wait to be written before new
+	// ones are dropped
+	Buffer int `yaml:"buffer"`
 }
 
 type LoggingConfig struct {
-	Level  string
-	Format string
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
 }
 
-func Load() (*Config, error) {
+// Default returns the configuration used when nothing is overridden
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port:            "8080",
+			Host:            "0.0.0.0",
+			GRPCPort:        "9090",
+			ShutdownTimeout: 30 * time.Second,
+		},
+		EmailVerification: EmailVerificationConfig{
+			APIURL:      "http://localhost:8081",
+			APIEndpoint: "/v0/check_email",
+
+			Strategy:       "round_robin",
+			HealthInterval: 30 * time.Second,
+
+			BreakerThreshold:   5,
+			BreakerOpenTimeout: 30 * time.Second,
+		},
+		Logging: LoggingConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		RateLimit:               60,
+		VerificationTimeout:     30 * time.Second,
+		MaxEmailPatterns:        200, // Increased default for numbered patterns
+		VerificationConcurrency: 100,
+		Retry: RetryConfig{
+			MaxAttempts:     3,
+			BaseDelay:       500 * time.Millisecond,
+			MaxDelay:        5 * time.Second,
+			Budget:          20 * time.Second,
+			RecheckDelay:    300 * time.Second,
+			RecheckAttempts: 3,
+		},
+		Throttle: ThrottleConfig{
+			DomainConcurrency: 25,
+			DomainRate:        25,
+			MXConcurrency:     50,
+			MXRate:            50,
+			ProviderOverrides: map[string]ThrottleLimit{
+				"google.com":  {Concurrency: 100, Rate: 50},
+				"outlook.com": {Concurrency: 100, Rate: 50},
+			},
+		},
+		Tracing: TracingConfig{
+			OTLPEndpoint: "localhost:4318",
+			Insecure:     true,
+			SampleRatio:  1,
+			ServiceName:  "email-finder",
+		},
+		Health: HealthConfig{
+			Timeout:        5 * time.Second,
+			DNSProbeDomain: "gmail.com",
+		},
+		Webhook: WebhookConfig{
+			MaxAttempts: 5,
+			BaseDelay:   time.Second,
+			MaxDelay:    time.Minute,
+			Timeout:     10 * time.Second,
+			History:     1000,
+			Path:        "webhooks.db",
+		},
+		Jobs: JobsConfig{
+			Path:        "jobs.db",
+			Workers:     10,
+			MaxRows:     100000,
+			MaxAttempts: 3,
+			RetryDelay:  30 * time.Second,
+		},
+		History: HistoryConfig{
+			Enabled:       true,
+			Path:          "history.db",
+			Retention:     90 * 24 * time.Hour,
+			PurgeInterval: time.Hour,
+			Buffer:        1000,
+		},
+		LookupCache: LookupCacheConfig{
+			TTL:        24 * time.Hour,
+			MaxEntries: 10000,
+		},
+		Domains: DomainsConfig{
+			Path:   "domains.db",
+			DNSTTL: 24 * time.Hour,
+			Buffer: 1000,
+		},
+	}
+}
+
+// Load builds the configuration from, in increasing order of precedence: the
+// defaults, a YAML config file (--config or CONFIG_FILE), environment variables
+// (including a .env file) and command-line flags. Every invalid value is
+// reported in the returned error.
+func Load(args []string) (*Config, error) {
+	flags, err := parseFlags(args)
+	if err != nil {
+		return nil, err
+	}
+	return LoadWithFlags(flags)
+}
+
+// LoadWithFlags builds the configuration like Load, taking the command-line
+// flags from a flag set that has already been parsed
+func LoadWithFlags(flags *Flags) (*Config, error) {
 	// Load .env file if it exists (ignore error if it doesn't)
 	_ = godotenv.Load()
 
-	port := getEnv("SERVER_PORT", "8080")
-	host := getEnv("SERVER_HOST", "0.0.0.0")
+	flags.fs.Visit(func(fl *flag.Flag) { flags.set[fl.Name] = true })
+
+	config := Default()
 
-	apiURL := getEnv("EMAIL_VERIFICATION_API_URL", "http://localhost:8081")
-	apiEndpoint := getEnv("EMAIL_VERIFICATION_API_ENDPOINT", "/v0/check_email")
-	cliPath := getEnv("EMAIL_VERIFICATION_CLI_PATH", "")
+	path := flags.configFile
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := config.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := config.loadEnv(); err != nil {
+		return nil, err
+	}
+	flags.apply(config)
+	config.derive()
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// loadFile overlays the settings in a YAML file. Unknown keys are rejected so
+// that typos don't go unnoticed.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// derive fills in the settings that follow from others
+func (c *Config) derive() {
+	ev := &c.EmailVerification
 
 	// Auto-detect CLI binary if not specified
-	if cliPath == "" {
+	if ev.CLIPath == "" {
 		if _, err := os.Stat("./check_if_email_exists"); err == nil {
-			cliPath = "./check_if_email_exists"
+			ev.CLIPath = "./check_if_email_exists"
 		} else if _, err := os.Stat("./check_if_email_exists_linux"); err == nil {
-			cliPath = "./check_if_email_exists_linux"
+			ev.CLIPath = "./check_if_email_exists_linux"
 		}
 	}
+	ev.UseCLI = ev.CLIPath != ""
 
-	useCLI := cliPath != ""
+	// Several HTTP backends can be listed; with a CLI binary present they are
+	// only used when listed explicitly, in which case checks fail over between them
+	ev.UseHTTP = !ev.UseCLI || len(ev.APIURLs) > 0
+	if len(ev.APIURLs) == 0 {
+		ev.APIURLs = []string{ev.APIURL}
+	}
 
-	logLevel := getEnv("LOG_LEVEL", "info")
-	logFormat := getEnv("LOG_FORMAT", "json")
+	overrides := make(map[string]ThrottleLimit, len(c.Throttle.ProviderOverrides))
+	for suffix, limit := range c.Throttle.ProviderOverrides {
+		overrides[strings.ToLower(strings.TrimSpace(suffix))] = limit
+	}
+	c.Throttle.ProviderOverrides = overrides
+}
 
-	rateLimit, _ := strconv.Atoi(getEnv("RATE_LIMIT", "60"))
-	timeoutSeconds, _ := strconv.Atoi(getEnv("VERIFICATION_TIMEOUT", "30"))
-	maxPatterns, _ := strconv.Atoi(getEnv("MAX_EMAIL_PATTERNS", "200")) // Increased default for numbered patterns
-	verificationConcurrency, _ := strconv.Atoi(getEnv("VERIFICATION_CONCURRENCY", "100"))
+// Write prints the configuration as YAML, in the format read by --config.
+// Secrets are masked.
+func (c *Config) Write(w io.Writer) error {
+	masked := *c
+	if masked.Admin.Token != "" {
+		masked.Admin.Token = "********"
+	}
+	if masked.Webhook.Secret != "" {
+		masked.Webhook.Secret = "********"
+	}
 
-	config := &Config{
-		Server: ServerConfig{
-			Port: port,
-			Host: host,
-		},
-		EmailVerification: EmailVerificationConfig{
-			APIURL:      apiURL,
-			APIEndpoint: apiEndpoint,
-			CLIPath:     cliPath,
-			UseCLI:      useCLI,
-		},
-		Logging: LoggingConfig{
-			Level:  logLevel,
-			Format: logFormat,
-		},
-		RateLimit:               rateLimit,
-		VerificationTimeout:     time.Duration(timeoutSeconds) * time.Second,
-		MaxEmailPatterns:        maxPatterns,
-		VerificationConcurrency: verificationConcurrency,
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&masked); err != nil {
+		return err
 	}
+	return encoder.Close()
+}
 
-	return config, nil
+// Flags holds the command-line flags; only flags that were set override
+type Flags struct {
+	fs         *flag.FlagSet
+	configFile string
+	set        map[string]bool
+
+	port        string
+	host        string
+	logLevel    string
+	logFormat   string
+	cliPath     string
+	apiURL      string
+	concurrency int
+	timeout     time.Duration
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// RegisterFlags defines the configuration flags on fs. Pass the result to
+// LoadWithFlags once fs has been parsed.
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{fs: fs, set: make(map[string]bool)}
+
+	fs.StringVar(&f.configFile, "config", "", "path to a YAML config file")
+	fs.StringVar(&f.port, "port", "", "server port")
+	fs.StringVar(&f.host, "host", "", "server host")
+	fs.StringVar(&f.logLevel, "log-level", "", "logging level (debug, info, warn, error)")
+	fs.StringVar(&f.logFormat, "log-format", "", "log format (json, text)")
+	fs.StringVar(&f.cliPath, "cli-path", "", "path to the check-if-email-exists CLI binary")
+	fs.StringVar(&f.apiURL, "api-url", "", "URL of the check-if-email-exists HTTP API")
+	fs.IntVar(&f.concurrency, "concurrency", 0, "size of the verification worker pool")
+	fs.DurationVar(&f.timeout, "verification-timeout", 0, "timeout for email verification")
+
+	return f
+}
+
+// parseFlags parses the command-line flags
+func parseFlags(args []string) (*Flags, error) {
+	fs := flag.NewFlagSet("email-finder", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	f := RegisterFlags(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return nil, fmt.Errorf("invalid flags: %w", err)
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	return f, nil
+}
+
+// apply overrides the configuration with the flags that were set
+func (f *Flags) apply(c *Config) {
+	if f.set["port"] {
+		c.Server.Port = f.port
+	}
+	if f.set["host"] {
+		c.Server.Host = f.host
+	}
+	if f.set["log-level"] {
+		c.Logging.Level = f.logLevel
+	}
+	if f.set["log-format"] {
+		c.Logging.Format = f.logFormat
+	}
+	if f.set["cli-path"] {
+		c.EmailVerification.CLIPath = f.cliPath
+	}
+	if f.set["api-url"] {
+		c.EmailVerification.APIURL = f.apiURL
+	}
+	if f.set["concurrency"] {
+		c.VerificationConcurrency = f.concurrency
+	}
+	if f.set["verification-timeout"] {
+		c.VerificationTimeout = f.timeout
 	}
-	return defaultValue
 }
 
 func (c *Config) GetLogger() (*zap.Logger, error) {
+	return c.NewLogger(zap.NewAtomicLevelAt(ParseLogLevel(c.Logging.Level)))
+}
+
+// NewLogger builds a logger whose level is controlled by level, so that it can
+// be changed while the service is running
+func (c *Config) NewLogger(level zap.AtomicLevel) (*zap.Logger, error) {
 	var config zap.Config
 
 	if c.Logging.Format == "json" {
@@ -105,19 +478,23 @@ func (c *Config) GetLogger() (*zap.Logger, error) {
 	} else {
 		config = zap.NewDevelopmentConfig()
 	}
+	config.Level = level
+
+	return config.Build()
+}
 
-	switch c.Logging.Level {
+// ParseLogLevel converts a configured log level to a zap level, defaulting to info
+func ParseLogLevel(level string) zapcore.Level {
+	switch level {
 	case "debug":
-		config.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+		return zap.DebugLevel
 	case "info":
-		config.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
+		return zap.InfoLevel
 	case "warn":
-		config.Level = zap.NewAtomicLevelAt(zap.WarnLevel)
+		return zap.WarnLevel
 	case "error":
-		config.Level = zap.NewAtomicLevelAt(zap.ErrorLevel)
+		return zap.ErrorLevel
 	default:
-		config.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
+		return zap.InfoLevel
 	}
-
-	return config.Build()
 }
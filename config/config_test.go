@@ -0,0 +1,153 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfigFile writes a YAML config file to a temporary directory
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeConfigFile(t, `
+server:
+  port: "7000"
+  host: 127.0.0.1
+verification_timeout: 12s
+logging:
+  level: warn
+`)
+	t.Setenv("SERVER_PORT", "7001")
+	t.Setenv("LOG_LEVEL", "debug")
+
+	cfg, err := Load([]string{"-config", path, "-log-level", "error"})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Server.Host != "127.0.0.1" {
+		t.Errorf("Host = %q, want the file value 127.0.0.1", cfg.Server.Host)
+	}
+	if cfg.VerificationTimeout != 12*time.Second {
+		t.Errorf("VerificationTimeout = %s, want the file value 12s", cfg.VerificationTimeout)
+	}
+	if cfg.Server.Port != "7001" {
+		t.Errorf("Port = %q, want the env value 7001", cfg.Server.Port)
+	}
+	if cfg.Logging.Level != "error" {
+		t.Errorf("Logging.Level = %q, want the flag value error", cfg.Logging.Level)
+	}
+	if cfg.MaxEmailPatterns != 200 {
+		t.Errorf("MaxEmailPatterns = %d, want the default 200", cfg.MaxEmailPatterns)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		file    string
+		args    []string
+		wantErr []string
+	}{
+		{
+			name:    "malformed integer",
+			env:     map[string]string{"VERIFICATION_TIMEOUT": "3o"},
+			wantErr: []string{`VERIFICATION_TIMEOUT: "3o" is not a whole number of seconds`},
+		},
+		{
+			name: "every bad value is reported",
+			env: map[string]string{
+				"VERIFICATION_CONCURRENCY": "ten",
+				"TRACING_ENABLED":          "yes",
+			},
+			wantErr: []string{"VERIFICATION_CONCURRENCY", "TRACING_ENABLED"},
+		},
+		{
+			name:    "malformed provider override",
+			env:     map[string]string{"THROTTLE_PROVIDER_OVERRIDES": "google.com=50"},
+			wantErr: []string{"THROTTLE_PROVIDER_OVERRIDES"},
+		},
+		{
+			name:    "out of range",
+			env:     map[string]string{"VERIFICATION_TIMEOUT": "0", "TRACING_SAMPLE_RATIO": "2"},
+			wantErr: []string{"verification_timeout: must be positive", "tracing.sample_ratio"},
+		},
+		{
+			name:    "unknown strategy",
+			env:     map[string]string{"EMAIL_VERIFICATION_STRATEGY": "random"},
+			wantErr: []string{"email_verification.strategy"},
+		},
+		{
+			name:    "gRPC port clashes with HTTP port",
+			env:     map[string]string{"SERVER_PORT": "9000", "GRPC_PORT": "9000"},
+			wantErr: []string{"server.grpc_port: must differ"},
+		},
+		{
+			name:    "malformed trusted proxy",
+			env:     map[string]string{"TRUSTED_PROXIES": "10.0.0.0/8,proxy.internal"},
+			wantErr: []string{`server.trusted_proxies: "proxy.internal"`},
+		},
+		{
+			name:    "unknown key in file",
+			file:    "verification_timout: 10s\n",
+			wantErr: []string{"verification_timout"},
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"-verbose"},
+			wantErr: []string{"invalid flags"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			args := tt.args
+			if tt.file != "" {
+				args = append(args, "-config", writeConfigFile(t, tt.file))
+			}
+
+			_, err := Load(args)
+			if err == nil {
+				t.Fatal("Load() error = nil, want an error")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Load() error = %q, want it to mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_WriteRoundTrip(t *testing.T) {
+	cfg := Default()
+	cfg.derive()
+
+	var buf bytes.Buffer
+	if err := cfg.Write(&buf); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	loaded, err := Load([]string{"-config", writeConfigFile(t, buf.String())})
+	if err != nil {
+		t.Fatalf("Load() of printed config error = %v", err)
+	}
+	if loaded.Retry.BaseDelay != cfg.Retry.BaseDelay || loaded.Throttle.ProviderOverrides["google.com"] != cfg.Throttle.ProviderOverrides["google.com"] {
+		t.Errorf("printed config did not load back to the same values")
+	}
+}
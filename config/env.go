@@ -0,0 +1,229 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envLoader reads environment variables into the configuration, collecting an
+// error for every value that doesn't parse. Unset variables leave the current
+// value alone.
+type envLoader struct {
+	errs []error
+}
+
+// loadEnv overlays the settings in environment variables
+func (c *Config) loadEnv() error {
+	l := &envLoader{}
+
+	l.string("SERVER_PORT", &c.Server.Port)
+	l.string("SERVER_HOST", &c.Server.Host)
+	l.string("GRPC_PORT", &c.Server.GRPCPort)
+	l.seconds("SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+	l.seconds("SHUTDOWN_READINESS_DELAY", &c.Server.ReadinessDelay)
+	l.list("TRUSTED_PROXIES", &c.Server.TrustedProxies)
+
+	ev := &c.EmailVerification
+	l.string("EMAIL_VERIFICATION_API_URL", &ev.APIURL)
+	l.list("EMAIL_VERIFICATION_API_URLS", &ev.APIURLs)
+	l.string("EMAIL_VERIFICATION_API_ENDPOINT", &ev.APIEndpoint)
+	l.string("EMAIL_VERIFICATION_CLI_PATH", &ev.CLIPath)
+	l.string("EMAIL_VERIFICATION_STRATEGY", &ev.Strategy)
+	l.seconds("EMAIL_VERIFICATION_HEALTH_INTERVAL", &ev.HealthInterval)
+	l.int("CIRCUIT_BREAKER_THRESHOLD", &ev.BreakerThreshold)
+	l.seconds("CIRCUIT_BREAKER_OPEN_TIMEOUT", &ev.BreakerOpenTimeout)
+
+	l.string("LOG_LEVEL", &c.Logging.Level)
+	l.string("LOG_FORMAT", &c.Logging.Format)
+
+	l.int("RATE_LIMIT", &c.RateLimit)
+	l.seconds("VERIFICATION_TIMEOUT", &c.VerificationTimeout)
+	l.int("MAX_EMAIL_PATTERNS", &c.MaxEmailPatterns)
+	l.int("VERIFICATION_CONCURRENCY", &c.VerificationConcurrency)
+
+	l.int("VERIFICATION_RETRY_ATTEMPTS", &c.Retry.MaxAttempts)
+	l.millis("VERIFICATION_RETRY_BASE_DELAY_MS", &c.Retry.BaseDelay)
+	l.millis("VERIFICATION_RETRY_MAX_DELAY_MS", &c.Retry.MaxDelay)
+	l.seconds("VERIFICATION_RETRY_BUDGET", &c.Retry.Budget)
+	l.seconds("GREYLIST_RECHECK_DELAY", &c.Retry.RecheckDelay)
+	l.int("GREYLIST_RECHECK_ATTEMPTS", &c.Retry.RecheckAttempts)
+
+	l.int("THROTTLE_DOMAIN_CONCURRENCY", &c.Throttle.DomainConcurrency)
+	l.float("THROTTLE_DOMAIN_RATE", &c.Throttle.DomainRate)
+	l.int("THROTTLE_MX_CONCURRENCY", &c.Throttle.MXConcurrency)
+	l.float("THROTTLE_MX_RATE", &c.Throttle.MXRate)
+	if value, ok := lookupEnv("THROTTLE_PROVIDER_OVERRIDES"); ok {
+		overrides, err := parseThrottleOverrides(value)
+		if err != nil {
+			l.errs = append(l.errs, fmt.Errorf("THROTTLE_PROVIDER_OVERRIDES: %w", err))
+		} else {
+			c.Throttle.ProviderOverrides = overrides
+		}
+	}
+
+	l.bool("TRACING_ENABLED", &c.Tracing.Enabled)
+	l.string("TRACING_OTLP_ENDPOINT", &c.Tracing.OTLPEndpoint)
+	l.bool("TRACING_OTLP_INSECURE", &c.Tracing.Insecure)
+	l.float("TRACING_SAMPLE_RATIO", &c.Tracing.SampleRatio)
+	l.string("TRACING_SERVICE_NAME", &c.Tracing.ServiceName)
+
+	l.seconds("HEALTH_CHECK_TIMEOUT", &c.Health.Timeout)
+	l.string("HEALTH_DNS_PROBE_DOMAIN", &c.Health.DNSProbeDomain)
+
+	l.string("ADMIN_TOKEN", &c.Admin.Token)
+
+	l.string("WEBHOOK_SECRET", &c.Webhook.Secret)
+	l.int("WEBHOOK_MAX_ATTEMPTS", &c.Webhook.MaxAttempts)
+	l.seconds("WEBHOOK_RETRY_BASE_DELAY", &c.Webhook.BaseDelay)
+	l.seconds("WEBHOOK_RETRY_MAX_DELAY", &c.Webhook.MaxDelay)
+	l.seconds("WEBHOOK_TIMEOUT", &c.Webhook.Timeout)
+	l.int("WEBHOOK_HISTORY", &c.Webhook.History)
+	l.string("WEBHOOK_DB_PATH", &c.Webhook.Path)
+	l.list("WEBHOOK_ALLOWED_NETWORKS", &c.Webhook.AllowedNetworks)
+
+	l.string("JOBS_DB_PATH", &c.Jobs.Path)
+	l.int("JOBS_WORKERS", &c.Jobs.Workers)
+	l.int("JOBS_MAX_ROWS", &c.Jobs.MaxRows)
+	l.int("JOBS_MAX_ATTEMPTS", &c.Jobs.MaxAttempts)
+	l.seconds("JOBS_RETRY_DELAY", &c.Jobs.RetryDelay)
+
+	l.bool("HISTORY_ENABLED", &c.History.Enabled)
+	l.string("HISTORY_DB_PATH", &c.History.Path)
+	l.days("HISTORY_RETENTION_DAYS", &c.History.Retention)
+	l.seconds("HISTORY_PURGE_INTERVAL", &c.History.PurgeInterval)
+	l.int("HISTORY_BUFFER", &c.History.Buffer)
+
+	l.seconds("LOOKUP_CACHE_TTL", &c.LookupCache.TTL)
+	l.int("LOOKUP_CACHE_SIZE", &c.LookupCache.MaxEntries)
+
+	l.string("DOMAINS_DB_PATH", &c.Domains.Path)
+	l.seconds("DOMAINS_DNS_TTL", &c.Domains.DNSTTL)
+	l.int("DOMAINS_BUFFER", &c.Domains.Buffer)
+
+	return errors.Join(l.errs...)
+}
+
+// lookupEnv returns the value of a variable that is set and not empty
+func lookupEnv(key string) (string, bool) {
+	value := strings.TrimSpace(os.Getenv(key))
+	return value, value != ""
+}
+
+func (l *envLoader) string(key string, dst *string) {
+	if value, ok := lookupEnv(key); ok {
+		*dst = value
+	}
+}
+
+func (l *envLoader) list(key string, dst *[]string) {
+	if value, ok := lookupEnv(key); ok {
+		*dst = SplitList(value)
+	}
+}
+
+func (l *envLoader) int(key string, dst *int) {
+	value, ok := lookupEnv(key)
+	if !ok {
+		return
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: %q is not an integer", key, value))
+		return
+	}
+	*dst = n
+}
+
+func (l *envLoader) float(key string, dst *float64) {
+	value, ok := lookupEnv(key)
+	if !ok {
+		return
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: %q is not a number", key, value))
+		return
+	}
+	*dst = f
+}
+
+func (l *envLoader) bool(key string, dst *bool) {
+	value, ok := lookupEnv(key)
+	if !ok {
+		return
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: %q is not true or false", key, value))
+		return
+	}
+	*dst = b
+}
+
+// seconds reads a whole number of seconds
+func (l *envLoader) seconds(key string, dst *time.Duration) {
+	l.duration(key, dst, time.Second, "seconds")
+}
+
+// millis reads a whole number of milliseconds
+func (l *envLoader) millis(key string, dst *time.Duration) {
+	l.duration(key, dst, time.Millisecond, "milliseconds")
+}
+
+// days reads a whole number of days
+func (l *envLoader) days(key string, dst *time.Duration) {
+	l.duration(key, dst, 24*time.Hour, "days")
+}
+
+func (l *envLoader) duration(key string, dst *time.Duration, unit time.Duration, unitName string) {
+	value, ok := lookupEnv(key)
+	if !ok {
+		return
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: %q is not a whole number of %s", key, value, unitName))
+		return
+	}
+	*dst = time.Duration(n) * unit
+}
+
+// SplitList splits a comma-separated value, dropping empty items
+func SplitList(value string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseThrottleOverrides parses "suffix=concurrency:rate" items separated by commas,
+// e.g. "google.com=100:50,outlook.com=100:50"
+func parseThrottleOverrides(value string) (map[string]ThrottleLimit, error) {
+	overrides := make(map[string]ThrottleLimit)
+	for _, item := range SplitList(value) {
+		suffix, limits, ok := strings.Cut(item, "=")
+		concurrency, rate, ok2 := strings.Cut(limits, ":")
+		suffix = strings.ToLower(strings.TrimSpace(suffix))
+		if !ok || !ok2 || suffix == "" {
+			return nil, fmt.Errorf("%q is not in the form suffix=concurrency:rate", item)
+		}
+
+		limit := ThrottleLimit{}
+		var err error
+		if limit.Concurrency, err = strconv.Atoi(strings.TrimSpace(concurrency)); err != nil {
+			return nil, fmt.Errorf("%q: concurrency %q is not an integer", item, concurrency)
+		}
+		if limit.Rate, err = strconv.ParseFloat(strings.TrimSpace(rate), 64); err != nil {
+			return nil, fmt.Errorf("%q: rate %q is not a number", item, rate)
+		}
+		overrides[suffix] = limit
+	}
+	return overrides, nil
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strconv"
+)
+
+// Validate reports every setting that is out of range or inconsistent
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	port, err := strconv.Atoi(c.Server.Port)
+	check(err == nil && port > 0 && port <= 65535, "server.port: %q is not a valid port", c.Server.Port)
+	if c.Server.GRPCPort != "" {
+		grpcPort, err := strconv.Atoi(c.Server.GRPCPort)
+		check(err == nil && grpcPort > 0 && grpcPort <= 65535, "server.grpc_port: %q is not a valid port", c.Server.GRPCPort)
+		check(c.Server.GRPCPort != c.Server.Port, "server.grpc_port: must differ from server.port %s", c.Server.Port)
+	}
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout: must be positive, got %s", c.Server.ShutdownTimeout)
+	check(c.Server.ReadinessDelay >= 0, "server.readiness_delay: must not be negative, got %s", c.Server.ReadinessDelay)
+	for _, proxy := range c.Server.TrustedProxies {
+		_, prefixErr := netip.ParsePrefix(proxy)
+		_, addrErr := netip.ParseAddr(proxy)
+		check(prefixErr == nil || addrErr == nil, "server.trusted_proxies: %q is not an IP address or CIDR range", proxy)
+	}
+
+	ev := c.EmailVerification
+	check(ev.UseCLI || ev.UseHTTP, "email_verification: no verification backend configured")
+	if ev.UseHTTP {
+		for _, apiURL := range ev.APIURLs {
+			u, err := url.Parse(apiURL)
+			check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "",
+				"email_verification.api_urls: %q is not an http(s) URL", apiURL)
+		}
+	}
+	check(ev.Strategy == "round_robin" || ev.Strategy == "least_in_flight",
+		"email_verification.strategy: must be round_robin or least_in_flight, got %q", ev.Strategy)
+	check(ev.HealthInterval > 0, "email_verification.health_interval: must be positive, got %s", ev.HealthInterval)
+	check(ev.BreakerThreshold >= 0, "email_verification.breaker_threshold: must not be negative, got %d", ev.BreakerThreshold)
+	check(ev.BreakerThreshold == 0 || ev.BreakerOpenTimeout > 0,
+		"email_verification.breaker_open_timeout: must be positive, got %s", ev.BreakerOpenTimeout)
+
+	switch c.Logging.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		check(false, "logging.level: must be debug, info, warn or error, got %q", c.Logging.Level)
+	}
+	check(c.Logging.Format == "json" || c.Logging.Format == "text",
+		"logging.format: must be json or text, got %q", c.Logging.Format)
+
+	check(c.RateLimit >= 0, "rate_limit: must not be negative, got %d", c.RateLimit)
+	check(c.VerificationTimeout > 0, "verification_timeout: must be positive, got %s", c.VerificationTimeout)
+	check(c.MaxEmailPatterns >= 0, "max_email_patterns: must not be negative, got %d", c.MaxEmailPatterns)
+	check(c.VerificationConcurrency > 0, "verification_concurrency: must be positive, got %d", c.VerificationConcurrency)
+
+	r := c.Retry
+	check(r.MaxAttempts >= 1, "retry.max_attempts: must be at least 1, got %d", r.MaxAttempts)
+	check(r.BaseDelay >= 0, "retry.base_delay: must not be negative, got %s", r.BaseDelay)
+	check(r.MaxDelay == 0 || r.MaxDelay >= r.BaseDelay,
+		"retry.max_delay: must be 0 (no cap) or at least base_delay (%s), got %s", r.BaseDelay, r.MaxDelay)
+	check(r.Budget >= 0, "retry.budget: must not be negative, got %s", r.Budget)
+	check(r.RecheckAttempts >= 0, "retry.recheck_attempts: must not be negative, got %d", r.RecheckAttempts)
+	check(r.RecheckAttempts == 0 || r.RecheckDelay > 0, "retry.recheck_delay: must be positive, got %s", r.RecheckDelay)
+
+	t := c.Throttle
+	check(t.DomainConcurrency >= 0, "throttle.domain_concurrency: must not be negative, got %d", t.DomainConcurrency)
+	check(t.DomainRate >= 0, "throttle.domain_rate: must not be negative, got %g", t.DomainRate)
+	check(t.MXConcurrency >= 0, "throttle.mx_concurrency: must not be negative, got %d", t.MXConcurrency)
+	check(t.MXRate >= 0, "throttle.mx_rate: must not be negative, got %g", t.MXRate)
+	for suffix, limit := range t.ProviderOverrides {
+		check(suffix != "", "throttle.provider_overrides: empty MX suffix")
+		check(limit.Concurrency >= 0 && limit.Rate >= 0,
+			"throttle.provider_overrides.%s: limits must not be negative, got %d:%g", suffix, limit.Concurrency, limit.Rate)
+	}
+
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1,
+		"tracing.sample_ratio: must be between 0 and 1, got %g", c.Tracing.SampleRatio)
+	check(!c.Tracing.Enabled || c.Tracing.OTLPEndpoint != "", "tracing.otlp_endpoint: required when tracing is enabled")
+
+	check(c.Health.Timeout > 0, "health.timeout: must be positive, got %s", c.Health.Timeout)
+	check(c.Health.DNSProbeDomain != "", "health.dns_probe_domain: must not be empty")
+
+	check(c.Webhook.MaxAttempts >= 1, "webhook.max_attempts: must be at least 1, got %d", c.Webhook.MaxAttempts)
+	check(c.Webhook.BaseDelay > 0, "webhook.base_delay: must be positive, got %s", c.Webhook.BaseDelay)
+	check(c.Webhook.MaxDelay >= c.Webhook.BaseDelay, "webhook.max_delay: must be at least base_delay, got %s", c.Webhook.MaxDelay)
+	check(c.Webhook.Timeout > 0, "webhook.timeout: must be positive, got %s", c.Webhook.Timeout)
+	check(c.Webhook.History >= 1, "webhook.history: must be at least 1, got %d", c.Webhook.History)
+	for _, network := range c.Webhook.AllowedNetworks {
+		_, err := netip.ParsePrefix(network)
+		check(err == nil, "webhook.allowed_networks: %q is not a CIDR range", network)
+	}
+
+	check(c.Jobs.Workers >= 1, "jobs.workers: must be at least 1, got %d", c.Jobs.Workers)
+	check(c.Jobs.MaxRows >= 1, "jobs.max_rows: must be at least 1, got %d", c.Jobs.MaxRows)
+	check(c.Jobs.MaxAttempts >= 1, "jobs.max_attempts: must be at least 1, got %d", c.Jobs.MaxAttempts)
+	check(c.Jobs.RetryDelay > 0, "jobs.retry_delay: must be positive, got %s", c.Jobs.RetryDelay)
+
+	check(c.History.Retention >= 0, "history.retention: must not be negative, got %s", c.History.Retention)
+	check(c.History.PurgeInterval > 0, "history.purge_interval: must be positive, got %s", c.History.PurgeInterval)
+	check(c.History.Buffer >= 1, "history.buffer: must be at least 1, got %d", c.History.Buffer)
+
+	check(c.LookupCache.TTL >= 0, "lookup_cache.ttl: must not be negative, got %s", c.LookupCache.TTL)
+	check(c.LookupCache.MaxEntries >= 0, "lookup_cache.max_entries: must not be negative, got %d", c.LookupCache.MaxEntries)
+
+	check(c.Domains.DNSTTL >= 0, "domains.dns_ttl: must not be negative, got %s", c.Domains.DNSTTL)
+	check(c.Domains.Buffer >= 1, "domains.buffer: must be at least 1, got %d", c.Domains.Buffer)
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+// Package app wires the email finder components together from the configuration,
+// so the server and the command-line tool run the same verification stack
+package app
+
+import (
+	"context"
+	"email-finder/config"
+	"email-finder/internal/reconfig"
+	"email-finder/internal/resolver"
+	"email-finder/internal/service"
+	"email-finder/internal/verifier"
+
+	"go.uber.org/zap"
+)
+
+// App holds the components built from a configuration
+type App struct {
+	WorkerPool   *verifier.WorkerPool
+	Throttler    *verifier.Throttler
+	Backends     []verifier.Backend
+	Verifier     verifier.Verifier
+	RecheckQueue *verifier.RecheckQueue
+	Resolver     *resolver.DomainResolver
+	Service      *service.EmailFinderService
+
+	// baseVerifier is the backend or load balancer, before retries are added
+	baseVerifier     verifier.Verifier
+	stopHealthChecks context.CancelFunc
+}
+
+// New builds the verifier backends, retries, domain resolver and service from cfg
+func New(cfg *config.Config, logger *zap.Logger) (*App, error) {
+	a := &App{stopHealthChecks: func() {}}
+
+	// All batch checks share one worker pool, which caps the number of CLI
+	// processes and HTTP calls across every request
+	a.WorkerPool = verifier.NewWorkerPool(cfg.VerificationConcurrency, logger)
+
+	// One throttler is shared by all backends, since they probe the same mail servers
+	a.Throttler = verifier.NewThrottler(reconfig.ThrottleConfig(cfg.Throttle), logger)
+
+	// Initialize email verifier
+	a.Backends = buildBackends(cfg, a.WorkerPool, a.Throttler, logger)
+	if len(a.Backends) == 1 {
+		a.baseVerifier = a.Backends[0].Verifier
+	} else {
+		logger.Info("load balancing across verification backends",
+			zap.Int("backends", len(a.Backends)),
+			zap.String("strategy", cfg.EmailVerification.Strategy),
+		)
+		multiVerifier := verifier.NewMultiVerifier(
+			a.Backends,
+			cfg.EmailVerification.Strategy,
+			cfg.VerificationConcurrency,
+			logger,
+		)
+		healthCtx, stopHealthChecks := context.WithCancel(context.Background())
+		a.stopHealthChecks = stopHealthChecks
+		multiVerifier.StartHealthChecks(healthCtx, cfg.EmailVerification.HealthInterval)
+		multiVerifier.SetWorkerPool(a.WorkerPool)
+		multiVerifier.SetThrottler(a.Throttler)
+		a.baseVerifier = multiVerifier
+	}
+	a.Verifier = a.baseVerifier
+
+	// Retry transient failures, and re-check greylisted emails once the
+	// greylisting window has passed
+	if cfg.Retry.RecheckAttempts > 0 {
+		a.RecheckQueue = verifier.NewRecheckQueue(
+			a.Verifier,
+			cfg.Retry.RecheckDelay,
+			cfg.Retry.RecheckAttempts,
+			logger,
+		)
+	}
+	if cfg.Retry.MaxAttempts > 1 || a.RecheckQueue != nil {
+		logger.Info("retrying transient verification failures",
+			zap.Int("max_attempts", cfg.Retry.MaxAttempts),
+			zap.Duration("budget", cfg.Retry.Budget),
+			zap.Duration("recheck_delay", cfg.Retry.RecheckDelay),
+		)
+		a.Verifier = verifier.NewRetryVerifier(
+			a.Verifier,
+			verifier.RetryPolicy{
+				MaxAttempts: cfg.Retry.MaxAttempts,
+				BaseDelay:   cfg.Retry.BaseDelay,
+				MaxDelay:    cfg.Retry.MaxDelay,
+				Budget:      cfg.Retry.Budget,
+			},
+			a.RecheckQueue,
+			logger,
+		)
+	}
+
+	// Lookups running at the same time often generate the same candidates, e.g.
+	// bulk rows for one company; those share one check, retries included
+	a.Verifier = verifier.NewCoalescingVerifier(a.Verifier, logger)
+
+	// Initialize domain resolver
+	a.Resolver = resolver.NewDomainResolver(
+		logger,
+		cfg.VerificationTimeout,
+	)
+
+	// Initialize service
+	a.Service = service.NewEmailFinderService(a.Verifier, a.Resolver, logger, cfg.MaxEmailPatterns)
+	a.Service.SetLookupCache(service.NewLookupCache(cfg.LookupCache.TTL, cfg.LookupCache.MaxEntries))
+
+	return a, nil
+}
+
+// HealthChecker returns the verifier that answers health checks: the single
+// backend or the load balancer across them
+func (a *App) HealthChecker() (verifier.HealthChecker, bool) {
+	hc, ok := a.baseVerifier.(verifier.HealthChecker)
+	return hc, ok
+}
+
+// TimeoutSetters returns the backends and the domain resolver, whose timeouts
+// follow the verification timeout and can be changed at runtime
+func (a *App) TimeoutSetters() []reconfig.TimeoutSetter {
+	setters := make([]reconfig.TimeoutSetter, 0, len(a.Backends)+1)
+	setters = append(setters, a.Resolver)
+	for _, b := range a.Backends {
+		if ts, ok := b.Verifier.(reconfig.TimeoutSetter); ok {
+			setters = append(setters, ts)
+		}
+	}
+	return setters
+}
+
+// Close stops background work: pending greylist re-checks are dropped, then
+// queued verifications are drained
+func (a *App) Close() {
+	a.stopHealthChecks()
+	if a.RecheckQueue != nil {
+		a.RecheckQueue.Close()
+	}
+	a.WorkerPool.Close()
+}
+
+// buildBackends creates a verifier for the CLI binary and every configured HTTP API,
+// each with its own circuit breaker and all sharing one throttler and worker pool
+func buildBackends(cfg *config.Config, pool *verifier.WorkerPool, throttler *verifier.Throttler, logger *zap.Logger) []verifier.Backend {
+	backends := make([]verifier.Backend, 0, len(cfg.EmailVerification.APIURLs)+1)
+
+	newBreaker := func(name string) *verifier.CircuitBreaker {
+		if cfg.EmailVerification.BreakerThreshold <= 0 {
+			return nil
+		}
+		return verifier.NewCircuitBreaker(name, cfg.EmailVerification.BreakerThreshold, cfg.EmailVerification.BreakerOpenTimeout, logger)
+	}
+
+	if cfg.EmailVerification.UseCLI {
+		logger.Info("using CLI verifier",
+			zap.String("path", cfg.EmailVerification.CLIPath),
+			zap.Int("concurrency", cfg.VerificationConcurrency),
+		)
+		cliVerifier := verifier.NewCLIVerifier(
+			cfg.EmailVerification.CLIPath,
+			cfg.VerificationTimeout,
+			cfg.VerificationConcurrency,
+			logger,
+		)
+		breaker := newBreaker("cli")
+		cliVerifier.SetCircuitBreaker(breaker)
+		cliVerifier.SetThrottler(throttler)
+		cliVerifier.SetWorkerPool(pool)
+		backends = append(backends, verifier.Backend{Name: "cli", Verifier: cliVerifier, Breaker: breaker})
+	}
+
+	if cfg.EmailVerification.UseHTTP {
+		for _, apiURL := range cfg.EmailVerification.APIURLs {
+			logger.Info("using HTTP verifier",
+				zap.String("url", apiURL),
+				zap.String("endpoint", cfg.EmailVerification.APIEndpoint),
+				zap.Int("concurrency", cfg.VerificationConcurrency),
+			)
+			httpVerifier := verifier.NewHTTPVerifier(
+				apiURL,
+				cfg.EmailVerification.APIEndpoint,
+				cfg.VerificationTimeout,
+				cfg.VerificationConcurrency,
+				logger,
+			)
+			breaker := newBreaker(apiURL)
+			httpVerifier.SetCircuitBreaker(breaker)
+			httpVerifier.SetThrottler(throttler)
+			httpVerifier.SetWorkerPool(pool)
+			backends = append(backends, verifier.Backend{Name: apiURL, Verifier: httpVerifier, Breaker: breaker})
+		}
+	}
+
+	return backends
+}
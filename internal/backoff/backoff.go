@@ -0,0 +1,22 @@
+// Package backoff computes the delays between retries
+package backoff
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Exponential returns the delay before the given retry (1 for the first
+// retry): base doubled every retry and capped at max, with "equal jitter", half
+// fixed and half random, so clients retrying together spread out
+func Exponential(base, max time.Duration, retry int) time.Duration {
+	delay := base << uint(retry-1)
+	if delay <= 0 || (max > 0 && delay > max) {
+		delay = max
+	}
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
@@ -0,0 +1,31 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExponential(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     time.Duration
+		max      time.Duration
+		retry    int
+		min, top time.Duration
+	}{
+		{"first retry", 100 * time.Millisecond, time.Second, 1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{"doubles", 100 * time.Millisecond, time.Second, 3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{"capped", 100 * time.Millisecond, time.Second, 10, 500 * time.Millisecond, time.Second},
+		{"overflow is capped", time.Second, time.Minute, 80, 30 * time.Second, time.Minute},
+		{"no delay", 0, 0, 1, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				if got := Exponential(tt.base, tt.max, tt.retry); got < tt.min || got > tt.top {
+					t.Fatalf("Exponential(%s, %s, %d) = %s, want between %s and %s", tt.base, tt.max, tt.retry, got, tt.min, tt.top)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package domains
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// profilesBucket holds the profiles keyed by domain
+var profilesBucket = []byte("profiles")
+
+// BoltStore keeps profiles in an embedded BoltDB file
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltStore opens or creates the domain database at path
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open domain database %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(profilesBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize domain database %s: %w", path, err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Get returns the profile of a domain
+func (s *BoltStore) Get(domain string) (*Profile, error) {
+	var p Profile
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(profilesBucket).Get([]byte(normalize(domain)))
+		if data == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(data, &p)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Save stores a profile, replacing the previous one of its domain
+func (s *BoltStore) Save(p *Profile) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(profilesBucket).Put([]byte(normalize(p.Domain)), data)
+	})
+}
+
+// HealthCheck reads the database in a transaction, for the readiness probe
+func (s *BoltStore) HealthCheck(ctx context.Context) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(profilesBucket) == nil {
+			return fmt.Errorf("bucket %s is missing", profilesBucket)
+		}
+		return nil
+	})
+}
+
+// Close closes the database
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
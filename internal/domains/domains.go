@@ -0,0 +1,92 @@
+// Package domains keeps what lookups learn about a domain, such as its MX
+// hosts, mail provider and catch-all status, so later lookups don't derive it
+// again and it can be queried
+package domains
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned for domains nothing is known about
+var ErrNotFound = errors.New("domain profile not found")
+
+// Mail providers, detected from the MX hosts of a domain
+const (
+	ProviderGoogleWorkspace = "google_workspace"
+	ProviderMicrosoft365    = "microsoft_365"
+	ProviderZoho            = "zoho"
+	// ProviderSelfHosted is any other mail server
+	ProviderSelfHosted = "self_hosted"
+)
+
+// providerMXSuffixes maps the MX hosts of the known providers to them
+var providerMXSuffixes = []struct {
+	suffix   string
+	provider string
+}{
+	{"google.com", ProviderGoogleWorkspace},
+	{"googlemail.com", ProviderGoogleWorkspace},
+	{"outlook.com", ProviderMicrosoft365},
+	{"zoho.com", ProviderZoho},
+	{"zoho.eu", ProviderZoho},
+	{"zoho.in", ProviderZoho},
+	{"zoho.com.au", ProviderZoho},
+	{"zohomail.com", ProviderZoho},
+}
+
+// Profile is what is known about a domain
+type Profile struct {
+	Domain string `json:"domain"`
+
+	// DNSVerified is set once the domain was found to have MX, A or CNAME records
+	DNSVerified  bool       `json:"dns_verified"`
+	DNSCheckedAt *time.Time `json:"dns_checked_at,omitempty"`
+
+	MXRecords []string `json:"mx_records,omitempty"`
+	// Provider is detected from MX records; empty while none are known
+	Provider string `json:"provider,omitempty"`
+	// CatchAll is whether the mail server accepts every address; nil until
+	// an SMTP check connected to it
+	CatchAll *bool `json:"catch_all,omitempty"`
+
+	// Patterns are the patterns inferred from sample addresses
+	Patterns []string `json:"patterns,omitempty"`
+	// VerifiedPattern is the pattern of the last address found deliverable as
+	// the only one of its lookup, at a domain that isn't catch-all
+	VerifiedPattern string `json:"verified_pattern,omitempty"`
+
+	// CheckedAt is when anything about the domain was last learned
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Store persists domain profiles. Implementations must be safe for concurrent
+// use and must not share profiles with callers.
+type Store interface {
+	Get(domain string) (*Profile, error)
+	Save(p *Profile) error
+	Close() error
+}
+
+// DetectProvider returns the mail provider of a domain served by the mx hosts,
+// in order of preference, or "" without any
+func DetectProvider(mx []string) string {
+	if len(mx) == 0 {
+		return ""
+	}
+	for _, host := range mx {
+		host = strings.TrimSuffix(normalize(host), ".")
+		for _, known := range providerMXSuffixes {
+			if host == known.suffix || strings.HasSuffix(host, "."+known.suffix) {
+				return known.provider
+			}
+		}
+	}
+	return ProviderSelfHosted
+}
+
+// normalize makes domains compare case-insensitively
+func normalize(domain string) string {
+	return strings.ToLower(strings.TrimSpace(domain))
+}
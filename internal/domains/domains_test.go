@@ -0,0 +1,256 @@
+package domains
+
+import (
+	"context"
+	"email-finder/internal/verifier"
+	"errors"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// storeTests runs the same checks against every Store implementation
+func storeTests(t *testing.T, newStore func(t *testing.T) Store) {
+	t.Run("save and get", func(t *testing.T) {
+		store := newStore(t)
+		catchAll := true
+		saved := &Profile{
+			Domain:    "Acme.com",
+			MXRecords: []string{"mx1.acme.com."},
+			Provider:  ProviderSelfHosted,
+			CatchAll:  &catchAll,
+			CheckedAt: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		}
+		if err := store.Save(saved); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		saved.MXRecords[0] = "changed"
+
+		got, err := store.Get(" ACME.COM")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got.Domain != "Acme.com" || !slices.Equal(got.MXRecords, []string{"mx1.acme.com."}) || got.CatchAll == nil || !*got.CatchAll || !got.CheckedAt.Equal(saved.CheckedAt) {
+			t.Errorf("Get() = %+v, want the saved profile", got)
+		}
+	})
+
+	t.Run("unknown domain", func(t *testing.T) {
+		store := newStore(t)
+		if _, err := store.Get("unknown.com"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestMemoryStore(t *testing.T) {
+	storeTests(t, func(t *testing.T) Store {
+		return NewMemoryStore()
+	})
+}
+
+func TestBoltStore(t *testing.T) {
+	storeTests(t, func(t *testing.T) Store {
+		store, err := OpenBoltStore(filepath.Join(t.TempDir(), "domains.db"))
+		if err != nil {
+			t.Fatalf("OpenBoltStore() error = %v", err)
+		}
+		t.Cleanup(func() { store.Close() })
+		return store
+	})
+}
+
+func TestDetectProvider(t *testing.T) {
+	tests := []struct {
+		name string
+		mx   []string
+		want string
+	}{
+		{"google workspace", []string{"aspmx.l.google.com.", "alt1.aspmx.l.google.com."}, ProviderGoogleWorkspace},
+		{"legacy google", []string{"aspmx.googlemail.com"}, ProviderGoogleWorkspace},
+		{"microsoft 365", []string{"acme-com.mail.protection.outlook.com."}, ProviderMicrosoft365},
+		{"zoho", []string{"MX.ZOHO.EU."}, ProviderZoho},
+		{"backup at a provider", []string{"mail.acme.com.", "aspmx.l.google.com."}, ProviderGoogleWorkspace},
+		{"own server", []string{"mail.acme.com."}, ProviderSelfHosted},
+		{"lookalike host", []string{"mx.notgoogle.com."}, ProviderSelfHosted},
+		{"no MX", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectProvider(tt.mx); got != tt.want {
+				t.Errorf("DetectProvider(%v) = %q, want %q", tt.mx, got, tt.want)
+			}
+		})
+	}
+}
+
+// newTestProfiles creates profiles on store trusting DNS checks for dnsTTL
+func newTestProfiles(t *testing.T, store Store, dnsTTL time.Duration) *Profiles {
+	t.Helper()
+	p := NewProfiles(store, Config{DNSTTL: dnsTTL, Buffer: 10}, zap.NewNop())
+	t.Cleanup(func() { p.Close(context.Background()) })
+	return p
+}
+
+// written waits until the queued updates of p are written and returns new
+// profiles on the same store
+func written(t *testing.T, p *Profiles) *Profiles {
+	t.Helper()
+	if err := p.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	return newTestProfiles(t, p.store, p.cfg.DNSTTL)
+}
+
+func TestProfiles_DNS(t *testing.T) {
+	store := NewMemoryStore()
+	profiles := newTestProfiles(t, store, time.Hour)
+
+	if profiles.Resolves("acme.com") {
+		t.Error("Resolves() = true for an unknown domain")
+	}
+	profiles.ObserveDNS("ACME.com", []string{"aspmx.l.google.com."})
+	profiles = written(t, profiles)
+	if !profiles.Resolves("acme.com") {
+		t.Error("Resolves() = false right after the domain was found")
+	}
+
+	profile, err := profiles.Get("acme.com")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if !profile.DNSVerified || profile.Provider != ProviderGoogleWorkspace || profile.CheckedAt.IsZero() {
+		t.Errorf("profile = %+v, want a DNS verified Google Workspace domain", profile)
+	}
+
+	// A DNS check older than the TTL is done again
+	stale := profile.CheckedAt.Add(-2 * time.Hour)
+	profile.DNSCheckedAt = &stale
+	store.Save(profile)
+	if profiles.Resolves("acme.com") {
+		t.Error("Resolves() = true for a stale DNS check")
+	}
+
+	if newTestProfiles(t, store, 0).Resolves("acme.com") {
+		t.Error("Resolves() = true with a TTL of 0")
+	}
+}
+
+func TestProfiles_ObserveVerifications(t *testing.T) {
+	profiles := newTestProfiles(t, NewMemoryStore(), time.Hour)
+	result := func(email string, connected, catchAll bool) *verifier.VerificationResult {
+		return &verifier.VerificationResult{
+			Email: email,
+			MX:    &verifier.MXDetails{AcceptsMail: true, Records: []string{"acme-com.mail.protection.outlook.com."}},
+			SMTP:  &verifier.SMTPDetails{CanConnectSMTP: connected, IsCatchAll: catchAll},
+		}
+	}
+
+	// A lookup that couldn't connect doesn't tell whether the domain is catch-all
+	profiles.ObserveVerifications("acme.com", []*verifier.VerificationResult{result("john@acme.com", false, false)}, "")
+	profiles = written(t, profiles)
+	profile, _ := profiles.Get("acme.com")
+	if profile.Provider != ProviderMicrosoft365 || profile.CatchAll != nil {
+		t.Errorf("profile = %+v, want a Microsoft 365 domain of unknown catch-all status", profile)
+	}
+
+	profiles.ObserveVerifications("acme.com", []*verifier.VerificationResult{result("john.doe@acme.com", true, false)}, "firstname.lastname")
+	profiles = written(t, profiles)
+	profile, _ = profiles.Get("acme.com")
+	if profile.CatchAll == nil || *profile.CatchAll || profile.VerifiedPattern != "firstname.lastname" {
+		t.Errorf("profile = %+v, want a domain that isn't catch-all with a verified pattern", profile)
+	}
+
+	// A DNS check without MX hosts keeps the ones verification reported
+	profiles.ObserveDNS("acme.com", nil)
+	profiles = written(t, profiles)
+	profile, _ = profiles.Get("acme.com")
+	if len(profile.MXRecords) != 1 || profile.Provider != ProviderMicrosoft365 {
+		t.Errorf("profile = %+v, want the MX hosts kept", profile)
+	}
+
+	// At a catch-all domain, a deliverable address doesn't reveal the pattern
+	profiles.ObserveVerifications("globex.com", []*verifier.VerificationResult{result("jdoe@globex.com", true, true)}, "flastname")
+	profiles = written(t, profiles)
+	profile, _ = profiles.Get("globex.com")
+	if profile.CatchAll == nil || !*profile.CatchAll || profile.VerifiedPattern != "" {
+		t.Errorf("profile = %+v, want a catch-all domain without a verified pattern", profile)
+	}
+}
+
+func TestProfiles_PatternsSurviveRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domains.db")
+	store, err := OpenBoltStore(path)
+	if err != nil {
+		t.Fatalf("OpenBoltStore() error = %v", err)
+	}
+	profiles := NewProfiles(store, Config{DNSTTL: time.Hour, Buffer: 10}, zap.NewNop())
+	profiles.ObservePatterns("acme.com", []string{"firstname.lastname"})
+	profiles.Close(context.Background())
+	store.Close()
+
+	store, err = OpenBoltStore(path)
+	if err != nil {
+		t.Fatalf("OpenBoltStore() error = %v", err)
+	}
+	defer store.Close()
+	profiles = newTestProfiles(t, store, time.Hour)
+	if got := profiles.LearnedPatterns("Acme.com"); !slices.Equal(got, []string{"firstname.lastname"}) {
+		t.Errorf("LearnedPatterns() = %v after reopening, want [firstname.lastname]", got)
+	}
+	if got := profiles.LearnedPatterns("globex.com"); got != nil {
+		t.Errorf("LearnedPatterns() = %v for an unknown domain, want nil", got)
+	}
+}
+
+func TestProfiles_UpdatesDontWaitForTheStore(t *testing.T) {
+	store := &blockingStore{Store: NewMemoryStore(), saving: make(chan struct{}), release: make(chan struct{})}
+	profiles := NewProfiles(store, Config{DNSTTL: time.Hour, Buffer: 2}, zap.NewNop())
+
+	// The first update is being written and two more wait in the buffer; a
+	// fourth is dropped instead of holding up the lookup
+	done := make(chan struct{})
+	go func() {
+		for _, domain := range []string{"acme.com", "globex.com", "initech.com", "hooli.com"} {
+			profiles.ObserveDNS(domain, nil)
+			if domain == "acme.com" {
+				<-store.saving
+			}
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("updates waited for the store")
+	}
+
+	close(store.release)
+	if err := profiles.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	for domain, want := range map[string]bool{"acme.com": true, "globex.com": true, "initech.com": true, "hooli.com": false} {
+		if _, err := store.Get(domain); (err == nil) != want {
+			t.Errorf("Get(%s) error = %v, want stored %v", domain, err, want)
+		}
+	}
+}
+
+// blockingStore holds every Save until release is closed, and closes saving
+// when the first one starts
+type blockingStore struct {
+	Store
+	saving  chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (s *blockingStore) Save(p *Profile) error {
+	s.once.Do(func() { close(s.saving) })
+	<-s.release
+	return s.Store.Save(p)
+}
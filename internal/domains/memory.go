@@ -0,0 +1,59 @@
+package domains
+
+import (
+	"slices"
+	"sync"
+)
+
+// MemoryStore keeps profiles in memory; they are lost on restart
+type MemoryStore struct {
+	mu       sync.RWMutex
+	profiles map[string]Profile
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{profiles: make(map[string]Profile)}
+}
+
+// Get returns the profile of a domain
+func (s *MemoryStore) Get(domain string) (*Profile, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, exists := s.profiles[normalize(domain)]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	p = p.clone()
+	return &p, nil
+}
+
+// Save stores a profile, replacing the previous one of its domain
+func (s *MemoryStore) Save(p *Profile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.profiles[normalize(p.Domain)] = p.clone()
+	return nil
+}
+
+// Close does nothing; the profiles are dropped with the store
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// clone copies a profile, so the stored one can't be changed through it
+func (p Profile) clone() Profile {
+	p.MXRecords = slices.Clone(p.MXRecords)
+	p.Patterns = slices.Clone(p.Patterns)
+	if p.DNSCheckedAt != nil {
+		checkedAt := *p.DNSCheckedAt
+		p.DNSCheckedAt = &checkedAt
+	}
+	if p.CatchAll != nil {
+		catchAll := *p.CatchAll
+		p.CatchAll = &catchAll
+	}
+	return p
+}
@@ -0,0 +1,225 @@
+package domains
+
+import (
+	"context"
+	"email-finder/internal/metrics"
+	"email-finder/internal/verifier"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Config controls how domain profiles are trusted and written
+type Config struct {
+	// DNSTTL is how long a DNS check is trusted; 0 looks the domain up every time
+	DNSTTL time.Duration
+	// Buffer is how many updates may wait to be written before new ones are dropped
+	Buffer int
+}
+
+// Profiles learns about domains from DNS lookups, verifications and sample
+// addresses, and keeps what it learned in a store. Updates are written in the
+// background, so lookups never wait for the disk; a lookup may not see what
+// another one learned a moment before.
+type Profiles struct {
+	store  Store
+	cfg    Config
+	logger *zap.Logger
+
+	updates chan update
+	wg      sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// update is a change to the profile of a domain, learned at a given time
+type update struct {
+	domain string
+	change func(profile *Profile, now time.Time)
+	at     time.Time
+}
+
+// NewProfiles creates profiles kept in store and starts writing their updates
+func NewProfiles(store Store, cfg Config, logger *zap.Logger) *Profiles {
+	if cfg.Buffer <= 0 {
+		cfg.Buffer = 1
+	}
+	p := &Profiles{
+		store:   store,
+		cfg:     cfg,
+		logger:  logger,
+		updates: make(chan update, cfg.Buffer),
+	}
+
+	p.wg.Add(1)
+	go p.write()
+	return p
+}
+
+// Get returns what is known about a domain
+func (p *Profiles) Get(domain string) (*Profile, error) {
+	return p.store.Get(domain)
+}
+
+// Resolves reports whether the domain was found in DNS within the DNS TTL.
+// Domains that weren't found aren't remembered, so one registered since is
+// found on its next lookup.
+func (p *Profiles) Resolves(domain string) bool {
+	if p.cfg.DNSTTL <= 0 {
+		return false
+	}
+	profile := p.load(domain)
+	hit := profile != nil && profile.DNSVerified && profile.DNSCheckedAt != nil && time.Since(*profile.DNSCheckedAt) <= p.cfg.DNSTTL
+	metrics.ObserveCacheLookup("domain_dns", hit)
+	return hit
+}
+
+// ObserveDNS records that the domain was found in DNS, with its MX hosts if it has any
+func (p *Profiles) ObserveDNS(domain string, mx []string) {
+	p.update(domain, func(profile *Profile, now time.Time) {
+		profile.DNSVerified = true
+		profile.DNSCheckedAt = &now
+		if len(mx) > 0 {
+			profile.setMX(mx)
+		}
+	})
+}
+
+// ObserveVerifications records what the verification results of a lookup at
+// the domain tell about it. pattern is the pattern of the only address the
+// lookup found deliverable, or empty.
+func (p *Profiles) ObserveVerifications(domain string, results []*verifier.VerificationResult, pattern string) {
+	var mx []string
+	var catchAll *bool
+	for _, result := range results {
+		if len(mx) == 0 && result.MX != nil {
+			mx = result.MX.Records
+		}
+		// Only a server that was reached tells whether it accepts every address
+		if result.SMTP != nil && result.SMTP.CanConnectSMTP {
+			isCatchAll := result.SMTP.IsCatchAll || (catchAll != nil && *catchAll)
+			catchAll = &isCatchAll
+		}
+	}
+	if len(mx) == 0 && catchAll == nil && pattern == "" {
+		return
+	}
+
+	p.update(domain, func(profile *Profile, now time.Time) {
+		if len(mx) > 0 {
+			profile.setMX(mx)
+		}
+		if catchAll != nil {
+			profile.CatchAll = catchAll
+		}
+		// Every address is deliverable at a catch-all domain, so it tells nothing
+		if pattern != "" && (catchAll == nil || !*catchAll) {
+			profile.VerifiedPattern = pattern
+		}
+	})
+}
+
+// ObservePatterns records the patterns inferred for the domain from sample addresses
+func (p *Profiles) ObservePatterns(domain string, patterns []string) {
+	p.update(domain, func(profile *Profile, now time.Time) {
+		profile.Patterns = slices.Clone(patterns)
+	})
+}
+
+// LearnedPatterns returns the patterns inferred for the domain, if any
+func (p *Profiles) LearnedPatterns(domain string) []string {
+	if profile := p.load(domain); profile != nil {
+		return profile.Patterns
+	}
+	return nil
+}
+
+// setMX records the MX hosts of the domain and the provider they belong to
+func (profile *Profile) setMX(mx []string) {
+	profile.MXRecords = slices.Clone(mx)
+	profile.Provider = DetectProvider(mx)
+}
+
+// load returns the profile of a domain, or nil if nothing is known about it
+// or it can't be read
+func (p *Profiles) load(domain string) *Profile {
+	profile, err := p.store.Get(domain)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			p.logger.Warn("failed to load domain profile", zap.String("domain", domain), zap.Error(err))
+		}
+		return nil
+	}
+	return profile
+}
+
+// update queues a change to the profile of a domain. It is dropped when the
+// buffer is full or the profiles are closed.
+func (p *Profiles) update(domain string, change func(profile *Profile, now time.Time)) {
+	domain = normalize(domain)
+	if domain == "" {
+		return
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		metrics.ObserveDomainProfileUpdate("dropped")
+		return
+	}
+	select {
+	case p.updates <- update{domain: domain, change: change, at: time.Now().UTC()}:
+	default:
+		p.logger.Warn("domain profile buffer full, dropping update", zap.String("domain", domain))
+		metrics.ObserveDomainProfileUpdate("dropped")
+	}
+}
+
+// Close stops taking updates and waits until ctx is done for the queued ones
+// to be written
+func (p *Profiles) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.updates)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// write applies the queued updates one at a time, so updates of the same
+// domain don't lose each other's facts without holding up lookups
+func (p *Profiles) write() {
+	defer p.wg.Done()
+	for u := range p.updates {
+		profile := p.load(u.domain)
+		if profile == nil {
+			profile = &Profile{Domain: u.domain}
+		}
+		u.change(profile, u.at)
+		profile.CheckedAt = u.at
+
+		if err := p.store.Save(profile); err != nil {
+			p.logger.Warn("failed to save domain profile", zap.String("domain", u.domain), zap.Error(err))
+			metrics.ObserveDomainProfileUpdate("failed")
+			continue
+		}
+		metrics.ObserveDomainProfileUpdate("saved")
+	}
+}
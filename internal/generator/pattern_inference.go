@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"sort"
+	"strings"
+)
+
+// SampleAddress is a known, real email address for a person at a company
+type SampleAddress struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+}
+
+// PatternMatch describes how many sample addresses a pattern explains
+type PatternMatch struct {
+	Pattern string `json:"pattern"`
+	Matches int    `json:"matches"`
+}
+
+// InferPatterns works out which generator patterns explain the given sample addresses.
+// Patterns are returned ordered by the number of samples they explain, then by
+// generator priority. Samples that no pattern explains are ignored.
+func InferPatterns(samples []SampleAddress) []PatternMatch {
+	counts := make(map[string]int)
+	priority := make(map[string]int)
+
+	for _, sample := range samples {
+		email := strings.TrimSpace(strings.ToLower(sample.Email))
+		at := strings.LastIndex(email, "@")
+		if at <= 0 || at == len(email)-1 {
+			continue
+		}
+		domain := email[at+1:]
+
+		for i, p := range GenerateEmailPatterns(sample.FirstName, sample.LastName, domain) {
+			if _, ok := priority[p.Pattern]; !ok {
+				priority[p.Pattern] = i
+			}
+			if p.Email == email {
+				counts[p.Pattern]++
+			}
+		}
+	}
+
+	matches := make([]PatternMatch, 0, len(counts))
+	for pattern, count := range counts {
+		matches = append(matches, PatternMatch{Pattern: pattern, Matches: count})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Matches != matches[j].Matches {
+			return matches[i].Matches > matches[j].Matches
+		}
+		return priority[matches[i].Pattern] < priority[matches[j].Pattern]
+	})
+
+	return matches
+}
+
+// FilterPatterns keeps only the patterns whose name is in names, preserving order
+func FilterPatterns(patterns []EmailPattern, names []string) []EmailPattern {
+	allowed := make(map[string]bool, len(names))
+	for _, name := range names {
+		allowed[name] = true
+	}
+
+	filtered := make([]EmailPattern, 0, len(names))
+	for _, p := range patterns {
+		if allowed[p.Pattern] {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
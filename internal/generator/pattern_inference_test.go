@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestInferPatterns(t *testing.T) {
+	tests := []struct {
+		name        string
+		samples     []SampleAddress
+		wantPattern string
+		wantMatches int
+	}{
+		{
+			name: "single sample",
+			samples: []SampleAddress{
+				{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+			},
+			wantPattern: "firstname.lastname",
+			wantMatches: 1,
+		},
+		{
+			name: "two samples agree",
+			samples: []SampleAddress{
+				{FirstName: "John", LastName: "Doe", Email: "jdoe@example.com"},
+				{FirstName: "Jane", LastName: "Smith", Email: "JSmith@example.com"},
+			},
+			wantPattern: "flastname",
+			wantMatches: 2,
+		},
+		{
+			name: "samples disagree",
+			samples: []SampleAddress{
+				{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+				{FirstName: "Jane", LastName: "Smith", Email: "jane.smith@example.com"},
+				{FirstName: "Bob", LastName: "Jones", Email: "bob@example.com"},
+			},
+			wantPattern: "firstname.lastname",
+			wantMatches: 2,
+		},
+		{
+			name: "no pattern explains sample",
+			samples: []SampleAddress{
+				{FirstName: "John", LastName: "Doe", Email: "sales@example.com"},
+			},
+			wantPattern: "",
+			wantMatches: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := InferPatterns(tt.samples)
+			if tt.wantPattern == "" {
+				if len(matches) != 0 {
+					t.Errorf("InferPatterns() = %v, want no matches", matches)
+				}
+				return
+			}
+			if len(matches) == 0 {
+				t.Fatalf("InferPatterns() returned no matches, want %s", tt.wantPattern)
+			}
+			if matches[0].Pattern != tt.wantPattern {
+				t.Errorf("InferPatterns() top pattern = %v, want %v", matches[0].Pattern, tt.wantPattern)
+			}
+			if matches[0].Matches != tt.wantMatches {
+				t.Errorf("InferPatterns() top matches = %v, want %v", matches[0].Matches, tt.wantMatches)
+			}
+		})
+	}
+}
+
+func TestFilterPatterns(t *testing.T) {
+	patterns := GenerateEmailPatterns("john", "doe", "example.com")
+	filtered := FilterPatterns(patterns, []string{"flastname", "firstname"})
+
+	if len(filtered) != 2 {
+		t.Fatalf("FilterPatterns() returned %d patterns, want 2", len(filtered))
+	}
+	if filtered[0].Email != "jdoe@example.com" || filtered[1].Email != "john@example.com" {
+		t.Errorf("FilterPatterns() = %v, want generator order preserved", filtered)
+	}
+}
@@ -0,0 +1,137 @@
+package grpcapi
+
+import (
+	emailfinderv1 "email-finder/api/emailfinder/v1"
+	"email-finder/internal/service"
+	"email-finder/internal/verifier"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func fromFindEmailRequest(req *emailfinderv1.FindEmailRequest) service.FindEmailRequest {
+	return service.FindEmailRequest{
+		FirstName: req.GetFirstName(),
+		LastName:  req.GetLastName(),
+		Company:   req.GetCompany(),
+		Include:   req.GetInclude(),
+		Refresh:   req.GetRefresh(),
+
+		SkipVerification: req.GetSkipVerification(),
+	}
+}
+
+func toFindEmailResponse(resp *service.FindEmailResponse) *emailfinderv1.FindEmailResponse {
+	out := &emailfinderv1.FindEmailResponse{
+		FoundEmails:      make([]*emailfinderv1.EmailResult, 0, len(resp.FoundEmails)),
+		TotalChecked:     int32(resp.TotalChecked),
+		TotalFound:       int32(resp.TotalFound),
+		Domain:           resp.Domain,
+		DomainResolved:   resp.DomainResolved,
+		InferredPatterns: resp.InferredPatterns,
+		Verified:         resp.Verified,
+		StatusBreakdown:  make(map[string]int32, len(resp.StatusBreakdown)),
+	}
+	for i := range resp.FoundEmails {
+		out.FoundEmails = append(out.FoundEmails, toEmailResult(&resp.FoundEmails[i]))
+	}
+	for status, count := range resp.StatusBreakdown {
+		out.StatusBreakdown[status] = int32(count)
+	}
+	if resp.BestGuess != nil {
+		out.BestGuess = toEmailResult(resp.BestGuess)
+	}
+	if resp.CachedAt != nil {
+		out.CachedAt = timestamppb.New(*resp.CachedAt)
+	}
+	return out
+}
+
+func toEmailResult(r *service.EmailResult) *emailfinderv1.EmailResult {
+	return &emailfinderv1.EmailResult{
+		Email:         r.Email,
+		Pattern:       r.Pattern,
+		IsReachable:   r.IsReachable,
+		IsValid:       r.IsValid,
+		IsDeliverable: r.IsDeliverable,
+		Confidence:    r.Confidence,
+		Score:         int32(r.Score),
+		ScoreBreakdown: &emailfinderv1.ScoreBreakdown{
+			Status:           int32(r.ScoreBreakdown.Status),
+			Deliverability:   int32(r.ScoreBreakdown.Deliverability),
+			CatchAll:         int32(r.ScoreBreakdown.CatchAll),
+			PatternPrior:     int32(r.ScoreBreakdown.PatternPrior),
+			DomainResolution: int32(r.ScoreBreakdown.DomainResolution),
+		},
+		MxRecords:      r.MXRecords,
+		CanConnectSmtp: r.CanConnectSMTP,
+		IsCatchAll:     r.IsCatchAll,
+		IsDisabled:     r.IsDisabled,
+		HasFullInbox:   r.HasFullInbox,
+		IsDisposable:   r.IsDisposable,
+		IsRoleAccount:  r.IsRoleAccount,
+		SmtpError:      toCheckError(r.SMTPError),
+		MxError:        toCheckError(r.MXError),
+	}
+}
+
+func toVerificationResult(r *verifier.VerificationResult) *emailfinderv1.VerificationResult {
+	out := &emailfinderv1.VerificationResult{
+		Email:         r.Email,
+		IsReachable:   r.IsReachable,
+		IsValid:       r.IsValid,
+		IsDeliverable: r.IsDeliverable,
+		MxError:       toCheckError(r.MXError),
+		SmtpError:     toCheckError(r.SMTPError),
+		MiscError:     toCheckError(r.MiscError),
+	}
+	if r.Syntax != nil {
+		out.Syntax = &emailfinderv1.SyntaxDetails{
+			Address:       r.Syntax.Address,
+			Domain:        r.Syntax.Domain,
+			Username:      r.Syntax.Username,
+			IsValidSyntax: r.Syntax.IsValidSyntax,
+		}
+	}
+	if r.MX != nil {
+		out.Mx = &emailfinderv1.MXDetails{
+			AcceptsMail: r.MX.AcceptsMail,
+			Records:     r.MX.Records,
+		}
+	}
+	if r.SMTP != nil {
+		out.Smtp = &emailfinderv1.SMTPDetails{
+			CanConnectSmtp: r.SMTP.CanConnectSMTP,
+			HasFullInbox:   r.SMTP.HasFullInbox,
+			IsCatchAll:     r.SMTP.IsCatchAll,
+			IsDeliverable:  r.SMTP.IsDeliverable,
+			IsDisabled:     r.SMTP.IsDisabled,
+		}
+	}
+	if r.Misc != nil {
+		out.Misc = &emailfinderv1.MiscDetails{
+			IsDisposable:  r.Misc.IsDisposable,
+			IsRoleAccount: r.Misc.IsRoleAccount,
+			GravatarUrl:   r.Misc.GravatarURL,
+		}
+	}
+	if r.Failure != nil {
+		out.Failure = &emailfinderv1.Failure{
+			Kind:       r.Failure.Kind,
+			Message:    r.Failure.Message,
+			Transient:  r.Failure.Transient,
+			Greylisted: r.Failure.Greylisted,
+		}
+	}
+	return out
+}
+
+func toCheckError(e *verifier.CheckError) *emailfinderv1.CheckError {
+	if e == nil {
+		return nil
+	}
+	return &emailfinderv1.CheckError{
+		Type:    e.Type,
+		Message: e.Message,
+		Code:    int32(e.Code),
+	}
+}
@@ -0,0 +1,31 @@
+package grpcapi
+
+import (
+	emailfinderv1 "email-finder/api/emailfinder/v1"
+	"email-finder/internal/service"
+	"reflect"
+	"testing"
+)
+
+func TestFromFindEmailRequest(t *testing.T) {
+	got := fromFindEmailRequest(&emailfinderv1.FindEmailRequest{
+		FirstName:        "John",
+		LastName:         "Doe",
+		Company:          "Acme",
+		Include:          []string{"risky"},
+		Refresh:          true,
+		SkipVerification: true,
+	})
+
+	want := service.FindEmailRequest{
+		FirstName:        "John",
+		LastName:         "Doe",
+		Company:          "Acme",
+		Include:          []string{"risky"},
+		Refresh:          true,
+		SkipVerification: true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fromFindEmailRequest() = %+v, want %+v", got, want)
+	}
+}
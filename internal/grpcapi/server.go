@@ -0,0 +1,211 @@
+// Package grpcapi serves the email finder over gRPC, backed by the same
+// service as the REST API
+package grpcapi
+
+import (
+	"context"
+	emailfinderv1 "email-finder/api/emailfinder/v1"
+	"email-finder/internal/service"
+	"email-finder/internal/verifier"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements the EmailFinderService gRPC service
+type Server struct {
+	emailfinderv1.UnimplementedEmailFinderServiceServer
+
+	service *service.EmailFinderService
+	logger  *zap.Logger
+}
+
+// NewServer creates a new gRPC service implementation
+func NewServer(svc *service.EmailFinderService, logger *zap.Logger) *Server {
+	return &Server{
+		service: svc,
+		logger:  logger,
+	}
+}
+
+// NewGRPCServer creates a gRPC server with the email finder, health and
+// reflection services registered. The health service reports SERVING until
+// the returned health server is shut down.
+func NewGRPCServer(svc *service.EmailFinderService, logger *zap.Logger) (*grpc.Server, *health.Server) {
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unaryLogger(logger), unaryRecovery(logger)),
+		grpc.ChainStreamInterceptor(streamLogger(logger), streamRecovery(logger)),
+	)
+
+	emailfinderv1.RegisterEmailFinderServiceServer(server, NewServer(svc, logger))
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(emailfinderv1.EmailFinderService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	// Lets tools such as grpcurl discover the API
+	reflection.Register(server)
+
+	return server, healthServer
+}
+
+// FindEmail handles EmailFinderService.FindEmail
+func (s *Server) FindEmail(ctx context.Context, req *emailfinderv1.FindEmailRequest) (*emailfinderv1.FindEmailResponse, error) {
+	findReq := fromFindEmailRequest(req)
+	if err := findReq.Validate(); err != nil {
+		return nil, toStatus(err)
+	}
+
+	result, err := s.service.FindEmails(ctx, findReq)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toFindEmailResponse(result), nil
+}
+
+// FindEmailStream handles EmailFinderService.FindEmailStream
+func (s *Server) FindEmailStream(req *emailfinderv1.FindEmailRequest, stream emailfinderv1.EmailFinderService_FindEmailStreamServer) error {
+	findReq := fromFindEmailRequest(req)
+	if err := findReq.Validate(); err != nil {
+		return toStatus(err)
+	}
+
+	// A failed send means the client went away; the lookup is cancelled with it
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	var sendErr error
+	result, err := s.service.FindEmailsStream(ctx, findReq, func(result *verifier.VerificationResult) {
+		if sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(&emailfinderv1.FindEmailStreamResponse{
+			Event: &emailfinderv1.FindEmailStreamResponse_Verification{Verification: toVerificationResult(result)},
+		})
+		if sendErr != nil {
+			cancel()
+		}
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return toStatus(err)
+	}
+
+	return stream.Send(&emailfinderv1.FindEmailStreamResponse{
+		Event: &emailfinderv1.FindEmailStreamResponse_Summary{Summary: toFindEmailResponse(result)},
+	})
+}
+
+// VerifyEmail handles EmailFinderService.VerifyEmail
+func (s *Server) VerifyEmail(ctx context.Context, req *emailfinderv1.VerifyEmailRequest) (*emailfinderv1.VerificationResult, error) {
+	result, err := s.service.VerifyEmails(ctx, service.VerifyEmailsRequest{Emails: []string{req.GetEmail()}})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toVerificationResult(result.Results[0]), nil
+}
+
+// VerifyEmails handles EmailFinderService.VerifyEmails
+func (s *Server) VerifyEmails(ctx context.Context, req *emailfinderv1.VerifyEmailsRequest) (*emailfinderv1.VerifyEmailsResponse, error) {
+	result, err := s.service.VerifyEmails(ctx, service.VerifyEmailsRequest{Emails: req.GetEmails()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &emailfinderv1.VerifyEmailsResponse{
+		Results: make([]*emailfinderv1.VerificationResult, 0, len(result.Results)),
+	}
+	for _, r := range result.Results {
+		resp.Results = append(resp.Results, toVerificationResult(r))
+	}
+	return resp, nil
+}
+
+// ResolveDomain handles EmailFinderService.ResolveDomain
+func (s *Server) ResolveDomain(ctx context.Context, req *emailfinderv1.ResolveDomainRequest) (*emailfinderv1.DomainResult, error) {
+	result, err := s.service.ResolveDomain(ctx, service.ResolveDomainRequest{Company: req.GetCompany()})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &emailfinderv1.DomainResult{
+		Domain:     result.Domain,
+		Resolved:   result.Resolved,
+		Method:     result.Method,
+		Candidates: result.Candidates,
+	}, nil
+}
+
+// toStatus maps service errors to gRPC status codes, like the REST handlers map
+// them to HTTP status codes
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidRequest):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, verifier.ErrCircuitOpen):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func unaryLogger(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logger.Info("gRPC request",
+			zap.String("method", info.FullMethod),
+			zap.String("code", status.Code(err).String()),
+			zap.Duration("latency", time.Since(start)),
+		)
+		return resp, err
+	}
+}
+
+func streamLogger(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logger.Info("gRPC stream",
+			zap.String("method", info.FullMethod),
+			zap.String("code", status.Code(err).String()),
+			zap.Duration("latency", time.Since(start)),
+		)
+		return err
+	}
+}
+
+func unaryRecovery(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("gRPC handler panicked", zap.String("method", info.FullMethod), zap.Any("panic", r))
+				err = status.Error(codes.Internal, "internal error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func streamRecovery(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("gRPC handler panicked", zap.String("method", info.FullMethod), zap.Any("panic", r))
+				err = status.Error(codes.Internal, "internal error")
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
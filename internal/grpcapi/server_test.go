@@ -0,0 +1,164 @@
+package grpcapi
+
+import (
+	"context"
+	emailfinderv1 "email-finder/api/emailfinder/v1"
+	"email-finder/internal/resolver"
+	"email-finder/internal/service"
+	"email-finder/internal/verifier"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeVerifier accepts only the addresses in valid
+type fakeVerifier struct {
+	valid map[string]bool
+}
+
+func (f *fakeVerifier) VerifyEmail(ctx context.Context, email string) (*verifier.VerificationResult, error) {
+	if f.valid[email] {
+		return &verifier.VerificationResult{Email: email, IsReachable: "safe", IsValid: true, IsDeliverable: true}, nil
+	}
+	return &verifier.VerificationResult{Email: email, IsReachable: "invalid", IsValid: true}, nil
+}
+
+func (f *fakeVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*verifier.VerificationResult, error) {
+	results := make([]*verifier.VerificationResult, 0, len(emails))
+	for _, email := range emails {
+		result, _ := f.VerifyEmail(ctx, email)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// fakeResolver maps every company to one domain
+type fakeResolver struct {
+	domain string
+}
+
+func (f *fakeResolver) ResolveDomain(ctx context.Context, companyName string) *resolver.DomainResult {
+	return &resolver.DomainResult{Domain: f.domain, Resolved: true, Method: "company_map"}
+}
+
+// newTestClient serves the API over an in-memory connection
+func newTestClient(t *testing.T) emailfinderv1.EmailFinderServiceClient {
+	t.Helper()
+
+	svc := service.NewEmailFinderService(
+		&fakeVerifier{valid: map[string]bool{"john.doe@example.org": true}},
+		&fakeResolver{domain: "example.org"},
+		zap.NewNop(),
+		5,
+	)
+	server, _ := NewGRPCServer(svc, zap.NewNop())
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return emailfinderv1.NewEmailFinderServiceClient(conn)
+}
+
+func TestFindEmail(t *testing.T) {
+	client := newTestClient(t)
+
+	resp, err := client.FindEmail(context.Background(), &emailfinderv1.FindEmailRequest{
+		FirstName: "John", LastName: "Doe", Company: "Example",
+	})
+	if err != nil {
+		t.Fatalf("FindEmail() error = %v", err)
+	}
+	if resp.Domain != "example.org" || resp.TotalChecked != 5 {
+		t.Errorf("Domain = %q, TotalChecked = %d, want example.org and 5", resp.Domain, resp.TotalChecked)
+	}
+	if resp.TotalFound != 1 || resp.FoundEmails[0].Email != "john.doe@example.org" {
+		t.Errorf("FoundEmails = %v, want only john.doe@example.org", resp.FoundEmails)
+	}
+}
+
+func TestFindEmail_InvalidArgument(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.FindEmail(context.Background(), &emailfinderv1.FindEmailRequest{FirstName: "John"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("FindEmail() without a company code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestFindEmailStream(t *testing.T) {
+	client := newTestClient(t)
+
+	stream, err := client.FindEmailStream(context.Background(), &emailfinderv1.FindEmailRequest{
+		FirstName: "John", LastName: "Doe", Company: "Example",
+	})
+	if err != nil {
+		t.Fatalf("FindEmailStream() error = %v", err)
+	}
+
+	verifications := 0
+	var summary *emailfinderv1.FindEmailResponse
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		if summary != nil {
+			t.Fatal("received an event after the summary")
+		}
+		switch event := msg.Event.(type) {
+		case *emailfinderv1.FindEmailStreamResponse_Verification:
+			verifications++
+		case *emailfinderv1.FindEmailStreamResponse_Summary:
+			summary = event.Summary
+		}
+	}
+
+	if verifications != 5 {
+		t.Errorf("received %d verifications, want 5", verifications)
+	}
+	if summary == nil || summary.TotalFound != 1 {
+		t.Errorf("summary = %v, want one found email", summary)
+	}
+}
+
+func TestVerifyEmails(t *testing.T) {
+	client := newTestClient(t)
+
+	resp, err := client.VerifyEmails(context.Background(), &emailfinderv1.VerifyEmailsRequest{
+		Emails: []string{"john.doe@example.org", "jd@example.org"},
+	})
+	if err != nil {
+		t.Fatalf("VerifyEmails() error = %v", err)
+	}
+	if len(resp.Results) != 2 || resp.Results[0].IsReachable != "safe" || resp.Results[1].IsReachable != "invalid" {
+		t.Errorf("Results = %v, want safe then invalid", resp.Results)
+	}
+
+	_, err = client.VerifyEmails(context.Background(), &emailfinderv1.VerifyEmailsRequest{})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("VerifyEmails() with no emails code = %v, want InvalidArgument", status.Code(err))
+	}
+}
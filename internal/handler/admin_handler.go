@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"email-finder/internal/reconfig"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler serves the runtime configuration endpoints
+type AdminHandler struct {
+	reconfigurer *reconfig.Reconfigurer
+	token        string
+}
+
+// NewAdminHandler creates a new admin handler whose endpoints require token
+func NewAdminHandler(reconfigurer *reconfig.Reconfigurer, token string) *AdminHandler {
+	return &AdminHandler{
+		reconfigurer: reconfigurer,
+		token:        token,
+	}
+}
+
+// RequireToken rejects requests without a matching "Authorization: Bearer" token
+func (h *AdminHandler) RequireToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "a valid admin token is required",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// GetConfig handles GET /admin/config. It returns the configuration in effect as YAML.
+func (h *AdminHandler) GetConfig(c *gin.Context) {
+	var buf bytes.Buffer
+	if err := h.reconfigurer.Current().Write(&buf); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to encode configuration",
+			"details": err.Error(),
+		})
+		return
+	}
+	c.Data(http.StatusOK, "application/yaml", buf.Bytes())
+}
+
+// UpdateConfig handles PATCH /admin/config. It applies the given safe-to-change settings.
+func (h *AdminHandler) UpdateConfig(c *gin.Context) {
+	var update reconfig.Update
+	if err := c.ShouldBindJSON(&update); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request. Please provide the settings to change.",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.reconfigurer.Update(update, "admin:"+c.ClientIP())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid settings",
+			"details": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ReloadConfig handles POST /admin/config/reload. It re-reads the config file,
+// environment and flags, like SIGHUP.
+func (h *AdminHandler) ReloadConfig(c *gin.Context) {
+	result, err := h.reconfigurer.Reload("admin:" + c.ClientIP())
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error":   "Failed to reload configuration",
+			"details": err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"email-finder/internal/domains"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// DomainHandler answers queries about what lookups learned about domains
+type DomainHandler struct {
+	profiles *domains.Profiles
+	logger   *zap.Logger
+}
+
+// NewDomainHandler creates a new domain handler
+func NewDomainHandler(profiles *domains.Profiles, logger *zap.Logger) *DomainHandler {
+	return &DomainHandler{
+		profiles: profiles,
+		logger:   logger,
+	}
+}
+
+// GetDomain handles GET /api/v1/domains/:domain
+func (h *DomainHandler) GetDomain(c *gin.Context) {
+	profile, err := h.profiles.Get(c.Param("domain"))
+	if err != nil {
+		if errors.Is(err, domains.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Domain not found",
+			})
+			return
+		}
+		h.logger.Error("failed to load domain profile", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load domain",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
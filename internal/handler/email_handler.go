@@ -1,8 +1,17 @@
 package handler
 
 import (
+	"context"
+	"email-finder/internal/jobs"
 	"email-finder/internal/service"
+	"email-finder/internal/verifier"
+	"email-finder/internal/webhook"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -10,8 +19,13 @@ import (
 
 // EmailHandler handles HTTP requests for email finding
 type EmailHandler struct {
-	service *service.EmailFinderService
-	logger  *zap.Logger
+	service  *service.EmailFinderService
+	webhooks *webhook.Dispatcher
+	jobs     *jobs.Queue
+	logger   *zap.Logger
+
+	// retryAfter is sent with 503 responses while the verification backend is unavailable
+	retryAfter time.Duration
 }
 
 // NewEmailHandler creates a new email handler
@@ -22,6 +36,36 @@ func NewEmailHandler(svc *service.EmailFinderService, logger *zap.Logger) *Email
 	}
 }
 
+// SetWebhooks enables requests with a callback_url, whose results are delivered
+// by the dispatcher
+func (h *EmailHandler) SetWebhooks(webhooks *webhook.Dispatcher) {
+	h.webhooks = webhooks
+}
+
+// SetJobs queues bulk requests too large to answer synchronously as jobs
+func (h *EmailHandler) SetJobs(queue *jobs.Queue) {
+	h.jobs = queue
+}
+
+// SetRetryAfter makes responses to requests failed by an open circuit breaker
+// tell clients to retry after d, the time the circuit stays open
+func (h *EmailHandler) SetRetryAfter(d time.Duration) {
+	h.retryAfter = d
+}
+
+// backendUnavailable answers a request that failed because the verification
+// backend is unavailable with 503 Service Unavailable
+func (h *EmailHandler) backendUnavailable(c *gin.Context, err error) {
+	h.logger.Warn("verification backend unavailable", zap.Error(err))
+	if h.retryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(h.retryAfter.Seconds()))))
+	}
+	c.JSON(http.StatusServiceUnavailable, gin.H{
+		"error":   "Email verification backend is unavailable, please retry later",
+		"details": err.Error(),
+	})
+}
+
 // FindEmail handles POST /api/v1/find-email
 func (h *EmailHandler) FindEmail(c *gin.Context) {
 	var req service.FindEmailRequest
@@ -35,17 +79,28 @@ func (h *EmailHandler) FindEmail(c *gin.Context) {
 		return
 	}
 
-	// Validate inputs
-	if req.FirstName == "" || req.LastName == "" || req.Company == "" {
+	if err := req.Validate(); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "first_name, last_name, and company are required fields",
+			"error":   "Invalid request",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if req.CallbackURL != "" {
+		h.runWithCallback(c, req.CallbackURL, "find_email", func(ctx context.Context) (interface{}, error) {
+			return h.service.FindEmails(ctx, req)
 		})
 		return
 	}
 
 	// Find emails
-	result, err := h.service.FindEmails(req)
+	result, err := h.service.FindEmails(c.Request.Context(), req)
 	if err != nil {
+		if errors.Is(err, verifier.ErrCircuitOpen) {
+			h.backendUnavailable(c, err)
+			return
+		}
 		h.logger.Error("failed to find emails", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to process email search",
@@ -57,6 +112,192 @@ func (h *EmailHandler) FindEmail(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// FindEmailsBulk handles POST /api/v1/find-email/bulk
+func (h *EmailHandler) FindEmailsBulk(c *gin.Context) {
+	var req service.BulkFindRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request. Please provide requests.",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid bulk request",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	// A batch answered later becomes a job, which survives a restart: one with a
+	// callback_url, and one too large for the caller to wait for many rounds of
+	// lookups. Its results are fetched from /api/v1/jobs.
+	if req.CallbackURL != "" || len(req.Requests) > service.MaxSyncBulkRequests {
+		if h.jobs == nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid bulk request",
+				"details": fmt.Sprintf("jobs are not configured on this server: at most %d requests can be submitted, without a callback_url", service.MaxSyncBulkRequests),
+			})
+			return
+		}
+		job, err := h.jobs.Submit(req.Requests, req.CallbackURL)
+		if err != nil {
+			submitJobError(c, err, h.logger)
+			return
+		}
+		c.JSON(http.StatusAccepted, job)
+		return
+	}
+
+	result, err := h.service.FindEmailsBulk(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, verifier.ErrCircuitOpen) {
+			h.backendUnavailable(c, err)
+			return
+		}
+		h.logger.Error("failed to run bulk lookup", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to process bulk lookup",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// runWithCallback starts work in the background and answers 202 Accepted with
+// the delivery that will carry its result to callbackURL
+func (h *EmailHandler) runWithCallback(c *gin.Context, callbackURL, event string, work func(ctx context.Context) (interface{}, error)) {
+	if h.webhooks == nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "callback_url is not supported: webhooks are not configured on this server",
+		})
+		return
+	}
+
+	delivery, err := h.webhooks.Run(callbackURL, event, work)
+	if err != nil {
+		if errors.Is(err, webhook.ErrClosed) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error": "The server is shutting down, please retry later",
+			})
+			return
+		}
+		h.logger.Error("failed to start lookup", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to start lookup",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, webhook.Accepted{
+		ID:        delivery.ID,
+		Status:    delivery.Status,
+		StatusURL: "/api/v1/webhooks/deliveries/" + delivery.ID,
+	})
+}
+
+// InferPattern handles POST /api/v1/infer-pattern
+func (h *EmailHandler) InferPattern(c *gin.Context) {
+	var req service.InferPatternRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request. Please provide samples with first_name, last_name, and email.",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service.InferPattern(req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidSamples) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid samples",
+				"details": err.Error(),
+			})
+			return
+		}
+		h.logger.Error("failed to infer pattern", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to infer email pattern",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// VerifyEmails handles POST /api/v1/verify
+func (h *EmailHandler) VerifyEmails(c *gin.Context) {
+	var req service.VerifyEmailsRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request. Please provide emails.",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service.VerifyEmails(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRequest) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":   "Invalid emails",
+				"details": err.Error(),
+			})
+			return
+		}
+		if errors.Is(err, verifier.ErrCircuitOpen) {
+			h.backendUnavailable(c, err)
+			return
+		}
+		h.logger.Error("failed to verify emails", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to verify emails",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ResolveDomain handles POST /api/v1/resolve-domain
+func (h *EmailHandler) ResolveDomain(c *gin.Context) {
+	var req service.ResolveDomainRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request. Please provide company.",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service.ResolveDomain(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid company",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // HealthCheck handles GET /health
 func (h *EmailHandler) HealthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
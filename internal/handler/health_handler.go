@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"email-finder/internal/health"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler serves liveness and readiness probes
+type HealthHandler struct {
+	checker *health.Checker
+}
+
+// NewHealthHandler creates a new health handler
+func NewHealthHandler(checker *health.Checker) *HealthHandler {
+	return &HealthHandler{
+		checker: checker,
+	}
+}
+
+// Live handles GET /health/live. It only reports that the process is serving requests.
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "alive",
+		"service": "email-finder",
+	})
+}
+
+// Ready handles GET /health/ready. It probes every dependency and returns 503
+// unless all critical ones are up.
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.checker.Check(c.Request.Context())
+	if !report.Ready {
+		c.JSON(http.StatusServiceUnavailable, report)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"email-finder/internal/history"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultHistoryLimit is how many records are listed when no limit is given
+	defaultHistoryLimit = 50
+	// maxHistoryLimit is the most records listed at once
+	maxHistoryLimit = 1000
+)
+
+// HistoryHandler answers queries about past lookups
+type HistoryHandler struct {
+	recorder *history.Recorder
+	logger   *zap.Logger
+}
+
+// NewHistoryHandler creates a new history handler
+func NewHistoryHandler(recorder *history.Recorder, logger *zap.Logger) *HistoryHandler {
+	return &HistoryHandler{
+		recorder: recorder,
+		logger:   logger,
+	}
+}
+
+// ListHistory handles GET /api/v1/history
+func (h *HistoryHandler) ListHistory(c *gin.Context) {
+	limit, ok := queryInt(c, "limit", defaultHistoryLimit, 1)
+	if !ok {
+		return
+	}
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+	from, ok := queryTime(c, "from", false)
+	if !ok {
+		return
+	}
+	to, ok := queryTime(c, "to", true)
+	if !ok {
+		return
+	}
+
+	records, err := h.recorder.Find(history.Query{
+		Email:   c.Query("email"),
+		Domain:  c.Query("domain"),
+		Company: c.Query("company"),
+		From:    from,
+		To:      to,
+		Limit:   limit,
+	})
+	if err != nil {
+		h.logger.Error("failed to query history", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to query history",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"records": records,
+	})
+}
+
+// GetRecord handles GET /api/v1/history/:id
+func (h *HistoryHandler) GetRecord(c *gin.Context) {
+	record, err := h.recorder.Get(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, history.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Record not found",
+			})
+			return
+		}
+		h.logger.Error("failed to load history record", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to load record",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+// queryTime reads an RFC 3339 time or a date query parameter, answering 400
+// when it is invalid. A date as the end of a range includes the whole day.
+func queryTime(c *gin.Context, name string, end bool) (time.Time, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return time.Time{}, true
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": name + " must be an RFC 3339 time or a YYYY-MM-DD date",
+		})
+		return time.Time{}, false
+	}
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, true
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"email-finder/internal/jobs"
+	"email-finder/internal/service"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultJobsLimit is how many jobs are listed when no limit is given
+	defaultJobsLimit = 50
+	// defaultRowsLimit is how many rows of a job are returned when no limit is given
+	defaultRowsLimit = 100
+	// maxRowsLimit is the most rows of a job returned at once
+	maxRowsLimit = 1000
+)
+
+// JobHandler submits bulk enrichment jobs to the durable queue and reports their progress
+type JobHandler struct {
+	queue  *jobs.Queue
+	logger *zap.Logger
+}
+
+// NewJobHandler creates a new job handler
+func NewJobHandler(queue *jobs.Queue, logger *zap.Logger) *JobHandler {
+	return &JobHandler{
+		queue:  queue,
+		logger: logger,
+	}
+}
+
+// SubmitJob handles POST /api/v1/jobs
+func (h *JobHandler) SubmitJob(c *gin.Context) {
+	var req jobs.SubmitRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid request", zap.Error(err))
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request. Please provide requests.",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	job, err := h.queue.Submit(req.Requests, req.CallbackURL)
+	if err != nil {
+		submitJobError(c, err, h.logger)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// submitJobError answers a request whose job could not be queued
+func submitJobError(c *gin.Context, err error, logger *zap.Logger) {
+	switch {
+	case errors.Is(err, service.ErrInvalidRequest), errors.Is(err, jobs.ErrCallbacksDisabled):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid job",
+			"details": err.Error(),
+		})
+	case errors.Is(err, jobs.ErrClosed):
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "The server is shutting down, please retry later",
+		})
+	default:
+		logger.Error("failed to submit job", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to submit job",
+			"details": err.Error(),
+		})
+	}
+}
+
+// ListJobs handles GET /api/v1/jobs
+func (h *JobHandler) ListJobs(c *gin.Context) {
+	limit, ok := queryInt(c, "limit", defaultJobsLimit, 1)
+	if !ok {
+		return
+	}
+
+	list, err := h.queue.List(limit)
+	if err != nil {
+		h.logger.Error("failed to list jobs", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list jobs",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobs": list,
+	})
+}
+
+// GetJob handles GET /api/v1/jobs/:id
+func (h *JobHandler) GetJob(c *gin.Context) {
+	job, err := h.queue.Get(c.Param("id"))
+	if err != nil {
+		h.jobError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// GetResults handles GET /api/v1/jobs/:id/results
+func (h *JobHandler) GetResults(c *gin.Context) {
+	offset, ok := queryInt(c, "offset", 0, 0)
+	if !ok {
+		return
+	}
+	limit, ok := queryInt(c, "limit", defaultRowsLimit, 1)
+	if !ok {
+		return
+	}
+	if limit > maxRowsLimit {
+		limit = maxRowsLimit
+	}
+
+	job, err := h.queue.Get(c.Param("id"))
+	if err != nil {
+		h.jobError(c, err)
+		return
+	}
+	rows, err := h.queue.Rows(job.ID, offset, limit)
+	if err != nil {
+		h.jobError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, jobs.Results{
+		Job:    job,
+		Offset: offset,
+		Rows:   rows,
+	})
+}
+
+func (h *JobHandler) jobError(c *gin.Context, err error) {
+	if errors.Is(err, jobs.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Job not found",
+		})
+		return
+	}
+	h.logger.Error("failed to load job", zap.Error(err))
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "Failed to load job",
+	})
+}
+
+// queryInt reads an integer query parameter of at least min, answering 400 when
+// it is invalid
+func queryInt(c *gin.Context, name string, def, min int) (int, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return def, true
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": name + " must be an integer of at least " + strconv.Itoa(min),
+		})
+		return 0, false
+	}
+	return n, true
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimitIdleTTL is how long the bucket of a client that stopped sending is kept
+const rateLimitIdleTTL = 10 * time.Minute
+
+// RateLimiter limits the requests per minute of each client IP with a token
+// bucket that allows bursts of up to a minute's worth of requests
+type RateLimiter struct {
+	mu        sync.Mutex
+	perMinute int // changeable at runtime
+	clients   map[string]*rateBucket
+	lastPrune time.Time
+}
+
+// rateBucket is the token bucket of one client IP
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a rate limiter allowing perMinute requests per minute
+// per client IP; 0 disables it
+func NewRateLimiter(perMinute int) *RateLimiter {
+	return &RateLimiter{
+		perMinute: perMinute,
+		clients:   make(map[string]*rateBucket),
+		lastPrune: time.Now(),
+	}
+}
+
+// SetLimit changes the requests allowed per minute per client IP; 0 disables
+// the limit. Clients keep the tokens they have, up to the new limit.
+func (l *RateLimiter) SetLimit(perMinute int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.perMinute = perMinute
+}
+
+// Middleware rejects requests over the limit with 429 Too Many Requests and a
+// Retry-After header
+func (l *RateLimiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if wait := l.take(c.ClientIP(), time.Now()); wait > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error": "Rate limit exceeded. Please retry later.",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// take spends a token of the client's bucket, or returns how long until one is available
+func (l *RateLimiter) take(client string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.perMinute <= 0 {
+		return 0
+	}
+	perSecond := float64(l.perMinute) / 60
+
+	if now.Sub(l.lastPrune) > rateLimitIdleTTL {
+		for ip, bucket := range l.clients {
+			if now.Sub(bucket.last) > rateLimitIdleTTL {
+				delete(l.clients, ip)
+			}
+		}
+		l.lastPrune = now
+	}
+
+	bucket, ok := l.clients[client]
+	if !ok {
+		bucket = &rateBucket{tokens: float64(l.perMinute), last: now}
+		l.clients[client] = bucket
+	}
+	bucket.tokens = math.Min(float64(l.perMinute), bucket.tokens+now.Sub(bucket.last).Seconds()*perSecond)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		return time.Duration((1 - bucket.tokens) / perSecond * float64(time.Second))
+	}
+	bucket.tokens--
+	return 0
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_AllowsABurstThenRefills(t *testing.T) {
+	l := NewRateLimiter(60)
+	now := time.Now()
+
+	for i := 0; i < 60; i++ {
+		if wait := l.take("198.51.100.1", now); wait != 0 {
+			t.Fatalf("request %d waited %s, want a burst of 60 allowed", i+1, wait)
+		}
+	}
+	if wait := l.take("198.51.100.1", now); wait != time.Second {
+		t.Errorf("request 61 waited %s, want 1s until the next token", wait)
+	}
+
+	// One token comes back every second
+	if wait := l.take("198.51.100.1", now.Add(time.Second)); wait != 0 {
+		t.Errorf("request after 1s waited %s, want it allowed", wait)
+	}
+	if wait := l.take("198.51.100.1", now.Add(time.Second)); wait == 0 {
+		t.Error("second request after 1s was allowed, want only one token refilled")
+	}
+}
+
+func TestRateLimiter_IsolatesClients(t *testing.T) {
+	l := NewRateLimiter(1)
+	now := time.Now()
+
+	if wait := l.take("198.51.100.1", now); wait != 0 {
+		t.Fatalf("first request waited %s, want it allowed", wait)
+	}
+	if wait := l.take("198.51.100.1", now); wait == 0 {
+		t.Error("second request of the same client was allowed, want it limited")
+	}
+	if wait := l.take("198.51.100.2", now); wait != 0 {
+		t.Errorf("request of another client waited %s, want it allowed", wait)
+	}
+}
+
+func TestRateLimiter_SetLimit(t *testing.T) {
+	l := NewRateLimiter(2)
+	now := time.Now()
+
+	l.take("198.51.100.1", now)
+	l.take("198.51.100.1", now)
+	if wait := l.take("198.51.100.1", now); wait == 0 {
+		t.Fatal("third request was allowed, want the limit of 2 enforced")
+	}
+
+	// A higher limit refills faster; tokens already spent stay spent
+	l.SetLimit(120)
+	if wait := l.take("198.51.100.1", now.Add(500*time.Millisecond)); wait != 0 {
+		t.Errorf("request 500ms after raising the limit to 120 waited %s, want it allowed", wait)
+	}
+
+	l.SetLimit(0)
+	for i := 0; i < 10; i++ {
+		if wait := l.take("198.51.100.1", now); wait != 0 {
+			t.Fatalf("request %d waited %s with the limit disabled, want it allowed", i+1, wait)
+		}
+	}
+
+	// A lower limit caps the tokens a client has saved
+	l.SetLimit(1)
+	later := now.Add(time.Hour)
+	l.take("198.51.100.1", later)
+	if wait := l.take("198.51.100.1", later); wait == 0 {
+		t.Error("second request with a limit of 1 was allowed, want it limited")
+	}
+}
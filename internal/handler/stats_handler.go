@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"email-finder/internal/verifier"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StatsHandler reports the load on the verification backends
+type StatsHandler struct {
+	pool *verifier.WorkerPool
+}
+
+// NewStatsHandler creates a new stats handler
+func NewStatsHandler(pool *verifier.WorkerPool) *StatsHandler {
+	return &StatsHandler{
+		pool: pool,
+	}
+}
+
+// VerificationQueue handles GET /api/v1/stats/verification-queue
+func (h *StatsHandler) VerificationQueue(c *gin.Context) {
+	c.JSON(http.StatusOK, h.pool.Stats())
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"email-finder/internal/webhook"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// defaultDeliveriesLimit is how many deliveries are listed when no limit is given
+const defaultDeliveriesLimit = 50
+
+// WebhookHandler reports the callbacks sent for asynchronous lookups
+type WebhookHandler struct {
+	webhooks *webhook.Dispatcher
+	logger   *zap.Logger
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhooks *webhook.Dispatcher, logger *zap.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhooks: webhooks,
+		logger:   logger,
+	}
+}
+
+// ListDeliveries handles GET /api/v1/webhooks/deliveries
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	limit := defaultDeliveriesLimit
+	if value := c.Query("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "limit must be a positive integer",
+			})
+			return
+		}
+		limit = n
+	}
+
+	list, err := h.webhooks.List(limit)
+	if err != nil {
+		h.logger.Error("failed to list webhook deliveries", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list deliveries",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": list,
+	})
+}
+
+// GetDelivery handles GET /api/v1/webhooks/deliveries/:id
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	delivery, err := h.webhooks.Get(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Delivery not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
@@ -0,0 +1,154 @@
+// Package health runs readiness probes against the service's dependencies
+package health
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Component and overall statuses
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusReady    = "ready"
+	StatusNotReady = "not_ready"
+	StatusDegraded = "degraded"
+)
+
+// CheckFunc probes one dependency and returns an error if it is unusable
+type CheckFunc func(ctx context.Context) error
+
+// ComponentStatus is the outcome of probing one dependency
+type ComponentStatus struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the outcome of a readiness check
+type Report struct {
+	Status     string            `json:"status"`
+	Ready      bool              `json:"ready"`
+	Components []ComponentStatus `json:"components"`
+}
+
+// component is a registered dependency probe
+type component struct {
+	name     string
+	critical bool
+	check    CheckFunc
+}
+
+// Checker probes the registered dependencies. The service is ready when every
+// critical dependency is up; a failing non-critical dependency only degrades it.
+type Checker struct {
+	timeout time.Duration
+
+	mu         sync.RWMutex
+	components []component
+	notReady   atomic.Bool
+}
+
+// NewChecker creates a new checker that gives each probe at most timeout
+func NewChecker(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = 5 * time.Second // Default timeout
+	}
+	return &Checker{timeout: timeout}
+}
+
+// Add registers a dependency probe
+func (c *Checker) Add(name string, critical bool, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.components = append(c.components, component{name: name, critical: critical, check: check})
+}
+
+// SetNotReady makes every later check report not ready, e.g. while shutting down
+func (c *Checker) SetNotReady() {
+	c.notReady.Store(true)
+}
+
+// Check probes all dependencies in parallel
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.RLock()
+	components := append([]component(nil), c.components...)
+	c.mu.RUnlock()
+
+	statuses := make([]ComponentStatus, len(components))
+	var wg sync.WaitGroup
+	for i, comp := range components {
+		wg.Add(1)
+		go func(idx int, comp component) {
+			defer wg.Done()
+			statuses[idx] = c.probe(ctx, comp)
+		}(i, comp)
+	}
+	wg.Wait()
+
+	sort.SliceStable(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+
+	report := Report{Status: StatusReady, Ready: true, Components: statuses}
+	for _, status := range statuses {
+		if status.Status == StatusUp {
+			continue
+		}
+		if status.Critical {
+			report.Status = StatusNotReady
+			report.Ready = false
+		} else if report.Ready {
+			report.Status = StatusDegraded
+		}
+	}
+	if c.notReady.Load() {
+		report.Status = StatusNotReady
+		report.Ready = false
+	}
+	return report
+}
+
+// probe runs one dependency check with the checker's timeout
+func (c *Checker) probe(ctx context.Context, comp component) ComponentStatus {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// A probe that doesn't watch ctx, like a database read, still can't hold
+	// the readiness check past the timeout
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- comp.check(ctx)
+	}()
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	status := ComponentStatus{
+		Name:      comp.name,
+		Status:    StatusUp,
+		Critical:  comp.critical,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		status.Status = StatusDown
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// DNSCheck returns a probe that looks up the MX records of domain
+func DNSCheck(domain string) CheckFunc {
+	return func(ctx context.Context) error {
+		_, err := net.DefaultResolver.LookupMX(ctx, domain)
+		return err
+	}
+}
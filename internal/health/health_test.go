@@ -0,0 +1,100 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChecker_Check(t *testing.T) {
+	up := func(ctx context.Context) error { return nil }
+	down := func(ctx context.Context) error { return errors.New("connection refused") }
+	hang := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	blocked := make(chan struct{})
+	defer close(blocked)
+	stuck := func(ctx context.Context) error {
+		<-blocked
+		return nil
+	}
+
+	tests := []struct {
+		name       string
+		setup      func(c *Checker)
+		wantStatus string
+		wantReady  bool
+	}{
+		{
+			name: "all up",
+			setup: func(c *Checker) {
+				c.Add("verifier", true, up)
+				c.Add("dns", true, up)
+			},
+			wantStatus: StatusReady,
+			wantReady:  true,
+		},
+		{
+			name: "critical down",
+			setup: func(c *Checker) {
+				c.Add("verifier", true, down)
+				c.Add("dns", true, up)
+			},
+			wantStatus: StatusNotReady,
+			wantReady:  false,
+		},
+		{
+			name: "non-critical down",
+			setup: func(c *Checker) {
+				c.Add("verifier", true, up)
+				c.Add("backend-2", false, down)
+			},
+			wantStatus: StatusDegraded,
+			wantReady:  true,
+		},
+		{
+			name: "critical times out",
+			setup: func(c *Checker) {
+				c.Add("verifier", true, hang)
+			},
+			wantStatus: StatusNotReady,
+			wantReady:  false,
+		},
+		{
+			name: "critical ignores the timeout",
+			setup: func(c *Checker) {
+				c.Add("store:jobs", true, stuck)
+			},
+			wantStatus: StatusNotReady,
+			wantReady:  false,
+		},
+		{
+			name: "shutting down",
+			setup: func(c *Checker) {
+				c.Add("verifier", true, up)
+				c.SetNotReady()
+			},
+			wantStatus: StatusNotReady,
+			wantReady:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewChecker(20 * time.Millisecond)
+			tt.setup(c)
+
+			report := c.Check(context.Background())
+			if report.Status != tt.wantStatus || report.Ready != tt.wantReady {
+				t.Errorf("Check() = %s (ready %v), want %s (ready %v)", report.Status, report.Ready, tt.wantStatus, tt.wantReady)
+			}
+			for _, comp := range report.Components {
+				if comp.Status == StatusDown && comp.Error == "" {
+					t.Errorf("component %s is down without an error", comp.Name)
+				}
+			}
+		})
+	}
+}
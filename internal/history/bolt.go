@@ -0,0 +1,240 @@
+package history
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	// recordsBucket holds the records keyed by their decoded ID, so in start time order
+	recordsBucket = []byte("records")
+
+	// The index buckets map "<value>\x00<record key>" to nothing
+	emailIndex   = []byte("by_email")
+	domainIndex  = []byte("by_domain")
+	companyIndex = []byte("by_company")
+)
+
+// BoltStore keeps records in an embedded BoltDB file, indexed by email, domain and company
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltStore opens or creates the history database at path
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{recordsBucket, emailIndex, domainIndex, companyIndex} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize history database %s: %w", path, err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Save stores a record and its index entries
+func (s *BoltStore) Save(rec *Record) error {
+	key, err := hex.DecodeString(rec.ID)
+	if err != nil || len(key) != 16 {
+		return fmt.Errorf("invalid record ID %q", rec.ID)
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(recordsBucket).Put(key, data); err != nil {
+			return err
+		}
+		return forEachIndex(tx, rec, func(index *bolt.Bucket, entry []byte) error {
+			return index.Put(entry, nil)
+		})
+	})
+}
+
+// Get returns a record by ID
+func (s *BoltStore) Get(id string) (*Record, error) {
+	key, err := hex.DecodeString(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+
+	var rec Record
+	err = s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(recordsBucket).Get(key)
+		if data == nil {
+			return ErrNotFound
+		}
+		return json.Unmarshal(data, &rec)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+// Find returns the records selected by q, newest first. The most selective
+// filter given picks the index that is scanned.
+func (s *BoltStore) Find(q Query) ([]*Record, error) {
+	found := make([]*Record, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		records := tx.Bucket(recordsBucket)
+
+		bucket, prefix := records, []byte(nil)
+		switch {
+		case q.Email != "":
+			bucket, prefix = tx.Bucket(emailIndex), indexPrefix(q.Email)
+		case q.Domain != "":
+			bucket, prefix = tx.Bucket(domainIndex), indexPrefix(q.Domain)
+		case q.Company != "":
+			bucket, prefix = tx.Bucket(companyIndex), indexPrefix(q.Company)
+		}
+
+		return scanBackward(bucket, prefix, q.From, q.To, func(key []byte) (bool, error) {
+			var rec Record
+			if err := json.Unmarshal(records.Get(key), &rec); err != nil {
+				return false, err
+			}
+			if q.matches(&rec) {
+				found = append(found, &rec)
+			}
+			return len(found) < q.Limit, nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// DeleteBefore removes the records of lookups started before t, with their index entries
+func (s *BoltStore) DeleteBefore(t time.Time) (int, error) {
+	deleted := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		records := tx.Bucket(recordsBucket)
+
+		// Deleting while iterating skips keys, so collect them first
+		var expired []*Record
+		c := records.Cursor()
+		for k, v := c.First(); k != nil && bytes.Compare(k, timeKey(t)) < 0; k, v = c.Next() {
+			var rec Record
+			if err := json.Unmarshal(v, &rec); err != nil {
+				return err
+			}
+			expired = append(expired, &rec)
+		}
+
+		for _, rec := range expired {
+			key, _ := hex.DecodeString(rec.ID)
+			if err := records.Delete(key); err != nil {
+				return err
+			}
+			err := forEachIndex(tx, rec, func(index *bolt.Bucket, entry []byte) error {
+				return index.Delete(entry)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		deleted = len(expired)
+		return nil
+	})
+	return deleted, err
+}
+
+// HealthCheck reads the database in a transaction, for the readiness probe
+func (s *BoltStore) HealthCheck(ctx context.Context) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(recordsBucket) == nil {
+			return fmt.Errorf("bucket %s is missing", recordsBucket)
+		}
+		return nil
+	})
+}
+
+// Close closes the database file
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// forEachIndex calls fn with every index entry of a record
+func forEachIndex(tx *bolt.Tx, rec *Record, fn func(index *bolt.Bucket, entry []byte) error) error {
+	key, _ := hex.DecodeString(rec.ID)
+	entry := func(value string) []byte {
+		return append(indexPrefix(value), key...)
+	}
+
+	for _, email := range emails(rec) {
+		if err := fn(tx.Bucket(emailIndex), entry(email)); err != nil {
+			return err
+		}
+	}
+	if rec.Domain != "" {
+		if err := fn(tx.Bucket(domainIndex), entry(rec.Domain)); err != nil {
+			return err
+		}
+	}
+	return fn(tx.Bucket(companyIndex), entry(rec.Request.Company))
+}
+
+// scanBackward calls fn with the record key of every entry of bucket with the
+// prefix, from the newest lookup started before to down to from, until fn returns false
+func scanBackward(bucket *bolt.Bucket, prefix []byte, from, to time.Time, fn func(key []byte) (bool, error)) error {
+	upper := append(append([]byte{}, prefix...), 0xff)
+	if !to.IsZero() {
+		upper = append(append([]byte{}, prefix...), timeKey(to)...)
+	}
+	var lower []byte
+	if !from.IsZero() {
+		lower = append(append([]byte{}, prefix...), timeKey(from)...)
+	}
+
+	c := bucket.Cursor()
+	k, _ := c.Seek(upper)
+	if k == nil {
+		k, _ = c.Last()
+	} else {
+		k, _ = c.Prev()
+	}
+	for ; k != nil && bytes.HasPrefix(k, prefix); k, _ = c.Prev() {
+		if lower != nil && bytes.Compare(k, lower) < 0 {
+			return nil
+		}
+		more, err := fn(k[len(prefix):])
+		if err != nil || !more {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexPrefix is the start of the index entries of a value
+func indexPrefix(value string) []byte {
+	return append([]byte(normalize(value)), 0)
+}
+
+// timeKey is the start of the record keys of lookups started at t
+func timeKey(t time.Time) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	return key
+}
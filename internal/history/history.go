@@ -0,0 +1,106 @@
+// Package history keeps a record of every lookup, so past results can be
+// looked up and explained
+package history
+
+import (
+	"crypto/rand"
+	"email-finder/internal/service"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned for unknown records
+var ErrNotFound = errors.New("history record not found")
+
+// Record is a stored lookup
+type Record struct {
+	ID string `json:"id"`
+	service.LookupRecord
+}
+
+// Query selects records. Empty fields match every record.
+type Query struct {
+	// Email matches lookups that found the address, including as their best guess
+	Email string
+	// Domain matches lookups that resolved to the domain
+	Domain string
+	// Company matches lookups for the company, ignoring case and surrounding spaces
+	Company string
+	// From and To bound the start time of the lookup; To is exclusive
+	From time.Time
+	To   time.Time
+	// Limit is the most records returned, newest first
+	Limit int
+}
+
+// Store persists lookup records. Implementations must be safe for concurrent use.
+type Store interface {
+	Save(rec *Record) error
+	Get(id string) (*Record, error)
+	Find(q Query) ([]*Record, error)
+	// DeleteBefore removes the records of lookups started before t and returns
+	// how many were removed
+	DeleteBefore(t time.Time) (int, error)
+	Close() error
+}
+
+// newID returns a random record ID that sorts by the start time of the lookup
+func newID(startedAt time.Time) (string, error) {
+	id := make([]byte, 16)
+	binary.BigEndian.PutUint64(id, uint64(startedAt.UnixNano()))
+	if _, err := rand.Read(id[8:]); err != nil {
+		return "", fmt.Errorf("failed to generate record ID: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// emails returns the addresses a record is found by
+func emails(rec *Record) []string {
+	resp := rec.Response
+	if resp == nil {
+		return nil
+	}
+	found := make([]string, 0, len(resp.FoundEmails)+1)
+	for _, result := range resp.FoundEmails {
+		found = append(found, normalize(result.Email))
+	}
+	if resp.BestGuess != nil {
+		found = append(found, normalize(resp.BestGuess.Email))
+	}
+	return found
+}
+
+// normalize makes emails, domains and companies compare case-insensitively
+func normalize(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// matches reports whether a record is selected by q
+func (q Query) matches(rec *Record) bool {
+	if !q.From.IsZero() && rec.StartedAt.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !rec.StartedAt.Before(q.To) {
+		return false
+	}
+	if q.Domain != "" && normalize(rec.Domain) != normalize(q.Domain) {
+		return false
+	}
+	if q.Company != "" && normalize(rec.Request.Company) != normalize(q.Company) {
+		return false
+	}
+	if q.Email != "" {
+		email := normalize(q.Email)
+		for _, found := range emails(rec) {
+			if found == email {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
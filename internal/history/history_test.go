@@ -0,0 +1,218 @@
+package history
+
+import (
+	"context"
+	"email-finder/internal/resolver"
+	"email-finder/internal/service"
+	"email-finder/internal/verifier"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var epoch = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// testRecord is a lookup for company at domain, started days after epoch, that found email
+func testRecord(t *testing.T, company, domain, email string, days int) *Record {
+	t.Helper()
+	startedAt := epoch.AddDate(0, 0, days)
+	id, err := newID(startedAt)
+	if err != nil {
+		t.Fatalf("newID() error = %v", err)
+	}
+	rec := &Record{ID: id, LookupRecord: service.LookupRecord{
+		Request:   service.FindEmailRequest{FirstName: "John", LastName: "Doe", Company: company},
+		Domain:    domain,
+		StartedAt: startedAt,
+		Response:  &service.FindEmailResponse{Domain: domain},
+	}}
+	if email != "" {
+		rec.Response.FoundEmails = []service.EmailResult{{Email: email}}
+	}
+	return rec
+}
+
+// storeTests runs the same checks against every Store implementation
+func storeTests(t *testing.T, newStore func(t *testing.T) Store) {
+	fill := func(t *testing.T, store Store) []*Record {
+		records := []*Record{
+			testRecord(t, "Acme", "acme.com", "john.doe@acme.com", 0),
+			testRecord(t, "Globex", "globex.com", "", 10),
+			testRecord(t, "ACME ", "acme.com", "jdoe@acme.com", 20),
+			testRecord(t, "Acme Europe", "acme.com", "John.Doe@acme.com", 30),
+		}
+		// Saved out of order, as lookups finish
+		for _, i := range []int{1, 3, 0, 2} {
+			if err := store.Save(records[i]); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+		}
+		return records
+	}
+	ids := func(records []*Record) []string {
+		found := make([]string, len(records))
+		for i, rec := range records {
+			found[i] = rec.ID
+		}
+		return found
+	}
+
+	t.Run("find", func(t *testing.T) {
+		store := newStore(t)
+		records := fill(t, store)
+
+		tests := []struct {
+			name  string
+			query Query
+			want  []*Record
+		}{
+			{"everything newest first", Query{Limit: 10}, []*Record{records[3], records[2], records[1], records[0]}},
+			{"limit", Query{Limit: 2}, []*Record{records[3], records[2]}},
+			{"email ignores case", Query{Email: "JOHN.DOE@acme.com", Limit: 10}, []*Record{records[3], records[0]}},
+			{"domain", Query{Domain: "acme.com", Limit: 10}, []*Record{records[3], records[2], records[0]}},
+			{"company is normalized", Query{Company: "acme", Limit: 10}, []*Record{records[2], records[0]}},
+			{"date range", Query{From: epoch.AddDate(0, 0, 10), To: epoch.AddDate(0, 0, 30), Limit: 10}, []*Record{records[2], records[1]}},
+			{"domain and date range", Query{Domain: "acme.com", To: epoch.AddDate(0, 0, 25), Limit: 10}, []*Record{records[2], records[0]}},
+			{"email and company", Query{Email: "john.doe@acme.com", Company: "acme europe", Limit: 10}, []*Record{records[3]}},
+			{"no match", Query{Email: "nobody@acme.com", Limit: 10}, []*Record{}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				found, err := store.Find(tt.query)
+				if err != nil {
+					t.Fatalf("Find() error = %v", err)
+				}
+				got, want := ids(found), ids(tt.want)
+				if len(got) != len(want) {
+					t.Fatalf("Find() returned %d records, want %d", len(got), len(want))
+				}
+				for i := range got {
+					if got[i] != want[i] {
+						t.Errorf("Find()[%d] = %s, want %s", i, got[i], want[i])
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		store := newStore(t)
+		records := fill(t, store)
+
+		rec, err := store.Get(records[1].ID)
+		if err != nil || rec.Request.Company != "Globex" {
+			t.Errorf("Get() = %+v, %v, want the Globex lookup", rec, err)
+		}
+		if _, err := store.Get("missing"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("delete before", func(t *testing.T) {
+		store := newStore(t)
+		records := fill(t, store)
+
+		deleted, err := store.DeleteBefore(epoch.AddDate(0, 0, 15))
+		if err != nil || deleted != 2 {
+			t.Fatalf("DeleteBefore() = %d, %v, want 2 records deleted", deleted, err)
+		}
+		if _, err := store.Get(records[0].ID); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() of a deleted record error = %v, want ErrNotFound", err)
+		}
+		found, _ := store.Find(Query{Domain: "acme.com", Limit: 10})
+		if len(found) != 2 {
+			t.Errorf("Find() after DeleteBefore returned %d records, want 2", len(found))
+		}
+	})
+}
+
+func TestMemoryStore(t *testing.T) {
+	storeTests(t, func(t *testing.T) Store {
+		return NewMemoryStore()
+	})
+}
+
+func TestBoltStore(t *testing.T) {
+	storeTests(t, func(t *testing.T) Store {
+		store, err := OpenBoltStore(filepath.Join(t.TempDir(), "history.db"))
+		if err != nil {
+			t.Fatalf("OpenBoltStore() error = %v", err)
+		}
+		t.Cleanup(func() { store.Close() })
+		return store
+	})
+}
+
+// fakeVerifier reports every address as safe
+type fakeVerifier struct{}
+
+func (fakeVerifier) VerifyEmail(ctx context.Context, email string) (*verifier.VerificationResult, error) {
+	return &verifier.VerificationResult{Email: email, IsReachable: "safe", IsValid: true, IsDeliverable: true}, nil
+}
+
+func (v fakeVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*verifier.VerificationResult, error) {
+	results := make([]*verifier.VerificationResult, 0, len(emails))
+	for _, email := range emails {
+		result, _ := v.VerifyEmail(ctx, email)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// fakeResolver maps every company to one domain
+type fakeResolver struct{}
+
+func (fakeResolver) ResolveDomain(ctx context.Context, companyName string) *resolver.DomainResult {
+	return &resolver.DomainResult{Domain: "example.org", Resolved: true, Method: "company_map"}
+}
+
+func TestRecorder_RecordsLookups(t *testing.T) {
+	store := NewMemoryStore()
+	recorder := NewRecorder(store, Config{Buffer: 10}, zap.NewNop())
+
+	finder := service.NewEmailFinderService(fakeVerifier{}, fakeResolver{}, zap.NewNop(), 3)
+	finder.SetRecorder(recorder)
+	if _, err := finder.FindEmails(context.Background(), service.FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Acme"}); err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if err := recorder.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	found, err := recorder.Find(Query{Company: "acme", Limit: 10})
+	if err != nil || len(found) != 1 {
+		t.Fatalf("Find() = %d records, %v, want 1", len(found), err)
+	}
+	rec := found[0]
+	if rec.Domain != "example.org" || rec.DomainMethod != "company_map" || !rec.DomainResolved {
+		t.Errorf("domain = %s via %s, want example.org via company_map", rec.Domain, rec.DomainMethod)
+	}
+	if len(rec.Patterns) != 3 || len(rec.Verifications) != 3 {
+		t.Errorf("recorded %d patterns and %d verifications, want 3 of each", len(rec.Patterns), len(rec.Verifications))
+	}
+	if rec.Response == nil || rec.Response.TotalFound != 3 || rec.Error != "" {
+		t.Errorf("Response = %+v, Error = %q, want 3 found emails", rec.Response, rec.Error)
+	}
+
+	// Lookups after Close are dropped
+	recorder.Record(&service.LookupRecord{StartedAt: time.Now()})
+}
+
+func TestRecorder_Purge(t *testing.T) {
+	store := NewMemoryStore()
+	store.Save(testRecord(t, "Acme", "acme.com", "", 0))
+	recent := testRecord(t, "Acme", "acme.com", "", 0)
+	recent.StartedAt = time.Now()
+	store.Save(recent)
+
+	recorder := NewRecorder(store, Config{Retention: 24 * time.Hour, Buffer: 1}, zap.NewNop())
+	defer recorder.Close(context.Background())
+
+	deleted, err := recorder.Purge()
+	if err != nil || deleted != 1 {
+		t.Errorf("Purge() = %d, %v, want the old record deleted", deleted, err)
+	}
+}
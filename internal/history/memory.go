@@ -0,0 +1,79 @@
+package history
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps records in memory; they are lost on restart
+type MemoryStore struct {
+	mu sync.RWMutex
+	// records are sorted by ID, so by start time
+	records []*Record
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Save stores a record
+func (s *MemoryStore) Save(rec *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Lookups finish out of order, so insert by ID
+	i := sort.Search(len(s.records), func(i int) bool { return s.records[i].ID >= rec.ID })
+	s.records = append(s.records, nil)
+	copy(s.records[i+1:], s.records[i:])
+	s.records[i] = rec
+	return nil
+}
+
+// Get returns a record by ID
+func (s *MemoryStore) Get(id string) (*Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	i := sort.Search(len(s.records), func(i int) bool { return s.records[i].ID >= id })
+	if i == len(s.records) || s.records[i].ID != id {
+		return nil, ErrNotFound
+	}
+	return s.records[i], nil
+}
+
+// Find returns the records selected by q, newest first
+func (s *MemoryStore) Find(q Query) ([]*Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	found := make([]*Record, 0)
+	for i := len(s.records) - 1; i >= 0 && len(found) < q.Limit; i-- {
+		if q.matches(s.records[i]) {
+			found = append(found, s.records[i])
+		}
+	}
+	return found, nil
+}
+
+// DeleteBefore removes the records of lookups started before t
+func (s *MemoryStore) DeleteBefore(t time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := make([]*Record, 0, len(s.records))
+	for _, rec := range s.records {
+		if !rec.StartedAt.Before(t) {
+			kept = append(kept, rec)
+		}
+	}
+	deleted := len(s.records) - len(kept)
+	s.records = kept
+	return deleted, nil
+}
+
+// Close does nothing; the records are dropped with the store
+func (s *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,168 @@
+package history
+
+import (
+	"context"
+	"email-finder/internal/metrics"
+	"email-finder/internal/service"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Config controls how records are written and kept
+type Config struct {
+	// Retention is how long records are kept; 0 keeps them forever
+	Retention time.Duration
+	// PurgeInterval is how often records older than Retention are deleted
+	PurgeInterval time.Duration
+	// Buffer is how many records may wait to be written before new ones are dropped
+	Buffer int
+}
+
+// Recorder writes lookup records to a Store in the background, so lookups never
+// wait for the disk, and deletes them once they are older than the retention
+type Recorder struct {
+	store  Store
+	cfg    Config
+	logger *zap.Logger
+
+	records chan *Record
+	stop    chan struct{}
+	wg      sync.WaitGroup
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewRecorder creates a recorder writing to store and starts its background work
+func NewRecorder(store Store, cfg Config, logger *zap.Logger) *Recorder {
+	if cfg.Buffer <= 0 {
+		cfg.Buffer = 1
+	}
+	r := &Recorder{
+		store:   store,
+		cfg:     cfg,
+		logger:  logger,
+		records: make(chan *Record, cfg.Buffer),
+		stop:    make(chan struct{}),
+	}
+
+	r.wg.Add(1)
+	go r.write()
+	if cfg.Retention > 0 && cfg.PurgeInterval > 0 {
+		r.wg.Add(1)
+		go r.purgeEvery(cfg.PurgeInterval)
+	}
+	return r
+}
+
+// Record queues a lookup record to be written. It is dropped when the buffer is
+// full or the recorder is closed.
+func (r *Recorder) Record(lookup *service.LookupRecord) {
+	id, err := newID(lookup.StartedAt)
+	if err != nil {
+		r.logger.Warn("failed to record lookup", zap.Error(err))
+		metrics.ObserveHistoryRecord("failed")
+		return
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.closed {
+		metrics.ObserveHistoryRecord("dropped")
+		return
+	}
+	select {
+	case r.records <- &Record{ID: id, LookupRecord: *lookup}:
+	default:
+		r.logger.Warn("history buffer full, dropping lookup record",
+			zap.String("company", lookup.Request.Company),
+		)
+		metrics.ObserveHistoryRecord("dropped")
+	}
+}
+
+// Get returns a record by ID
+func (r *Recorder) Get(id string) (*Record, error) {
+	return r.store.Get(id)
+}
+
+// Find returns the records selected by q, newest first
+func (r *Recorder) Find(q Query) ([]*Record, error) {
+	return r.store.Find(q)
+}
+
+// Purge deletes the records older than the retention
+func (r *Recorder) Purge() (int, error) {
+	if r.cfg.Retention <= 0 {
+		return 0, nil
+	}
+	return r.store.DeleteBefore(time.Now().Add(-r.cfg.Retention))
+}
+
+// Close stops taking records and waits until ctx is done for the queued ones
+// to be written
+func (r *Recorder) Close(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.records)
+		close(r.stop)
+	}
+	r.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *Recorder) write() {
+	defer r.wg.Done()
+	for rec := range r.records {
+		if err := r.store.Save(rec); err != nil {
+			r.logger.Error("failed to store lookup record", zap.String("id", rec.ID), zap.Error(err))
+			metrics.ObserveHistoryRecord("failed")
+			continue
+		}
+		metrics.ObserveHistoryRecord("saved")
+	}
+}
+
+func (r *Recorder) purgeEvery(interval time.Duration) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		r.purge()
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Recorder) purge() {
+	deleted, err := r.Purge()
+	if err != nil {
+		r.logger.Error("failed to delete expired lookup records", zap.Error(err))
+		return
+	}
+	if deleted > 0 {
+		r.logger.Info("deleted expired lookup records",
+			zap.Int("records", deleted),
+			zap.Duration("retention", r.cfg.Retention),
+		)
+	}
+}
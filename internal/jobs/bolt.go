@@ -0,0 +1,281 @@
+package jobs
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	jobsBucket = []byte("jobs")
+	// rowsBucket holds one nested bucket per job, keyed by row index
+	rowsBucket = []byte("rows")
+)
+
+// BoltStore keeps jobs in an embedded BoltDB file
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltStore opens or creates the job database at path
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open job database %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{jobsBucket, rowsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize job database %s: %w", path, err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// CreateJob stores a new job and its rows
+func (s *BoltStore) CreateJob(job *Job, rows []*Row) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := putJSON(tx.Bucket(jobsBucket), []byte(job.ID), job); err != nil {
+			return err
+		}
+		bucket, err := tx.Bucket(rowsBucket).CreateBucket([]byte(job.ID))
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := putJSON(bucket, rowKey(row.Index), row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetJob returns a job by ID
+func (s *BoltStore) GetJob(id string) (*Job, error) {
+	var job Job
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return getJSON(tx.Bucket(jobsBucket), []byte(id), &job)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListJobs returns up to limit jobs, newest first
+func (s *BoltStore) ListJobs(limit int) ([]*Job, error) {
+	jobs, err := s.allJobs()
+	if err != nil {
+		return nil, err
+	}
+	return newestFirst(jobs, limit), nil
+}
+
+// GetRow returns one row of a job
+func (s *BoltStore) GetRow(jobID string, index int) (*Row, error) {
+	var row Row
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(rowsBucket).Bucket([]byte(jobID))
+		if bucket == nil {
+			return ErrNotFound
+		}
+		return getJSON(bucket, rowKey(index), &row)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// GetRows returns up to limit rows of a job in index order, starting at offset
+func (s *BoltStore) GetRows(jobID string, offset, limit int) ([]*Row, error) {
+	rows := make([]*Row, 0, limit)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(rowsBucket).Bucket([]byte(jobID))
+		if bucket == nil {
+			return ErrNotFound
+		}
+		c := bucket.Cursor()
+		for k, v := c.Seek(rowKey(offset)); k != nil && len(rows) < limit; k, v = c.Next() {
+			var row Row
+			if err := json.Unmarshal(v, &row); err != nil {
+				return err
+			}
+			rows = append(rows, &row)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// UpdateRow stores a row and updates the counts and status of its job, in one
+// transaction
+func (s *BoltStore) UpdateRow(row *Row) (*Job, error) {
+	var job Job
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		jobs := tx.Bucket(jobsBucket)
+		if err := getJSON(jobs, []byte(row.JobID), &job); err != nil {
+			return err
+		}
+		bucket := tx.Bucket(rowsBucket).Bucket([]byte(row.JobID))
+		if bucket == nil {
+			return ErrNotFound
+		}
+		var old Row
+		if err := getJSON(bucket, rowKey(row.Index), &old); err != nil {
+			return err
+		}
+
+		job.moveRow(old.State, row.State, time.Now().UTC())
+		if err := putJSON(bucket, rowKey(row.Index), row); err != nil {
+			return err
+		}
+		return putJSON(jobs, []byte(row.JobID), &job)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Unfinished returns the rows that are not done or failed, oldest job first
+func (s *BoltStore) Unfinished() ([]*Row, error) {
+	jobs, err := s.allJobs()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.Before(jobs[j].CreatedAt) })
+
+	rows := make([]*Row, 0)
+	err = s.db.View(func(tx *bolt.Tx) error {
+		for _, job := range jobs {
+			if job.Status == JobCompleted {
+				continue
+			}
+			bucket := tx.Bucket(rowsBucket).Bucket([]byte(job.ID))
+			if bucket == nil {
+				continue
+			}
+			err := bucket.ForEach(func(k, v []byte) error {
+				var row Row
+				if err := json.Unmarshal(v, &row); err != nil {
+					return err
+				}
+				if !row.Finished() {
+					rows = append(rows, &row)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// PendingCallbacks returns the completed jobs whose callback has not been sent, oldest first
+func (s *BoltStore) PendingCallbacks() ([]*Job, error) {
+	jobs, err := s.allJobs()
+	if err != nil {
+		return nil, err
+	}
+	pending := make([]*Job, 0)
+	for _, job := range jobs {
+		if job.CallbackPending {
+			pending = append(pending, job)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].CreatedAt.Before(pending[j].CreatedAt) })
+	return pending, nil
+}
+
+// CallbackSent clears the callback pending flag of a job
+func (s *BoltStore) CallbackSent(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		jobs := tx.Bucket(jobsBucket)
+		var job Job
+		if err := getJSON(jobs, []byte(id), &job); err != nil {
+			return err
+		}
+		job.CallbackPending = false
+		return putJSON(jobs, []byte(id), &job)
+	})
+}
+
+// HealthCheck reads the database in a transaction, for the readiness probe
+func (s *BoltStore) HealthCheck(ctx context.Context) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(jobsBucket) == nil {
+			return fmt.Errorf("bucket %s is missing", jobsBucket)
+		}
+		return nil
+	})
+}
+
+// Close closes the database file
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *BoltStore) allJobs() ([]*Job, error) {
+	jobs := make([]*Job, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(jobsBucket).ForEach(func(k, v []byte) error {
+			var job Job
+			if err := json.Unmarshal(v, &job); err != nil {
+				return err
+			}
+			jobs = append(jobs, &job)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// rowKey encodes a row index so keys sort in index order
+func rowKey(index int) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(index))
+	return key
+}
+
+func putJSON(bucket *bolt.Bucket, key []byte, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return bucket.Put(key, data)
+}
+
+func getJSON(bucket *bolt.Bucket, key []byte, v interface{}) error {
+	data := bucket.Get(key)
+	if data == nil {
+		return ErrNotFound
+	}
+	return json.Unmarshal(data, v)
+}
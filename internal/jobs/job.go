@@ -0,0 +1,178 @@
+// Package jobs runs large batches of lookups from a persistent queue, so a
+// batch resumes where it left off after a crash or deploy
+package jobs
+
+import (
+	"crypto/rand"
+	"email-finder/internal/service"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Row states. A row moves from pending through resolving and verifying to done
+// or failed. Rows found resolving or verifying on startup were interrupted and
+// start over; done and failed rows are never run again.
+const (
+	RowPending   = "pending"
+	RowResolving = "resolving"
+	RowVerifying = "verifying"
+	RowDone      = "done"
+	RowFailed    = "failed"
+)
+
+// Job statuses, derived from the states of its rows
+const (
+	JobPending   = "pending"
+	JobRunning   = "running"
+	JobCompleted = "completed"
+)
+
+// ErrNotFound is returned for unknown jobs and rows
+var ErrNotFound = errors.New("job not found")
+
+// Job is a batch of lookups
+type Job struct {
+	ID          string `json:"id"`
+	Status      string `json:"status"`
+	CallbackURL string `json:"callback_url,omitempty"`
+	Total       int    `json:"total"`
+	// Counts is the number of rows in each state
+	Counts      map[string]int `json:"counts"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	CompletedAt *time.Time     `json:"completed_at,omitempty"`
+	// CallbackPending is set when the job completes and cleared once its
+	// callback is handed to the webhook dispatcher, so a callback interrupted
+	// by a restart is sent on the next start
+	CallbackPending bool `json:"callback_pending,omitempty"`
+}
+
+// Finished reports whether every row of the job is done or failed
+func (j *Job) Finished() bool {
+	return j.Counts[RowDone]+j.Counts[RowFailed] == j.Total
+}
+
+// moveRow updates the counts and status of the job for a row going from one
+// state to another
+func (j *Job) moveRow(from, to string, now time.Time) {
+	if from == to {
+		return
+	}
+	j.Counts[from]--
+	if j.Counts[from] <= 0 {
+		delete(j.Counts, from)
+	}
+	j.Counts[to]++
+	j.UpdatedAt = now
+
+	switch {
+	case j.Finished():
+		j.Status = JobCompleted
+		j.CompletedAt = &now
+		j.CallbackPending = j.CallbackURL != ""
+	case j.Counts[RowPending] == j.Total:
+		j.Status = JobPending
+	default:
+		j.Status = JobRunning
+	}
+}
+
+// Row is one lookup of a job
+type Row struct {
+	JobID   string                   `json:"job_id"`
+	Index   int                      `json:"index"`
+	Request service.FindEmailRequest `json:"request"`
+	State   string                   `json:"state"`
+	// Attempts counts the runs of the row, including interrupted ones
+	Attempts  int                        `json:"attempts"`
+	Result    *service.FindEmailResponse `json:"result,omitempty"`
+	Error     string                     `json:"error,omitempty"`
+	UpdatedAt time.Time                  `json:"updated_at"`
+}
+
+// Finished reports whether the row will not run again
+func (r *Row) Finished() bool {
+	return r.State == RowDone || r.State == RowFailed
+}
+
+// SubmitRequest is the input of the submit endpoint
+type SubmitRequest struct {
+	Requests []service.FindEmailRequest `json:"requests" binding:"required"`
+
+	// CallbackURL is POSTed the job once every row is done or failed
+	CallbackURL string `json:"callback_url,omitempty"`
+}
+
+// Results is a page of the rows of a job
+type Results struct {
+	Job    *Job   `json:"job"`
+	Offset int    `json:"offset"`
+	Rows   []*Row `json:"rows"`
+}
+
+// Store persists jobs and the state of their rows. Implementations must be safe
+// for concurrent use.
+type Store interface {
+	// CreateJob stores a new job and its rows
+	CreateJob(job *Job, rows []*Row) error
+	// GetJob returns a job by ID
+	GetJob(id string) (*Job, error)
+	// ListJobs returns up to limit jobs, newest first
+	ListJobs(limit int) ([]*Job, error)
+	// GetRow returns one row of a job
+	GetRow(jobID string, index int) (*Row, error)
+	// GetRows returns up to limit rows of a job in index order, starting at offset
+	GetRows(jobID string, offset, limit int) ([]*Row, error)
+	// UpdateRow stores a row and updates the counts and status of its job,
+	// which it returns
+	UpdateRow(row *Row) (*Job, error)
+	// Unfinished returns the rows that are not done or failed, oldest job first
+	Unfinished() ([]*Row, error)
+	// PendingCallbacks returns the completed jobs whose callback has not been
+	// sent, oldest first
+	PendingCallbacks() ([]*Job, error)
+	// CallbackSent clears the callback pending flag of a job
+	CallbackSent(id string) error
+	Close() error
+}
+
+// newJob creates a job with every row pending
+func newJob(requests []service.FindEmailRequest, callbackURL string) (*Job, []*Row, error) {
+	id, err := newID()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now().UTC()
+	job := &Job{
+		ID:          id,
+		Status:      JobPending,
+		CallbackURL: callbackURL,
+		Total:       len(requests),
+		Counts:      map[string]int{RowPending: len(requests)},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	rows := make([]*Row, len(requests))
+	for i, req := range requests {
+		rows[i] = &Row{
+			JobID:     id,
+			Index:     i,
+			Request:   req,
+			State:     RowPending,
+			UpdatedAt: now,
+		}
+	}
+	return job, rows, nil
+}
+
+// newID returns a random job ID
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate job ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
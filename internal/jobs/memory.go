@@ -0,0 +1,188 @@
+package jobs
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps jobs in memory. Jobs don't survive a restart; it is meant
+// for tests and deployments without a writable disk.
+type MemoryStore struct {
+	mu   sync.Mutex
+	jobs map[string]*Job
+	rows map[string][]*Row
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		jobs: make(map[string]*Job),
+		rows: make(map[string][]*Row),
+	}
+}
+
+// CreateJob stores a new job and its rows
+func (s *MemoryStore) CreateJob(job *Job, rows []*Row) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := make([]*Row, len(rows))
+	for i, row := range rows {
+		stored[i] = copyRow(row)
+	}
+	s.jobs[job.ID] = copyJob(job)
+	s.rows[job.ID] = stored
+	return nil
+}
+
+// GetJob returns a job by ID
+func (s *MemoryStore) GetJob(id string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return copyJob(job), nil
+}
+
+// ListJobs returns up to limit jobs, newest first
+func (s *MemoryStore) ListJobs(limit int) ([]*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]*Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, copyJob(job))
+	}
+	return newestFirst(jobs, limit), nil
+}
+
+// GetRow returns one row of a job
+func (s *MemoryStore) GetRow(jobID string, index int) (*Row, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rows := s.rows[jobID]
+	if index < 0 || index >= len(rows) {
+		return nil, ErrNotFound
+	}
+	return copyRow(rows[index]), nil
+}
+
+// GetRows returns up to limit rows of a job in index order, starting at offset
+func (s *MemoryStore) GetRows(jobID string, offset, limit int) ([]*Row, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, ok := s.rows[jobID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	rows := make([]*Row, 0, limit)
+	for i := offset; i < len(all) && len(rows) < limit; i++ {
+		rows = append(rows, copyRow(all[i]))
+	}
+	return rows, nil
+}
+
+// UpdateRow stores a row and updates the counts and status of its job
+func (s *MemoryStore) UpdateRow(row *Row) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[row.JobID]
+	rows := s.rows[row.JobID]
+	if !ok || row.Index < 0 || row.Index >= len(rows) {
+		return nil, ErrNotFound
+	}
+	job.moveRow(rows[row.Index].State, row.State, time.Now().UTC())
+	rows[row.Index] = copyRow(row)
+	return copyJob(job), nil
+}
+
+// Unfinished returns the rows that are not done or failed, oldest job first
+func (s *MemoryStore) Unfinished() ([]*Row, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]*Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		if job.Status != JobCompleted {
+			jobs = append(jobs, job)
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.Before(jobs[j].CreatedAt) })
+
+	rows := make([]*Row, 0)
+	for _, job := range jobs {
+		for _, row := range s.rows[job.ID] {
+			if !row.Finished() {
+				rows = append(rows, copyRow(row))
+			}
+		}
+	}
+	return rows, nil
+}
+
+// PendingCallbacks returns the completed jobs whose callback has not been sent, oldest first
+func (s *MemoryStore) PendingCallbacks() ([]*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]*Job, 0)
+	for _, job := range s.jobs {
+		if job.CallbackPending {
+			jobs = append(jobs, copyJob(job))
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.Before(jobs[j].CreatedAt) })
+	return jobs, nil
+}
+
+// CallbackSent clears the callback pending flag of a job
+func (s *MemoryStore) CallbackSent(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return ErrNotFound
+	}
+	job.CallbackPending = false
+	return nil
+}
+
+// Close does nothing
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// newestFirst sorts jobs by creation time, newest first, and keeps up to limit
+func newestFirst(jobs []*Job, limit int) []*Job {
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.After(jobs[j].CreatedAt) })
+	if limit > 0 && len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs
+}
+
+func copyJob(job *Job) *Job {
+	c := *job
+	c.Counts = make(map[string]int, len(job.Counts))
+	for state, n := range job.Counts {
+		c.Counts[state] = n
+	}
+	return &c
+}
+
+// copyRow deep-copies a row through JSON, like a persistent store would
+func copyRow(row *Row) *Row {
+	data, _ := json.Marshal(row)
+	var c Row
+	json.Unmarshal(data, &c)
+	return &c
+}
@@ -0,0 +1,352 @@
+package jobs
+
+import (
+	"context"
+	"email-finder/internal/service"
+	"email-finder/internal/verifier"
+	"email-finder/internal/webhook"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrClosed is returned when a job is submitted during shutdown
+	ErrClosed = errors.New("job queue is shutting down")
+	// ErrCallbacksDisabled is returned for jobs with a callback URL when no
+	// webhook dispatcher is configured
+	ErrCallbacksDisabled = errors.New("callback_url is not supported: webhooks are not configured")
+)
+
+// Config controls how jobs are run
+type Config struct {
+	// Workers is how many rows are looked up at once, across all jobs
+	Workers int
+	// MaxRows is the most rows a job may have
+	MaxRows int
+	// MaxAttempts is how often a row is run before it fails, counting runs cut
+	// short by a restart or an unavailable verification backend
+	MaxAttempts int
+	// RetryDelay is the wait before a row is retried after the verification
+	// backend was unavailable
+	RetryDelay time.Duration
+}
+
+// rowRef identifies a queued row; the row itself is read from the store when it runs
+type rowRef struct {
+	jobID string
+	index int
+}
+
+// Queue runs the rows of jobs with the email finder service, recording the
+// state of every row in a Store
+type Queue struct {
+	store    Store
+	finder   *service.EmailFinderService
+	webhooks *webhook.Dispatcher
+	cfg      Config
+	logger   *zap.Logger
+
+	// ctx is cancelled when Close gives up waiting, which stops the lookups in progress
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	pending []rowRef
+	closed  bool
+}
+
+// NewQueue creates a new queue. Call Start to resume unfinished jobs and run new ones.
+func NewQueue(store Store, finder *service.EmailFinderService, cfg Config, logger *zap.Logger) *Queue {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &Queue{
+		store:  store,
+		finder: finder,
+		cfg:    cfg,
+		logger: logger,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// SetWebhooks enables jobs with a callback URL, which is notified when the job completes
+func (q *Queue) SetWebhooks(webhooks *webhook.Dispatcher) {
+	q.webhooks = webhooks
+}
+
+// Start queues the rows left unfinished by the previous run and starts the workers.
+// Rows that were interrupted run again from the start; finished rows never do.
+func (q *Queue) Start() error {
+	rows, err := q.store.Unfinished()
+	if err != nil {
+		return fmt.Errorf("failed to load unfinished jobs: %w", err)
+	}
+
+	resumed := 0
+	for _, row := range rows {
+		if row.State != RowPending {
+			// The previous run stopped in the middle of this row
+			if row.Attempts >= q.cfg.MaxAttempts {
+				row.State = RowFailed
+				row.Error = fmt.Sprintf("interrupted %d times", row.Attempts)
+				q.update(row)
+				continue
+			}
+			row.State = RowPending
+			if _, err := q.update(row); err != nil {
+				continue
+			}
+		}
+		q.push(rowRef{jobID: row.JobID, index: row.Index})
+		resumed++
+	}
+	if resumed > 0 {
+		q.logger.Info("resuming unfinished jobs", zap.Int("rows", resumed))
+	}
+
+	// Jobs that completed just before the previous run stopped may not have
+	// handed their callback to the dispatcher
+	if q.webhooks != nil {
+		jobs, err := q.store.PendingCallbacks()
+		if err != nil {
+			return fmt.Errorf("failed to load pending job callbacks: %w", err)
+		}
+		for _, job := range jobs {
+			q.notify(job)
+		}
+	}
+
+	for i := 0; i < q.cfg.Workers; i++ {
+		q.wg.Add(1)
+		go q.work()
+	}
+	return nil
+}
+
+// Submit stores a new job and queues its rows
+func (q *Queue) Submit(requests []service.FindEmailRequest, callbackURL string) (*Job, error) {
+	if err := service.ValidateBatch(requests, callbackURL, q.cfg.MaxRows); err != nil {
+		return nil, err
+	}
+	if callbackURL != "" && q.webhooks == nil {
+		return nil, ErrCallbacksDisabled
+	}
+
+	q.mu.Lock()
+	closed := q.closed
+	q.mu.Unlock()
+	if closed {
+		return nil, ErrClosed
+	}
+
+	job, rows, err := newJob(requests, callbackURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := q.store.CreateJob(job, rows); err != nil {
+		return nil, fmt.Errorf("failed to store job: %w", err)
+	}
+
+	for _, row := range rows {
+		q.push(rowRef{jobID: job.ID, index: row.Index})
+	}
+	q.logger.Info("job submitted", zap.String("job", job.ID), zap.Int("rows", job.Total))
+	return job, nil
+}
+
+// Get returns a job by ID
+func (q *Queue) Get(id string) (*Job, error) {
+	return q.store.GetJob(id)
+}
+
+// List returns up to limit jobs, newest first
+func (q *Queue) List(limit int) ([]*Job, error) {
+	return q.store.ListJobs(limit)
+}
+
+// Rows returns up to limit rows of a job in index order, starting at offset
+func (q *Queue) Rows(jobID string, offset, limit int) ([]*Row, error) {
+	return q.store.GetRows(jobID, offset, limit)
+}
+
+// Queued returns the number of rows waiting for a worker
+func (q *Queue) Queued() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// Close stops taking rows from the queue and waits for the rows in progress
+// until ctx is done, then cancels them. Queued and cancelled rows stay in the
+// store and are resumed by the next Start.
+func (q *Queue) Close(ctx context.Context) error {
+	q.mu.Lock()
+	q.closed = true
+	q.cond.Broadcast()
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.cancel()
+		return nil
+	case <-ctx.Done():
+		q.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// push queues a row, unless the queue is closed
+func (q *Queue) push(ref rowRef) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.pending = append(q.pending, ref)
+	q.cond.Signal()
+}
+
+// next waits for a queued row. It returns false once the queue is closed.
+func (q *Queue) next() (rowRef, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.pending) == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if q.closed {
+		return rowRef{}, false
+	}
+	ref := q.pending[0]
+	q.pending[0] = rowRef{}
+	q.pending = q.pending[1:]
+	return ref, true
+}
+
+func (q *Queue) work() {
+	defer q.wg.Done()
+	for {
+		ref, ok := q.next()
+		if !ok {
+			return
+		}
+		q.run(ref)
+	}
+}
+
+// run looks up one row, recording each state it goes through
+func (q *Queue) run(ref rowRef) {
+	row, err := q.store.GetRow(ref.jobID, ref.index)
+	if err != nil {
+		q.logger.Error("failed to load job row", zap.String("job", ref.jobID), zap.Int("row", ref.index), zap.Error(err))
+		return
+	}
+	if row.Finished() {
+		return
+	}
+
+	row.Attempts++
+	row.State = RowResolving
+	if _, err := q.update(row); err != nil {
+		return
+	}
+
+	ctx := verifier.WithPriority(q.ctx, verifier.PriorityBulk)
+	ctx = service.WithStageHook(ctx, func(stage service.Stage) {
+		if stage == service.StageVerifying {
+			row.State = RowVerifying
+			q.update(row)
+		}
+	})
+	result, err := q.finder.FindEmails(ctx, row.Request)
+
+	switch {
+	case q.ctx.Err() != nil:
+		// Shutting down: the row is resumed on the next start
+		return
+	case errors.Is(err, verifier.ErrCircuitOpen) && row.Attempts < q.cfg.MaxAttempts:
+		row.State = RowPending
+		row.Error = err.Error()
+		if _, err := q.update(row); err != nil {
+			return
+		}
+		time.AfterFunc(q.cfg.RetryDelay, func() { q.push(ref) })
+		return
+	case err != nil:
+		row.State = RowFailed
+		row.Error = err.Error()
+	default:
+		row.State = RowDone
+		row.Result = result
+		row.Error = ""
+	}
+
+	job, err := q.update(row)
+	if err == nil && job.Finished() {
+		q.complete(job)
+	}
+}
+
+// update stores a row and returns its job
+func (q *Queue) update(row *Row) (*Job, error) {
+	row.UpdatedAt = time.Now().UTC()
+	job, err := q.store.UpdateRow(row)
+	if err != nil {
+		q.logger.Error("failed to store job row",
+			zap.String("job", row.JobID),
+			zap.Int("row", row.Index),
+			zap.String("state", row.State),
+			zap.Error(err),
+		)
+	}
+	return job, err
+}
+
+// complete logs a finished job and notifies its callback URL
+func (q *Queue) complete(job *Job) {
+	q.logger.Info("job completed",
+		zap.String("job", job.ID),
+		zap.Int("done", job.Counts[RowDone]),
+		zap.Int("failed", job.Counts[RowFailed]),
+	)
+	if job.CallbackPending && q.webhooks != nil {
+		q.notify(job)
+	}
+}
+
+// notify hands the callback of a completed job to the dispatcher, which stores
+// it, and then clears the job's pending flag. A job whose flag is still set on
+// the next start is sent again, so a callback may be delivered twice but is
+// never lost.
+func (q *Queue) notify(job *Job) {
+	job.CallbackPending = false
+	_, err := q.webhooks.Run(job.CallbackURL, "job", func(ctx context.Context) (interface{}, error) {
+		return job, nil
+	})
+	if err != nil {
+		q.logger.Warn("failed to send job callback", zap.String("job", job.ID), zap.Error(err))
+		return
+	}
+	if err := q.store.CallbackSent(job.ID); err != nil {
+		q.logger.Error("failed to store sent job callback", zap.String("job", job.ID), zap.Error(err))
+	}
+}
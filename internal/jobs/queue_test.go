@@ -0,0 +1,227 @@
+package jobs
+
+import (
+	"context"
+	"email-finder/internal/resolver"
+	"email-finder/internal/service"
+	"email-finder/internal/verifier"
+	"email-finder/internal/webhook"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// countingVerifier accepts every address and counts the verifications per email
+type countingVerifier struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (v *countingVerifier) VerifyEmail(ctx context.Context, email string) (*verifier.VerificationResult, error) {
+	v.mu.Lock()
+	v.calls[email]++
+	v.mu.Unlock()
+	return &verifier.VerificationResult{Email: email, IsReachable: "safe", IsValid: true, IsDeliverable: true}, nil
+}
+
+func (v *countingVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*verifier.VerificationResult, error) {
+	results := make([]*verifier.VerificationResult, 0, len(emails))
+	for _, email := range emails {
+		result, _ := v.VerifyEmail(ctx, email)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (v *countingVerifier) count(email string) int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.calls[email]
+}
+
+// fakeResolver maps every company to one domain
+type fakeResolver struct{}
+
+func (fakeResolver) ResolveDomain(ctx context.Context, companyName string) *resolver.DomainResult {
+	return &resolver.DomainResult{Domain: "example.org", Resolved: true, Method: "company_map"}
+}
+
+func newTestQueue(t *testing.T, store Store, v *countingVerifier) *Queue {
+	t.Helper()
+	finder := service.NewEmailFinderService(v, fakeResolver{}, zap.NewNop(), 1)
+	q := NewQueue(store, finder, Config{Workers: 2, MaxRows: 10, MaxAttempts: 2, RetryDelay: time.Millisecond}, zap.NewNop())
+	if err := q.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(func() { q.Close(context.Background()) })
+	return q
+}
+
+// waitCompleted waits until every row of the job is done or failed
+func waitCompleted(t *testing.T, q *Queue, id string) *Job {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		job, err := q.Get(id)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if job.Status == JobCompleted {
+			return job
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("job did not complete in time")
+	return nil
+}
+
+func TestQueue_RunsJob(t *testing.T) {
+	q := newTestQueue(t, NewMemoryStore(), &countingVerifier{calls: make(map[string]int)})
+
+	job, err := q.Submit([]service.FindEmailRequest{
+		{FirstName: "John", LastName: "Doe", Company: "Acme"},
+		{FirstName: "Jane", LastName: "Roe", Company: "Acme"},
+	}, "")
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	job = waitCompleted(t, q, job.ID)
+	if job.Counts[RowDone] != 2 {
+		t.Errorf("Counts = %v, want 2 done", job.Counts)
+	}
+	rows, _ := q.Rows(job.ID, 0, 10)
+	if len(rows) != 2 || rows[1].Result == nil || rows[1].Result.FoundEmails[0].Email != "jane.roe@example.org" {
+		t.Errorf("rows = %+v, want results in request order", rows)
+	}
+	if rows[0].Attempts != 1 {
+		t.Errorf("Attempts = %d, want 1", rows[0].Attempts)
+	}
+}
+
+func TestQueue_SubmitValidates(t *testing.T) {
+	q := newTestQueue(t, NewMemoryStore(), &countingVerifier{calls: make(map[string]int)})
+
+	if _, err := q.Submit([]service.FindEmailRequest{{FirstName: "John"}}, ""); err == nil {
+		t.Error("Submit() with an invalid row succeeded, want an error")
+	}
+	valid := []service.FindEmailRequest{{FirstName: "John", LastName: "Doe", Company: "Acme"}}
+	if _, err := q.Submit(valid, "https://hooks.example.org"); err != ErrCallbacksDisabled {
+		t.Errorf("Submit() with a callback and no webhooks error = %v, want ErrCallbacksDisabled", err)
+	}
+}
+
+func TestQueue_ResumesAfterRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.db")
+	store, err := OpenBoltStore(path)
+	if err != nil {
+		t.Fatalf("OpenBoltStore() error = %v", err)
+	}
+
+	// A previous run finished row 0, was verifying row 1 and hadn't started row 2
+	job, rows, _ := newJob([]service.FindEmailRequest{
+		{FirstName: "John", LastName: "Doe", Company: "Acme"},
+		{FirstName: "Jane", LastName: "Roe", Company: "Acme"},
+		{FirstName: "Max", LastName: "Power", Company: "Acme"},
+	}, "")
+	store.CreateJob(job, rows)
+	rows[0].State, rows[0].Attempts = RowDone, 1
+	store.UpdateRow(rows[0])
+	rows[1].State, rows[1].Attempts = RowVerifying, 1
+	store.UpdateRow(rows[1])
+	store.Close()
+
+	// Restart on the same database
+	store, err = OpenBoltStore(path)
+	if err != nil {
+		t.Fatalf("OpenBoltStore() error = %v", err)
+	}
+	defer store.Close()
+	v := &countingVerifier{calls: make(map[string]int)}
+	q := newTestQueue(t, store, v)
+
+	job = waitCompleted(t, q, job.ID)
+	if job.Counts[RowDone] != 3 {
+		t.Errorf("Counts = %v, want 3 done", job.Counts)
+	}
+	if n := v.count("john.doe@example.org"); n != 0 {
+		t.Errorf("the finished row was verified %d times after the restart, want 0", n)
+	}
+	if v.count("jane.roe@example.org") != 1 || v.count("max.power@example.org") != 1 {
+		t.Error("the interrupted and pending rows were not verified after the restart")
+	}
+	resumed, _ := q.store.GetRow(job.ID, 1)
+	if resumed.Attempts != 2 {
+		t.Errorf("Attempts of the interrupted row = %d, want 2", resumed.Attempts)
+	}
+}
+
+func TestQueue_FailsRowsInterruptedTooOften(t *testing.T) {
+	store := NewMemoryStore()
+	job, rows, _ := newJob([]service.FindEmailRequest{{FirstName: "John", LastName: "Doe", Company: "Acme"}}, "")
+	store.CreateJob(job, rows)
+	rows[0].State, rows[0].Attempts = RowResolving, 2
+	store.UpdateRow(rows[0])
+
+	v := &countingVerifier{calls: make(map[string]int)}
+	q := newTestQueue(t, store, v)
+
+	job, _ = q.Get(job.ID)
+	if job.Status != JobCompleted || job.Counts[RowFailed] != 1 {
+		t.Errorf("job = %s %v, want completed with the row failed", job.Status, job.Counts)
+	}
+	if v.count("john.doe@example.org") != 0 {
+		t.Error("a row interrupted MaxAttempts times was run again")
+	}
+}
+
+func TestQueue_SendsPendingCallbacksOnStart(t *testing.T) {
+	events := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		events <- r.Header.Get(webhook.EventHeader)
+	}))
+	defer server.Close()
+
+	// The previous run completed the job but stopped before sending its callback
+	store := NewMemoryStore()
+	job, rows, _ := newJob([]service.FindEmailRequest{{FirstName: "John", LastName: "Doe", Company: "Acme"}}, server.URL)
+	store.CreateJob(job, rows)
+	rows[0].State, rows[0].Attempts = RowDone, 1
+	if job, _ = store.UpdateRow(rows[0]); !job.CallbackPending {
+		t.Fatal("completing the job didn't mark its callback pending")
+	}
+
+	webhooks := webhook.NewDispatcher(webhook.Config{
+		Secret:          "s3cret",
+		MaxAttempts:     1,
+		Timeout:         time.Second,
+		History:         10,
+		AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")},
+	}, webhook.NewMemoryStore(), zap.NewNop())
+	defer webhooks.Close(context.Background())
+	finder := service.NewEmailFinderService(&countingVerifier{calls: make(map[string]int)}, fakeResolver{}, zap.NewNop(), 1)
+	q := NewQueue(store, finder, Config{Workers: 1, MaxRows: 10}, zap.NewNop())
+	q.SetWebhooks(webhooks)
+	if err := q.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer q.Close(context.Background())
+
+	select {
+	case event := <-events:
+		if event != "job.completed" {
+			t.Errorf("event = %q, want job.completed", event)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the pending callback was not sent")
+	}
+	if pending, _ := store.PendingCallbacks(); len(pending) != 0 {
+		t.Errorf("PendingCallbacks() returned %d jobs after the callback was sent, want none", len(pending))
+	}
+}
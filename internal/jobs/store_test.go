@@ -0,0 +1,161 @@
+package jobs
+
+import (
+	"context"
+	"email-finder/internal/service"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// storeTests runs the same checks against every Store implementation
+func storeTests(t *testing.T, newStore func(t *testing.T) Store) {
+	requests := []service.FindEmailRequest{
+		{FirstName: "John", LastName: "Doe", Company: "Acme"},
+		{FirstName: "Jane", LastName: "Roe", Company: "Acme"},
+		{FirstName: "Max", LastName: "Mustermann", Company: "Globex"},
+	}
+
+	t.Run("rows move the job through its statuses", func(t *testing.T) {
+		store := newStore(t)
+		job, rows, err := newJob(requests, "")
+		if err != nil {
+			t.Fatalf("newJob() error = %v", err)
+		}
+		if err := store.CreateJob(job, rows); err != nil {
+			t.Fatalf("CreateJob() error = %v", err)
+		}
+
+		row, err := store.GetRow(job.ID, 1)
+		if err != nil || row.Request.FirstName != "Jane" || row.State != RowPending {
+			t.Fatalf("GetRow(1) = %+v, %v, want Jane pending", row, err)
+		}
+
+		row.State = RowVerifying
+		got, err := store.UpdateRow(row)
+		if err != nil {
+			t.Fatalf("UpdateRow() error = %v", err)
+		}
+		if got.Status != JobRunning || got.Counts[RowPending] != 2 || got.Counts[RowVerifying] != 1 {
+			t.Errorf("job = %s %v, want running with 2 pending and 1 verifying", got.Status, got.Counts)
+		}
+
+		for i, state := range []string{RowDone, RowFailed, RowDone} {
+			row, _ := store.GetRow(job.ID, i)
+			row.State = state
+			if got, err = store.UpdateRow(row); err != nil {
+				t.Fatalf("UpdateRow() error = %v", err)
+			}
+		}
+		if got.Status != JobCompleted || got.CompletedAt == nil || got.Counts[RowDone] != 2 || got.Counts[RowFailed] != 1 {
+			t.Errorf("job = %s %v, want completed with 2 done and 1 failed", got.Status, got.Counts)
+		}
+
+		stored, err := store.GetJob(job.ID)
+		if err != nil || stored.Status != JobCompleted {
+			t.Errorf("GetJob() = %+v, %v, want the completed job", stored, err)
+		}
+	})
+
+	t.Run("rows are paged in index order", func(t *testing.T) {
+		store := newStore(t)
+		job, rows, _ := newJob(requests, "")
+		store.CreateJob(job, rows)
+
+		page, err := store.GetRows(job.ID, 1, 5)
+		if err != nil {
+			t.Fatalf("GetRows() error = %v", err)
+		}
+		if len(page) != 2 || page[0].Index != 1 || page[1].Index != 2 {
+			t.Errorf("GetRows(1, 5) returned %d rows, want rows 1 and 2", len(page))
+		}
+	})
+
+	t.Run("unfinished rows skip completed jobs and finished rows", func(t *testing.T) {
+		store := newStore(t)
+		first, firstRows, _ := newJob(requests[:1], "")
+		store.CreateJob(first, firstRows)
+		firstRows[0].State = RowDone
+		store.UpdateRow(firstRows[0])
+
+		second, secondRows, _ := newJob(requests, "")
+		store.CreateJob(second, secondRows)
+		secondRows[0].State = RowDone
+		store.UpdateRow(secondRows[0])
+		secondRows[2].State = RowResolving
+		store.UpdateRow(secondRows[2])
+
+		rows, err := store.Unfinished()
+		if err != nil {
+			t.Fatalf("Unfinished() error = %v", err)
+		}
+		if len(rows) != 2 || rows[0].Index != 1 || rows[1].Index != 2 || rows[1].State != RowResolving {
+			t.Errorf("Unfinished() returned %d rows, want rows 1 and 2 of the second job", len(rows))
+		}
+	})
+
+	t.Run("completing a job with a callback marks it pending", func(t *testing.T) {
+		store := newStore(t)
+		job, rows, _ := newJob(requests[:1], "https://hooks.example.org")
+		store.CreateJob(job, rows)
+		rows[0].State = RowDone
+		store.UpdateRow(rows[0])
+
+		pending, err := store.PendingCallbacks()
+		if err != nil {
+			t.Fatalf("PendingCallbacks() error = %v", err)
+		}
+		if len(pending) != 1 || pending[0].ID != job.ID {
+			t.Fatalf("PendingCallbacks() returned %d jobs, want the completed job", len(pending))
+		}
+
+		if err := store.CallbackSent(job.ID); err != nil {
+			t.Fatalf("CallbackSent() error = %v", err)
+		}
+		if pending, _ := store.PendingCallbacks(); len(pending) != 0 {
+			t.Errorf("PendingCallbacks() returned %d jobs after CallbackSent, want none", len(pending))
+		}
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		store := newStore(t)
+		if _, err := store.GetJob("missing"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetJob() error = %v, want ErrNotFound", err)
+		}
+		if _, err := store.GetRows("missing", 0, 10); !errors.Is(err, ErrNotFound) {
+			t.Errorf("GetRows() error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestMemoryStore(t *testing.T) {
+	storeTests(t, func(t *testing.T) Store {
+		return NewMemoryStore()
+	})
+}
+
+func TestBoltStore(t *testing.T) {
+	storeTests(t, func(t *testing.T) Store {
+		store, err := OpenBoltStore(filepath.Join(t.TempDir(), "jobs.db"))
+		if err != nil {
+			t.Fatalf("OpenBoltStore() error = %v", err)
+		}
+		t.Cleanup(func() { store.Close() })
+		return store
+	})
+}
+
+func TestBoltStore_HealthCheck(t *testing.T) {
+	store, err := OpenBoltStore(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatalf("OpenBoltStore() error = %v", err)
+	}
+	if err := store.HealthCheck(context.Background()); err != nil {
+		t.Errorf("HealthCheck() error = %v, want nil", err)
+	}
+
+	store.Close()
+	if err := store.HealthCheck(context.Background()); err == nil {
+		t.Error("HealthCheck() on a closed database succeeded, want an error")
+	}
+}
@@ -0,0 +1,151 @@
+// Package metrics defines the Prometheus metrics exported on /metrics
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "email_finder"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route, method and status code.",
+	}, []string{"route", "method", "status"})
+
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route and method.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60},
+	}, []string{"route", "method"})
+
+	verifications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "verifications_total",
+		Help:      "Email verifications by backend and outcome (is_reachable, or \"error\").",
+	}, []string{"backend", "outcome"})
+
+	verificationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "verification_duration_seconds",
+		Help:      "Latency of single email verifications by backend.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2, 3, 5, 10, 20, 30},
+	}, []string{"backend"})
+
+	domainResolutions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "domain_resolutions_total",
+		Help:      "Company domain resolutions by method.",
+	}, []string{"method"})
+
+	cacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_lookups_total",
+		Help:      "Cache lookups by cache and result (hit or miss).",
+	}, []string{"cache", "result"})
+
+	webhookDeliveries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Finished webhook deliveries by status (delivered or failed).",
+	}, []string{"status"})
+
+	historyRecords = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "history_records_total",
+		Help:      "Lookup records by outcome (saved, failed or dropped).",
+	}, []string{"outcome"})
+
+	domainProfileUpdates = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "domain_profile_updates_total",
+		Help:      "Domain profile updates by outcome (saved, failed or dropped).",
+	}, []string{"outcome"})
+
+	cliProcesses = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cli_processes_in_flight",
+		Help:      "Number of check-if-email-exists CLI processes currently running.",
+	})
+)
+
+// Handler returns the HTTP handler serving the metrics
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.Handler())
+}
+
+// Middleware records request counts and latencies per route
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		// Use the route template so path parameters don't explode cardinality
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		httpRequests.WithLabelValues(route, c.Request.Method, strconv.Itoa(c.Writer.Status())).Inc()
+		httpDuration.WithLabelValues(route, c.Request.Method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveVerification records one verification against backend. outcome is the
+// is_reachable status, or "error" if the verification failed.
+func ObserveVerification(backend, outcome string, duration time.Duration) {
+	verifications.WithLabelValues(backend, outcome).Inc()
+	verificationDuration.WithLabelValues(backend).Observe(duration.Seconds())
+}
+
+// ObserveResolution records the method used to resolve a company domain
+func ObserveResolution(method string) {
+	domainResolutions.WithLabelValues(method).Inc()
+}
+
+// ObserveCacheLookup records a hit or miss in the named cache
+func ObserveCacheLookup(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheLookups.WithLabelValues(cache, result).Inc()
+}
+
+// ObserveWebhookDelivery records the final status of a webhook delivery
+func ObserveWebhookDelivery(status string) {
+	webhookDeliveries.WithLabelValues(status).Inc()
+}
+
+// ObserveHistoryRecord records the outcome of storing a lookup record
+func ObserveHistoryRecord(outcome string) {
+	historyRecords.WithLabelValues(outcome).Inc()
+}
+
+// ObserveDomainProfileUpdate records the outcome of storing a domain profile update
+func ObserveDomainProfileUpdate(outcome string) {
+	domainProfileUpdates.WithLabelValues(outcome).Inc()
+}
+
+// CLIProcessStarted records a CLI process being started. The returned function
+// must be called when it exits.
+func CLIProcessStarted() func() {
+	cliProcesses.Inc()
+	return cliProcesses.Dec
+}
+
+// RegisterGaugeFunc exports a gauge whose value is read from fn at scrape time
+func RegisterGaugeFunc(name, help string, fn func() float64) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      name,
+		Help:      help,
+	}, fn)
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMiddleware_LabelsByRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware())
+	router.GET("/items/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	before := testutil.ToFloat64(httpRequests.WithLabelValues("/items/:id", "GET", "204"))
+	for _, path := range []string{"/items/1", "/items/2"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	if got := testutil.ToFloat64(httpRequests.WithLabelValues("/items/:id", "GET", "204")) - before; got != 2 {
+		t.Errorf("requests for /items/:id = %v, want 2", got)
+	}
+}
+
+func TestObserveCacheLookup(t *testing.T) {
+	hits := testutil.ToFloat64(cacheLookups.WithLabelValues("test", "hit"))
+	misses := testutil.ToFloat64(cacheLookups.WithLabelValues("test", "miss"))
+
+	ObserveCacheLookup("test", true)
+	ObserveCacheLookup("test", false)
+	ObserveCacheLookup("test", false)
+
+	if got := testutil.ToFloat64(cacheLookups.WithLabelValues("test", "hit")) - hits; got != 1 {
+		t.Errorf("hits = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(cacheLookups.WithLabelValues("test", "miss")) - misses; got != 2 {
+		t.Errorf("misses = %v, want 2", got)
+	}
+}
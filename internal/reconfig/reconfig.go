@@ -0,0 +1,300 @@
+// Package reconfig applies configuration changes to the running service
+package reconfig
+
+import (
+	"email-finder/config"
+	"email-finder/internal/service"
+	"email-finder/internal/verifier"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TimeoutSetter is implemented by verifiers whose timeout can change at runtime
+type TimeoutSetter interface {
+	SetTimeout(timeout time.Duration)
+}
+
+// RateLimitSetter is implemented by rate limiters whose limit can change at runtime
+type RateLimitSetter interface {
+	SetLimit(perMinute int)
+}
+
+// Targets are the components whose settings can be changed without a restart
+type Targets struct {
+	Level zap.AtomicLevel
+	Pool  *verifier.WorkerPool
+	// Timeouts follow the verification timeout: the backends and the domain resolver
+	Timeouts  []TimeoutSetter
+	Service   *service.EmailFinderService
+	Throttler *verifier.Throttler
+	// RateLimiter enforces the REST API rate limit
+	RateLimiter RateLimitSetter
+}
+
+// Change is one setting that was changed
+type Change struct {
+	Setting string `json:"setting"`
+	Old     string `json:"old"`
+	New     string `json:"new"`
+}
+
+// Result describes what a reconfiguration did
+type Result struct {
+	Changes []Change `json:"changes"`
+	// RestartRequired lists changed settings that only take effect after a restart
+	RestartRequired []string `json:"restart_required,omitempty"`
+}
+
+// Update is a partial change of the safe-to-change settings; nil fields are kept
+type Update struct {
+	LogLevel                  *string  `json:"log_level"`
+	VerificationConcurrency   *int     `json:"verification_concurrency"`
+	VerificationTimeout       *string  `json:"verification_timeout"`
+	MaxEmailPatterns          *int     `json:"max_email_patterns"`
+	ThrottleDomainConcurrency *int     `json:"throttle_domain_concurrency"`
+	ThrottleDomainRate        *float64 `json:"throttle_domain_rate"`
+	ThrottleMXConcurrency     *int     `json:"throttle_mx_concurrency"`
+	ThrottleMXRate            *float64 `json:"throttle_mx_rate"`
+	RateLimit                 *int     `json:"rate_limit"`
+}
+
+// Reconfigurer applies the settings that are safe to change live: log level,
+// verification concurrency and timeout, pattern limit, throttle limits and
+// rate limit.
+// Every change is written to the log as an audit entry.
+type Reconfigurer struct {
+	targets Targets
+	load    func() (*config.Config, error)
+	logger  *zap.Logger
+
+	mu      sync.Mutex
+	current *config.Config
+}
+
+// New creates a new reconfigurer for a service started with current. load
+// re-reads the configuration for Reload.
+func New(current *config.Config, targets Targets, load func() (*config.Config, error), logger *zap.Logger) *Reconfigurer {
+	return &Reconfigurer{
+		targets: targets,
+		load:    load,
+		logger:  logger,
+		current: clone(current),
+	}
+}
+
+// Current returns the configuration in effect
+func (r *Reconfigurer) Current() *config.Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return clone(r.current)
+}
+
+// Reload re-reads the configuration file, environment and flags and applies
+// the safe-to-change settings
+func (r *Reconfigurer) Reload(source string) (*Result, error) {
+	next, err := r.load()
+	if err != nil {
+		r.logger.Error("configuration reload failed, keeping current settings",
+			zap.String("source", source),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.apply(next, source), nil
+}
+
+// Update applies a partial change of the safe-to-change settings. The change
+// is merged into the settings in effect and applied under one lock, so
+// concurrent updates don't undo each other.
+func (r *Reconfigurer) Update(update Update, source string) (*Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next := clone(r.current)
+
+	if update.LogLevel != nil {
+		next.Logging.Level = *update.LogLevel
+	}
+	if update.VerificationConcurrency != nil {
+		next.VerificationConcurrency = *update.VerificationConcurrency
+	}
+	if update.VerificationTimeout != nil {
+		timeout, err := time.ParseDuration(*update.VerificationTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("verification_timeout: %q is not a duration", *update.VerificationTimeout)
+		}
+		next.VerificationTimeout = timeout
+	}
+	if update.MaxEmailPatterns != nil {
+		next.MaxEmailPatterns = *update.MaxEmailPatterns
+	}
+	if update.ThrottleDomainConcurrency != nil {
+		next.Throttle.DomainConcurrency = *update.ThrottleDomainConcurrency
+	}
+	if update.ThrottleDomainRate != nil {
+		next.Throttle.DomainRate = *update.ThrottleDomainRate
+	}
+	if update.ThrottleMXConcurrency != nil {
+		next.Throttle.MXConcurrency = *update.ThrottleMXConcurrency
+	}
+	if update.ThrottleMXRate != nil {
+		next.Throttle.MXRate = *update.ThrottleMXRate
+	}
+	if update.RateLimit != nil {
+		next.RateLimit = *update.RateLimit
+	}
+
+	if err := next.Validate(); err != nil {
+		return nil, err
+	}
+	return r.apply(next, source), nil
+}
+
+// apply changes every safe setting that differs in next and reports the
+// others. r.mu must be held.
+func (r *Reconfigurer) apply(next *config.Config, source string) *Result {
+	cur := r.current
+	result := &Result{Changes: make([]Change, 0)}
+	record := func(setting string, old, new interface{}) {
+		change := Change{Setting: setting, Old: fmt.Sprint(old), New: fmt.Sprint(new)}
+		result.Changes = append(result.Changes, change)
+		r.logger.Info("configuration changed",
+			zap.Bool("audit", true),
+			zap.String("source", source),
+			zap.String("setting", change.Setting),
+			zap.String("old", change.Old),
+			zap.String("new", change.New),
+		)
+	}
+
+	if next.Logging.Level != cur.Logging.Level {
+		r.targets.Level.SetLevel(config.ParseLogLevel(next.Logging.Level))
+		record("logging.level", cur.Logging.Level, next.Logging.Level)
+	}
+	if next.VerificationConcurrency != cur.VerificationConcurrency {
+		if r.targets.Pool != nil {
+			r.targets.Pool.Resize(next.VerificationConcurrency)
+		}
+		record("verification_concurrency", cur.VerificationConcurrency, next.VerificationConcurrency)
+	}
+	if next.VerificationTimeout != cur.VerificationTimeout {
+		for _, v := range r.targets.Timeouts {
+			v.SetTimeout(next.VerificationTimeout)
+		}
+		record("verification_timeout", cur.VerificationTimeout, next.VerificationTimeout)
+	}
+	if next.MaxEmailPatterns != cur.MaxEmailPatterns {
+		if r.targets.Service != nil {
+			r.targets.Service.SetMaxPatterns(next.MaxEmailPatterns)
+		}
+		record("max_email_patterns", cur.MaxEmailPatterns, next.MaxEmailPatterns)
+	}
+	if !reflect.DeepEqual(next.Throttle, cur.Throttle) {
+		if r.targets.Throttler != nil {
+			r.targets.Throttler.SetConfig(ThrottleConfig(next.Throttle))
+		}
+		record("throttle", fmt.Sprintf("%+v", cur.Throttle), fmt.Sprintf("%+v", next.Throttle))
+	}
+	if next.RateLimit != cur.RateLimit {
+		if r.targets.RateLimiter != nil {
+			r.targets.RateLimiter.SetLimit(next.RateLimit)
+		}
+		record("rate_limit", cur.RateLimit, next.RateLimit)
+	}
+
+	result.RestartRequired = restartRequired(cur, next)
+	if len(result.RestartRequired) > 0 {
+		r.logger.Warn("configuration changes need a restart to take effect",
+			zap.String("source", source),
+			zap.Strings("settings", result.RestartRequired),
+		)
+	}
+
+	// Only the applied settings become current, so a later reload reports the
+	// others again until the service is restarted
+	applied := clone(cur)
+	applied.Logging.Level = next.Logging.Level
+	applied.VerificationConcurrency = next.VerificationConcurrency
+	applied.VerificationTimeout = next.VerificationTimeout
+	applied.MaxEmailPatterns = next.MaxEmailPatterns
+	applied.Throttle = clone(next).Throttle
+	applied.RateLimit = next.RateLimit
+	r.current = applied
+
+	return result
+}
+
+// ThrottleConfig converts the throttle settings to the verifier's limits
+func ThrottleConfig(cfg config.ThrottleConfig) verifier.ThrottleConfig {
+	overrides := make(map[string]verifier.ThrottleLimit, len(cfg.ProviderOverrides))
+	for suffix, limit := range cfg.ProviderOverrides {
+		overrides[suffix] = verifier.ThrottleLimit{Concurrency: limit.Concurrency, PerSecond: limit.Rate}
+	}
+	return verifier.ThrottleConfig{
+		Domain:            verifier.ThrottleLimit{Concurrency: cfg.DomainConcurrency, PerSecond: cfg.DomainRate},
+		MX:                verifier.ThrottleLimit{Concurrency: cfg.MXConcurrency, PerSecond: cfg.MXRate},
+		ProviderOverrides: overrides,
+	}
+}
+
+// restartRequired lists the sections of next that differ from cur in settings
+// that cannot be changed live
+func restartRequired(cur, next *config.Config) []string {
+	a, b := clone(cur), clone(next)
+	for _, c := range []*config.Config{a, b} {
+		c.Logging.Level = ""
+		c.VerificationConcurrency = 0
+		c.VerificationTimeout = 0
+		c.MaxEmailPatterns = 0
+		c.Throttle = config.ThrottleConfig{}
+		c.RateLimit = 0
+	}
+
+	sections := []struct {
+		name     string
+		old, new interface{}
+	}{
+		{"server", a.Server, b.Server},
+		{"email_verification", a.EmailVerification, b.EmailVerification},
+		{"logging", a.Logging, b.Logging},
+		{"retry", a.Retry, b.Retry},
+		{"tracing", a.Tracing, b.Tracing},
+		{"health", a.Health, b.Health},
+		{"admin", a.Admin, b.Admin},
+		{"webhook", a.Webhook, b.Webhook},
+		{"jobs", a.Jobs, b.Jobs},
+		{"history", a.History, b.History},
+		{"lookup_cache", a.LookupCache, b.LookupCache},
+		{"domains", a.Domains, b.Domains},
+	}
+
+	changed := make([]string, 0)
+	for _, section := range sections {
+		if !reflect.DeepEqual(section.old, section.new) {
+			changed = append(changed, section.name)
+		}
+	}
+	return changed
+}
+
+// clone returns a deep copy of a configuration
+func clone(c *config.Config) *config.Config {
+	copied := *c
+	copied.Server.TrustedProxies = append([]string(nil), c.Server.TrustedProxies...)
+	copied.EmailVerification.APIURLs = append([]string(nil), c.EmailVerification.APIURLs...)
+	copied.Webhook.AllowedNetworks = append([]string(nil), c.Webhook.AllowedNetworks...)
+	copied.Throttle.ProviderOverrides = make(map[string]config.ThrottleLimit, len(c.Throttle.ProviderOverrides))
+	for suffix, limit := range c.Throttle.ProviderOverrides {
+		copied.Throttle.ProviderOverrides[suffix] = limit
+	}
+	return &copied
+}
@@ -0,0 +1,186 @@
+package reconfig
+
+import (
+	"email-finder/config"
+	"email-finder/internal/verifier"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// fakeVerifier records the timeout it was given
+type fakeVerifier struct {
+	timeout time.Duration
+}
+
+func (f *fakeVerifier) SetTimeout(timeout time.Duration) { f.timeout = timeout }
+
+// fakeRateLimiter records the limit it was set to
+type fakeRateLimiter struct {
+	perMinute int
+}
+
+func (f *fakeRateLimiter) SetLimit(perMinute int) { f.perMinute = perMinute }
+
+// testConfig returns a valid configuration using the HTTP backend
+func testConfig() *config.Config {
+	cfg := config.Default()
+	cfg.EmailVerification.UseHTTP = true
+	cfg.EmailVerification.APIURLs = []string{cfg.EmailVerification.APIURL}
+	return cfg
+}
+
+func TestReconfigurer_Update(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	pool := verifier.NewWorkerPool(2, zap.NewNop())
+	defer pool.Close()
+	fake := &fakeVerifier{}
+
+	r := New(testConfig(), Targets{Level: level, Pool: pool, Timeouts: []TimeoutSetter{fake}}, nil, zap.New(core))
+
+	logLevel, concurrency, timeout := "debug", 5, "45s"
+	result, err := r.Update(Update{
+		LogLevel:                &logLevel,
+		VerificationConcurrency: &concurrency,
+		VerificationTimeout:     &timeout,
+	}, "test")
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if len(result.Changes) != 3 {
+		t.Errorf("Update() changes = %+v, want 3", result.Changes)
+	}
+	if level.Level() != zapcore.DebugLevel {
+		t.Errorf("log level = %s, want debug", level.Level())
+	}
+	if stats := pool.Stats(); stats.Workers != 5 {
+		t.Errorf("pool workers = %d, want 5", stats.Workers)
+	}
+	if fake.timeout != 45*time.Second {
+		t.Errorf("verifier timeout = %s, want 45s", fake.timeout)
+	}
+	if got := r.Current().VerificationConcurrency; got != 5 {
+		t.Errorf("Current().VerificationConcurrency = %d, want 5", got)
+	}
+
+	audit := logs.FilterMessage("configuration changed").FilterField(zap.Bool("audit", true))
+	if audit.Len() != 3 {
+		t.Errorf("logged %d audit entries, want 3", audit.Len())
+	}
+}
+
+func TestReconfigurer_ConcurrentUpdates(t *testing.T) {
+	r := New(testConfig(), Targets{Level: zap.NewAtomicLevel()}, nil, zap.NewNop())
+
+	// Each update changes another setting; none may undo the others
+	var wg sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var update Update
+			switch i % 4 {
+			case 0:
+				patterns := 10*i + 1 // never the default
+				update.MaxEmailPatterns = &patterns
+			case 1:
+				update.ThrottleDomainConcurrency = &i
+			case 2:
+				update.ThrottleMXConcurrency = &i
+			case 3:
+				timeout := fmt.Sprintf("%ds", i)
+				update.VerificationTimeout = &timeout
+			}
+			if _, err := r.Update(update, "test"); err != nil {
+				t.Errorf("Update() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	cur, def := r.Current(), testConfig()
+	if cur.MaxEmailPatterns == def.MaxEmailPatterns || cur.Throttle.DomainConcurrency == def.Throttle.DomainConcurrency ||
+		cur.Throttle.MXConcurrency == def.Throttle.MXConcurrency || cur.VerificationTimeout == def.VerificationTimeout {
+		t.Errorf("Current() = %+v, want every setting changed", cur)
+	}
+}
+
+func TestReconfigurer_RateLimit(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	limiter := &fakeRateLimiter{}
+	next := testConfig()
+	next.RateLimit = 30
+
+	r := New(testConfig(), Targets{Level: zap.NewAtomicLevel(), RateLimiter: limiter}, func() (*config.Config, error) {
+		return next, nil
+	}, zap.New(core))
+
+	limit := 120
+	if _, err := r.Update(Update{RateLimit: &limit}, "test"); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if limiter.perMinute != 120 {
+		t.Errorf("rate limit = %d after Update(), want 120", limiter.perMinute)
+	}
+
+	result, err := r.Reload("test")
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if limiter.perMinute != 30 || len(result.RestartRequired) != 0 {
+		t.Errorf("rate limit = %d, restart required = %v after Reload(), want 30 applied live", limiter.perMinute, result.RestartRequired)
+	}
+
+	audit := logs.FilterMessage("configuration changed").FilterField(zap.String("setting", "rate_limit"))
+	if audit.Len() != 2 {
+		t.Errorf("logged %d rate_limit audit entries, want 2", audit.Len())
+	}
+}
+
+func TestReconfigurer_UpdateInvalid(t *testing.T) {
+	pool := verifier.NewWorkerPool(2, zap.NewNop())
+	defer pool.Close()
+
+	r := New(testConfig(), Targets{Level: zap.NewAtomicLevel(), Pool: pool}, nil, zap.NewNop())
+
+	concurrency := -1
+	if _, err := r.Update(Update{VerificationConcurrency: &concurrency}, "test"); err == nil {
+		t.Error("Update() with negative concurrency succeeded, want an error")
+	}
+	if stats := pool.Stats(); stats.Workers != 2 {
+		t.Errorf("pool workers = %d after a rejected update, want 2", stats.Workers)
+	}
+}
+
+func TestReconfigurer_ReloadRestartRequired(t *testing.T) {
+	next := testConfig()
+	next.MaxEmailPatterns = 50
+	next.Server.Port = "9999"
+
+	r := New(testConfig(), Targets{Level: zap.NewAtomicLevel()}, func() (*config.Config, error) {
+		return next, nil
+	}, zap.NewNop())
+
+	result, err := r.Reload("test")
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if len(result.Changes) != 1 || result.Changes[0].Setting != "max_email_patterns" {
+		t.Errorf("Reload() changes = %+v, want only max_email_patterns", result.Changes)
+	}
+	if len(result.RestartRequired) != 1 || result.RestartRequired[0] != "server" {
+		t.Errorf("Reload() restart required = %v, want [server]", result.RestartRequired)
+	}
+
+	// The port is still the one the server is listening on
+	if got := r.Current().Server.Port; got == "9999" {
+		t.Errorf("Current().Server.Port = %q, want the port in use", got)
+	}
+}
@@ -2,21 +2,28 @@ package resolver
 
 import (
 	"context"
+	"email-finder/internal/domains"
 	"fmt"
 	"net"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
+var tracer = otel.Tracer("email-finder/internal/resolver")
+
 // DomainResolver resolves company names to domains
 type DomainResolver struct {
 	logger     *zap.Logger
-	timeout    time.Duration
+	timeout    atomic.Int64 // time.Duration, changeable at runtime
 	companyMap map[string]string
 	mapMutex   sync.RWMutex
+	profiles   *domains.Profiles
 }
 
 // wellKnownCompanies is a map of company names (normalized) to their domains
@@ -160,11 +167,24 @@ func NewDomainResolver(logger *zap.Logger, timeout time.Duration) *DomainResolve
 		companyMap[k] = v
 	}
 
-	return &DomainResolver{
+	r := &DomainResolver{
 		logger:     logger,
-		timeout:    timeout,
 		companyMap: companyMap,
 	}
+	r.timeout.Store(int64(timeout))
+	return r
+}
+
+// SetTimeout changes the DNS lookup timeout of later lookups
+func (r *DomainResolver) SetTimeout(timeout time.Duration) {
+	r.timeout.Store(int64(timeout))
+}
+
+// SetProfiles makes the resolver record the domains it finds in DNS, with
+// their MX hosts, and trust those it recently found instead of looking them up
+// again. It must be called before the resolver is used.
+func (r *DomainResolver) SetProfiles(p *domains.Profiles) {
+	r.profiles = p
 }
 
 // AddCompanyDomain adds or updates a company domain mapping
@@ -191,7 +211,21 @@ func (r *DomainResolver) GetCompanyDomain(companyName string) (string, bool) {
 }
 
 // ResolveDomain attempts to resolve a company name to a domain
-func (r *DomainResolver) ResolveDomain(companyName string) *DomainResult {
+func (r *DomainResolver) ResolveDomain(ctx context.Context, companyName string) *DomainResult {
+	ctx, span := tracer.Start(ctx, "resolver.ResolveDomain")
+	defer span.End()
+
+	result := r.resolve(ctx, companyName)
+	span.SetAttributes(
+		attribute.String("company", companyName),
+		attribute.String("domain", result.Domain),
+		attribute.String("method", result.Method),
+	)
+	return result
+}
+
+// resolve attempts to resolve a company name to a domain
+func (r *DomainResolver) resolve(ctx context.Context, companyName string) *DomainResult {
 	companyName = strings.TrimSpace(strings.ToLower(companyName))
 
 	if companyName == "" {
@@ -205,7 +239,7 @@ func (r *DomainResolver) ResolveDomain(companyName string) *DomainResult {
 	// Check if it's already a domain
 	if r.isDomain(companyName) {
 		// Verify it has valid DNS records
-		if r.verifyDomain(companyName) {
+		if r.verifyDomain(ctx, companyName) {
 			return &DomainResult{
 				Domain:   companyName,
 				Resolved: true,
@@ -238,7 +272,7 @@ func (r *DomainResolver) ResolveDomain(companyName string) *DomainResult {
 
 	// Try to verify candidates via DNS
 	for _, candidate := range candidates {
-		if r.verifyDomain(candidate) {
+		if r.verifyDomain(ctx, candidate) {
 			r.logger.Info("domain resolved via DNS",
 				zap.String("company", companyName),
 				zap.String("domain", candidate),
@@ -376,27 +410,56 @@ func (r *DomainResolver) getCompanyVariations(name string) []string {
 }
 
 // verifyDomain checks if a domain has valid DNS records
-func (r *DomainResolver) verifyDomain(domain string) bool {
-	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+func (r *DomainResolver) verifyDomain(ctx context.Context, domain string) bool {
+	ctx, span := tracer.Start(ctx, "resolver.verifyDomain")
+	defer span.End()
+	span.SetAttributes(attribute.String("domain", domain))
+
+	valid := r.lookupDomain(ctx, domain)
+	span.SetAttributes(attribute.Bool("valid", valid))
+	return valid
+}
+
+// lookupDomain looks up MX, A and CNAME records for a domain
+func (r *DomainResolver) lookupDomain(ctx context.Context, domain string) bool {
+	if r.profiles != nil && r.profiles.Resolves(domain) {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(r.timeout.Load()))
 	defer cancel()
 
 	// Try to resolve MX records (most reliable for email domains)
 	mxRecords, err := net.DefaultResolver.LookupMX(ctx, domain)
 	if err == nil && len(mxRecords) > 0 {
+		hosts := make([]string, len(mxRecords))
+		for i, mx := range mxRecords {
+			hosts[i] = mx.Host
+		}
+		r.observeDNS(domain, hosts)
 		return true
 	}
 
 	// Fallback: try A records
 	_, err = net.DefaultResolver.LookupHost(ctx, domain)
 	if err == nil {
+		r.observeDNS(domain, nil)
 		return true
 	}
 
 	// Fallback: try CNAME
 	_, err = net.DefaultResolver.LookupCNAME(ctx, domain)
 	if err == nil {
+		r.observeDNS(domain, nil)
 		return true
 	}
 
 	return false
 }
+
+// observeDNS records that a domain was found in DNS, with its MX hosts if any
+func (r *DomainResolver) observeDNS(domain string, mx []string) {
+	if r.profiles != nil {
+		r.profiles.ObserveDNS(domain, mx)
+	}
+}
@@ -1,6 +1,7 @@
 package resolver
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -64,7 +65,7 @@ func TestDomainResolver_ResolveDomain(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := resolver.ResolveDomain(tt.company)
+			result := resolver.ResolveDomain(context.Background(), tt.company)
 			if result.Resolved != tt.wantResolved {
 				t.Errorf("ResolveDomain() Resolved = %v, want %v", result.Resolved, tt.wantResolved)
 			}
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"email-finder/config"
+	"email-finder/internal/app"
+	"email-finder/internal/handler"
+	"email-finder/internal/health"
+	"email-finder/internal/metrics"
+	"email-finder/internal/verifier"
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.uber.org/zap"
+)
+
+// probedStore is a database whose readiness is probed
+type probedStore struct {
+	name     string
+	critical bool
+	store    interface{}
+}
+
+// buildHealthChecker registers the readiness probes: the verifier as a whole and
+// DNS resolution are critical, while a single backend among several is not.
+// Every database that supports it is probed with a read transaction; those
+// holding accepted work are critical.
+func buildHealthChecker(cfg *config.Config, components *app.App, stores []probedStore) *health.Checker {
+	checker := health.NewChecker(cfg.Health.Timeout)
+
+	if hc, ok := components.HealthChecker(); ok {
+		checker.Add("verifier", true, hc.HealthCheck)
+	}
+	if len(components.Backends) > 1 {
+		for _, b := range components.Backends {
+			if hc, ok := b.Verifier.(verifier.HealthChecker); ok {
+				checker.Add("verifier:"+b.Name, false, hc.HealthCheck)
+			}
+		}
+	}
+	checker.Add("dns", true, health.DNSCheck(cfg.Health.DNSProbeDomain))
+	for _, s := range stores {
+		if hc, ok := s.store.(verifier.HealthChecker); ok {
+			checker.Add("store:"+s.name, s.critical, hc.HealthCheck)
+		}
+	}
+
+	return checker
+}
+
+func setupRouter(emailHandler *handler.EmailHandler, statsHandler *handler.StatsHandler, healthHandler *handler.HealthHandler, adminHandler *handler.AdminHandler, webhookHandler *handler.WebhookHandler, jobHandler *handler.JobHandler, historyHandler *handler.HistoryHandler, domainHandler *handler.DomainHandler, rateLimiter *handler.RateLimiter, logger *zap.Logger, cfg *config.Config) (*gin.Engine, error) {
+	// Set Gin mode
+	if cfg.Logging.Level == "debug" {
+		gin.SetMode(gin.DebugMode)
+	} else {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	router := gin.New()
+
+	// The client IP, which the rate limit is keyed by, is only read from
+	// X-Forwarded-For when the request comes from a trusted proxy
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
+	// Middleware
+	router.Use(otelgin.Middleware(cfg.Tracing.ServiceName))
+	router.Use(ginLogger(logger))
+	router.Use(metrics.Middleware())
+	router.Use(gin.Recovery())
+	router.Use(corsMiddleware())
+
+	// Health check
+	router.GET("/health", emailHandler.HealthCheck)
+	router.GET("/health/live", healthHandler.Live)
+	router.GET("/health/ready", healthHandler.Ready)
+
+	// Prometheus metrics
+	router.GET("/metrics", metrics.Handler())
+
+	// API routes
+	v1 := router.Group("/api/v1", rateLimiter.Middleware())
+	{
+		v1.POST("/find-email", emailHandler.FindEmail)
+		v1.POST("/find-email/bulk", emailHandler.FindEmailsBulk)
+		v1.POST("/infer-pattern", emailHandler.InferPattern)
+		v1.POST("/verify", emailHandler.VerifyEmails)
+		v1.POST("/resolve-domain", emailHandler.ResolveDomain)
+		v1.GET("/stats/verification-queue", statsHandler.VerificationQueue)
+
+		v1.POST("/jobs", jobHandler.SubmitJob)
+		v1.GET("/jobs", jobHandler.ListJobs)
+		v1.GET("/jobs/:id", jobHandler.GetJob)
+		v1.GET("/jobs/:id/results", jobHandler.GetResults)
+
+		v1.GET("/domains/:domain", domainHandler.GetDomain)
+
+		if historyHandler != nil {
+			v1.GET("/history", historyHandler.ListHistory)
+			v1.GET("/history/:id", historyHandler.GetRecord)
+		}
+
+		if webhookHandler != nil {
+			v1.GET("/webhooks/deliveries", webhookHandler.ListDeliveries)
+			v1.GET("/webhooks/deliveries/:id", webhookHandler.GetDelivery)
+		}
+	}
+
+	// Admin routes are only served when an admin token is configured
+	if adminHandler != nil {
+		admin := router.Group("/admin", adminHandler.RequireToken())
+		{
+			admin.GET("/config", adminHandler.GetConfig)
+			admin.PATCH("/config", adminHandler.UpdateConfig)
+			admin.POST("/config/reload", adminHandler.ReloadConfig)
+		}
+	}
+
+	return router, nil
+}
+
+func ginLogger(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+		query := c.Request.URL.RawQuery
+
+		c.Next()
+
+		latency := time.Since(start)
+		logger.Info("HTTP request",
+			zap.Int("status", c.Writer.Status()),
+			zap.String("method", c.Request.Method),
+			zap.String("path", path),
+			zap.String("query", query),
+			zap.String("ip", c.ClientIP()),
+			zap.Duration("latency", latency),
+		)
+	}
+}
+
+func corsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
+
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(204)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,354 @@
+// Package server runs the email finder HTTP and gRPC servers with every
+// component built from the configuration
+package server
+
+import (
+	"context"
+	"email-finder/config"
+	"email-finder/internal/app"
+	"email-finder/internal/domains"
+	"email-finder/internal/grpcapi"
+	"email-finder/internal/handler"
+	"email-finder/internal/history"
+	"email-finder/internal/jobs"
+	"email-finder/internal/metrics"
+	"email-finder/internal/reconfig"
+	"email-finder/internal/webhook"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+)
+
+// Run serves the API until SIGINT or SIGTERM, then drains in-flight work. The
+// log level is changed through logLevel, and load re-reads the configuration
+// on SIGHUP.
+func Run(cfg *config.Config, logLevel zap.AtomicLevel, load func() (*config.Config, error), logger *zap.Logger) error {
+	logger.Info("starting email finder service",
+		zap.String("host", cfg.Server.Host),
+		zap.String("port", cfg.Server.Port),
+	)
+
+	// Build the verification stack
+	components, err := app.New(cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to initialize email finder: %w", err)
+	}
+	defer components.Close()
+	workerPool := components.WorkerPool
+	metrics.RegisterGaugeFunc("verification_queue_depth", "Verifications waiting for a worker.", func() float64 {
+		return float64(workerPool.Stats().Queued)
+	})
+	metrics.RegisterGaugeFunc("verification_workers_active", "Workers currently running a verification.", func() float64 {
+		return float64(workerPool.Stats().Active)
+	})
+	// Initialize handler
+	emailHandler := handler.NewEmailHandler(components.Service, logger)
+	emailHandler.SetRetryAfter(cfg.EmailVerification.BreakerOpenTimeout)
+	statsHandler := handler.NewStatsHandler(workerPool)
+
+	// What lookups learn about a domain is kept, so later lookups don't derive
+	// it again
+	domainStore, err := openDomainStore(cfg.Domains.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open domain store: %w", err)
+	}
+	defer domainStore.Close()
+	stores := []probedStore{{name: "domains", store: domainStore}}
+	profiles := domains.NewProfiles(domainStore, domains.Config{
+		DNSTTL: cfg.Domains.DNSTTL,
+		Buffer: cfg.Domains.Buffer,
+	}, logger)
+	components.Resolver.SetProfiles(profiles)
+	components.Service.SetDomainProfiles(profiles)
+	domainHandler := handler.NewDomainHandler(profiles, logger)
+
+	// Every lookup is recorded, so past results can be looked up and explained
+	var recorder *history.Recorder
+	var historyHandler *handler.HistoryHandler
+	if cfg.History.Enabled {
+		historyStore, err := openHistoryStore(cfg.History.Path)
+		if err != nil {
+			return fmt.Errorf("failed to open history store: %w", err)
+		}
+		defer historyStore.Close()
+		stores = append(stores, probedStore{name: "history", store: historyStore})
+		recorder = history.NewRecorder(historyStore, history.Config{
+			Retention:     cfg.History.Retention,
+			PurgeInterval: cfg.History.PurgeInterval,
+			Buffer:        cfg.History.Buffer,
+		}, logger)
+		components.Service.SetRecorder(recorder)
+		historyHandler = handler.NewHistoryHandler(recorder, logger)
+	}
+
+	// Lookups with a callback_url run in the background and POST their result
+	// when done; they need a secret to sign the callbacks
+	var webhooks *webhook.Dispatcher
+	var webhookHandler *handler.WebhookHandler
+	if cfg.Webhook.Secret != "" {
+		webhookStore, err := openWebhookStore(cfg.Webhook.Path)
+		if err != nil {
+			return fmt.Errorf("failed to open webhook store: %w", err)
+		}
+		defer webhookStore.Close()
+		stores = append(stores, probedStore{name: "webhooks", critical: true, store: webhookStore})
+		allowed := make([]netip.Prefix, 0, len(cfg.Webhook.AllowedNetworks))
+		for _, network := range cfg.Webhook.AllowedNetworks {
+			allowed = append(allowed, netip.MustParsePrefix(network))
+		}
+		webhooks = webhook.NewDispatcher(webhook.Config{
+			Secret:          cfg.Webhook.Secret,
+			MaxAttempts:     cfg.Webhook.MaxAttempts,
+			BaseDelay:       cfg.Webhook.BaseDelay,
+			MaxDelay:        cfg.Webhook.MaxDelay,
+			Timeout:         cfg.Webhook.Timeout,
+			History:         cfg.Webhook.History,
+			AllowedNetworks: allowed,
+		}, webhookStore, logger)
+		if err := webhooks.Start(); err != nil {
+			return fmt.Errorf("failed to start webhook deliveries: %w", err)
+		}
+		emailHandler.SetWebhooks(webhooks)
+		webhookHandler = handler.NewWebhookHandler(webhooks, logger)
+	}
+
+	// Bulk enrichment jobs record every row in the job store, so a restart
+	// resumes them without looking up finished rows again
+	jobStore, err := openJobStore(cfg.Jobs.Path)
+	if err != nil {
+		return fmt.Errorf("failed to open job store: %w", err)
+	}
+	defer jobStore.Close()
+	stores = append(stores, probedStore{name: "jobs", critical: true, store: jobStore})
+	jobQueue := jobs.NewQueue(jobStore, components.Service, jobs.Config{
+		Workers:     cfg.Jobs.Workers,
+		MaxRows:     cfg.Jobs.MaxRows,
+		MaxAttempts: cfg.Jobs.MaxAttempts,
+		RetryDelay:  cfg.Jobs.RetryDelay,
+	}, logger)
+	if webhooks != nil {
+		jobQueue.SetWebhooks(webhooks)
+	}
+	if err := jobQueue.Start(); err != nil {
+		return fmt.Errorf("failed to start job queue: %w", err)
+	}
+	metrics.RegisterGaugeFunc("job_rows_queued", "Job rows waiting for a worker.", func() float64 {
+		return float64(jobQueue.Queued())
+	})
+	jobHandler := handler.NewJobHandler(jobQueue, logger)
+	emailHandler.SetJobs(jobQueue)
+
+	healthChecker := buildHealthChecker(cfg, components, stores)
+	healthHandler := handler.NewHealthHandler(healthChecker)
+
+	rateLimiter := handler.NewRateLimiter(cfg.RateLimit)
+
+	// Safe-to-change settings are applied live on SIGHUP and through the admin API
+	reconfigurer := reconfig.New(cfg, reconfig.Targets{
+		Level:       logLevel,
+		Pool:        workerPool,
+		Timeouts:    components.TimeoutSetters(),
+		Service:     components.Service,
+		Throttler:   components.Throttler,
+		RateLimiter: rateLimiter,
+	}, load, logger)
+	var adminHandler *handler.AdminHandler
+	if cfg.Admin.Token != "" {
+		adminHandler = handler.NewAdminHandler(reconfigurer, cfg.Admin.Token)
+	}
+
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+	go func() {
+		for range hangup {
+			logger.Info("reloading configuration", zap.String("signal", "SIGHUP"))
+			reconfigurer.Reload("sighup")
+		}
+	}()
+
+	// Setup router
+	router, err := setupRouter(emailHandler, statsHandler, healthHandler, adminHandler, webhookHandler, jobHandler, historyHandler, domainHandler, rateLimiter, logger, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Requests derive their context from requestCtx, so cancelling it stops every
+	// outstanding verification, including running CLI processes
+	requestCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
+	// Start server
+	addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
+	server := &http.Server{
+		Addr:        addr,
+		Handler:     router,
+		BaseContext: func(net.Listener) context.Context { return requestCtx },
+	}
+
+	serverErr := make(chan error, 2)
+	go func() {
+		logger.Info("server starting", zap.String("address", addr))
+		serverErr <- server.ListenAndServe()
+	}()
+
+	// The gRPC API is served from the same process and service as the REST API
+	var grpcServer *grpc.Server
+	var grpcHealth *grpchealth.Server
+	if cfg.Server.GRPCPort != "" {
+		grpcAddr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.GRPCPort)
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen for gRPC on %s: %w", grpcAddr, err)
+		}
+		grpcServer, grpcHealth = grpcapi.NewGRPCServer(components.Service, logger)
+		go func() {
+			logger.Info("gRPC server starting", zap.String("address", grpcAddr))
+			serverErr <- grpcServer.Serve(listener)
+		}()
+	}
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case err := <-serverErr:
+		return fmt.Errorf("server failed to start: %w", err)
+	case sig := <-quit:
+		logger.Info("shutting down",
+			zap.String("signal", sig.String()),
+			zap.Duration("drain_timeout", cfg.Server.ShutdownTimeout),
+		)
+	}
+
+	// Stop receiving new traffic, then let in-flight requests finish
+	healthChecker.SetNotReady()
+	if grpcHealth != nil {
+		grpcHealth.Shutdown()
+	}
+	if cfg.Server.ReadinessDelay > 0 {
+		logger.Info("waiting for load balancers to observe readiness",
+			zap.Duration("delay", cfg.Server.ReadinessDelay),
+		)
+		time.Sleep(cfg.Server.ReadinessDelay)
+	}
+
+	// Everything drains within one shutdown timeout
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancelDrain()
+
+	// gRPC calls, jobs and background lookups drain alongside HTTP requests
+	var drained sync.WaitGroup
+	if grpcServer != nil {
+		drained.Add(1)
+		go func() {
+			defer drained.Done()
+			shutdownGRPC(drainCtx, grpcServer, logger)
+		}()
+	}
+	drained.Add(1)
+	go func() {
+		defer drained.Done()
+		// Jobs completing while they drain still send their callbacks
+		shutdownJobs(drainCtx, jobQueue, logger)
+		if webhooks != nil {
+			shutdownWebhooks(drainCtx, webhooks, logger)
+		}
+	}()
+	shutdown(drainCtx, server, cancelRequests, logger)
+	drained.Wait()
+
+	// Every lookup has finished, so its record and what it learned are queued
+	if recorder != nil {
+		shutdownHistory(drainCtx, recorder, logger)
+	}
+	shutdownDomains(drainCtx, profiles, logger)
+	return nil
+}
+
+// shutdownGRPC stops accepting gRPC calls and waits until ctx is done for
+// in-flight calls and streams, then cancels the rest
+func shutdownGRPC(ctx context.Context, server *grpc.Server, logger *zap.Logger) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		logger.Info("all in-flight gRPC calls finished")
+	case <-ctx.Done():
+		logger.Warn("drain timeout exceeded, cancelling in-flight gRPC calls")
+		server.Stop()
+		<-stopped
+	}
+}
+
+// shutdown stops accepting connections and waits until ctx is done for in-flight
+// requests. Requests still running after that have their verifications cancelled.
+func shutdown(ctx context.Context, server *http.Server, cancelRequests context.CancelFunc, logger *zap.Logger) {
+	if err := server.Shutdown(ctx); err == nil {
+		logger.Info("all in-flight requests finished")
+		return
+	}
+
+	logger.Warn("drain timeout exceeded, cancelling in-flight verifications")
+	cancelRequests()
+
+	// Give the cancelled requests a moment to write their responses
+	graceCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := server.Shutdown(graceCtx); err != nil {
+		logger.Warn("closing remaining connections", zap.Error(err))
+		server.Close()
+	}
+}
+
+// shutdownWebhooks stops accepting lookups with a callback and waits until ctx
+// is done for running ones and their deliveries, then cancels the rest
+func shutdownWebhooks(ctx context.Context, webhooks *webhook.Dispatcher, logger *zap.Logger) {
+	if err := webhooks.Close(ctx); err != nil {
+		logger.Warn("drain timeout exceeded, cancelling background lookups and webhook deliveries")
+		return
+	}
+	logger.Info("all background lookups and webhook deliveries finished")
+}
+
+// shutdownJobs stops starting job rows and waits until ctx is done for the rows
+// in progress, then cancels them. Unfinished rows are resumed on the next start.
+func shutdownJobs(ctx context.Context, queue *jobs.Queue, logger *zap.Logger) {
+	if err := queue.Close(ctx); err != nil {
+		logger.Warn("drain timeout exceeded, cancelling job rows in progress")
+		return
+	}
+	logger.Info("all job rows in progress finished")
+}
+
+// shutdownHistory waits until ctx is done for the queued lookup records to be written
+func shutdownHistory(ctx context.Context, recorder *history.Recorder, logger *zap.Logger) {
+	if err := recorder.Close(ctx); err != nil {
+		logger.Warn("drain timeout exceeded, dropping unwritten lookup records")
+		return
+	}
+	logger.Info("all lookup records written")
+}
+
+// shutdownDomains waits until ctx is done for the queued domain profile updates to be written
+func shutdownDomains(ctx context.Context, profiles *domains.Profiles, logger *zap.Logger) {
+	if err := profiles.Close(ctx); err != nil {
+		logger.Warn("drain timeout exceeded, dropping unwritten domain profile updates")
+		return
+	}
+	logger.Info("all domain profile updates written")
+}
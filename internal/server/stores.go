@@ -0,0 +1,44 @@
+package server
+
+import (
+	"email-finder/internal/domains"
+	"email-finder/internal/history"
+	"email-finder/internal/jobs"
+	"email-finder/internal/webhook"
+)
+
+// openHistoryStore opens the history database at path, or keeps records in
+// memory when path is empty
+func openHistoryStore(path string) (history.Store, error) {
+	if path == "" {
+		return history.NewMemoryStore(), nil
+	}
+	return history.OpenBoltStore(path)
+}
+
+// openDomainStore opens the domain database at path, or keeps profiles in
+// memory when path is empty
+func openDomainStore(path string) (domains.Store, error) {
+	if path == "" {
+		return domains.NewMemoryStore(), nil
+	}
+	return domains.OpenBoltStore(path)
+}
+
+// openJobStore opens the job database at path, or keeps jobs in memory when
+// path is empty
+func openJobStore(path string) (jobs.Store, error) {
+	if path == "" {
+		return jobs.NewMemoryStore(), nil
+	}
+	return jobs.OpenBoltStore(path)
+}
+
+// openWebhookStore opens the webhook database at path, or keeps deliveries in
+// memory when path is empty
+func openWebhookStore(path string) (webhook.Store, error) {
+	if path == "" {
+		return webhook.NewMemoryStore(), nil
+	}
+	return webhook.OpenBoltStore(path)
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"email-finder/internal/verifier"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// MaxBulkRequests is the most lookups a single bulk request may contain
+const MaxBulkRequests = 1000
+
+// bulkParallelism is how many lookups of a bulk request run at once. Their
+// verifications still share the worker pool with every other request.
+const bulkParallelism = 10
+
+// MaxSyncBulkRequests is the most lookups a bulk request without a callback URL
+// may contain over HTTP, so the caller waits for a single round of lookups.
+// Larger batches are queued as a job.
+const MaxSyncBulkRequests = bulkParallelism
+
+// BulkFindRequest represents several lookups submitted together
+type BulkFindRequest struct {
+	Requests []FindEmailRequest `json:"requests" binding:"required"`
+
+	// CallbackURL makes the bulk request asynchronous: the response is POSTed
+	// to it once every lookup has finished
+	CallbackURL string `json:"callback_url,omitempty"`
+}
+
+// BulkFindResult is the outcome of one lookup of a bulk request
+type BulkFindResult struct {
+	// Index is the position of the lookup in the request
+	Index  int                `json:"index"`
+	Result *FindEmailResponse `json:"result,omitempty"`
+	Error  string             `json:"error,omitempty"`
+}
+
+// BulkFindResponse holds one result per lookup, in request order
+type BulkFindResponse struct {
+	Results []BulkFindResult `json:"results"`
+	Total   int              `json:"total"`
+	Failed  int              `json:"failed"`
+}
+
+// Validate checks every lookup of the request
+func (r BulkFindRequest) Validate() error {
+	return ValidateBatch(r.Requests, r.CallbackURL, MaxBulkRequests)
+}
+
+// ValidateBatch checks a batch of up to maxRequests lookups whose results are
+// delivered together to callbackURL, if any
+func ValidateBatch(requests []FindEmailRequest, callbackURL string, maxRequests int) error {
+	if len(requests) == 0 {
+		return fmt.Errorf("%w: at least one request is required", ErrInvalidRequest)
+	}
+	if len(requests) > maxRequests {
+		return fmt.Errorf("%w: at most %d requests can be submitted at once", ErrInvalidRequest, maxRequests)
+	}
+	for i, req := range requests {
+		if req.CallbackURL != "" {
+			return fmt.Errorf("%w: requests[%d]: callback_url is only accepted for the whole batch", ErrInvalidRequest, i)
+		}
+		if err := req.Validate(); err != nil {
+			return fmt.Errorf("requests[%d]: %w", i, err)
+		}
+	}
+	return validateCallbackURL(callbackURL)
+}
+
+// FindEmailsBulk runs every lookup of req. A failed lookup is reported in its
+// result and doesn't stop the others. Verifications are queued at bulk priority,
+// behind interactive lookups. If every lookup failed because the verification
+// backend is unavailable, verifier.ErrCircuitOpen is returned instead.
+func (s *EmailFinderService) FindEmailsBulk(ctx context.Context, req BulkFindRequest) (*BulkFindResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	ctx = verifier.WithPriority(ctx, verifier.PriorityBulk)
+
+	results := make([]BulkFindResult, len(req.Requests))
+	var circuitOpen atomic.Int64
+	sem := make(chan struct{}, bulkParallelism)
+	var wg sync.WaitGroup
+	for i := range req.Requests {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			results[i].Index = i
+			result, err := s.FindEmails(ctx, req.Requests[i])
+			if err != nil {
+				if errors.Is(err, verifier.ErrCircuitOpen) {
+					circuitOpen.Add(1)
+				}
+				results[i].Error = err.Error()
+				return
+			}
+			results[i].Result = result
+		}(i)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if int(circuitOpen.Load()) == len(results) {
+		return nil, verifier.ErrCircuitOpen
+	}
+
+	resp := &BulkFindResponse{Results: results, Total: len(results)}
+	for _, r := range results {
+		if r.Error != "" {
+			resp.Failed++
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"email-finder/internal/verifier"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestBulkFindRequest_Validate(t *testing.T) {
+	valid := FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Acme"}
+
+	tests := []struct {
+		name    string
+		req     BulkFindRequest
+		wantErr string
+	}{
+		{
+			name: "valid with callback",
+			req:  BulkFindRequest{Requests: []FindEmailRequest{valid}, CallbackURL: "https://hooks.example.org/bulk"},
+		},
+		{
+			name:    "empty",
+			req:     BulkFindRequest{},
+			wantErr: "at least one request",
+		},
+		{
+			name:    "invalid row is reported with its index",
+			req:     BulkFindRequest{Requests: []FindEmailRequest{valid, {FirstName: "Jane"}}},
+			wantErr: "requests[1]",
+		},
+		{
+			name: "callback on a row",
+			req: BulkFindRequest{Requests: []FindEmailRequest{
+				{FirstName: "John", LastName: "Doe", Company: "Acme", CallbackURL: "https://hooks.example.org"},
+			}},
+			wantErr: "only accepted for the whole batch",
+		},
+		{
+			name:    "callback is not http",
+			req:     BulkFindRequest{Requests: []FindEmailRequest{valid}, CallbackURL: "ftp://hooks.example.org"},
+			wantErr: "callback_url",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidRequest) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want ErrInvalidRequest mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// unavailableVerifier fails the checks of a person's addresses as if its
+// circuit breaker were open, unless they contain available
+type unavailableVerifier struct {
+	available string
+}
+
+func (v unavailableVerifier) VerifyEmail(ctx context.Context, email string) (*verifier.VerificationResult, error) {
+	results, err := v.VerifyEmailsBatch(ctx, []string{email})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (v unavailableVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*verifier.VerificationResult, error) {
+	results := make([]*verifier.VerificationResult, len(emails))
+	for i, email := range emails {
+		if v.available == "" || !strings.Contains(email, v.available) {
+			return nil, verifier.ErrCircuitOpen
+		}
+		results[i] = &verifier.VerificationResult{Email: email, IsReachable: "invalid"}
+	}
+	return results, nil
+}
+
+func TestFindEmailsBulk_BackendUnavailable(t *testing.T) {
+	req := BulkFindRequest{Requests: []FindEmailRequest{
+		{FirstName: "John", LastName: "Doe", Company: "Example"},
+		{FirstName: "Jane", LastName: "Roe", Company: "Example"},
+	}}
+
+	s := NewEmailFinderService(unavailableVerifier{}, staticResolver{}, zap.NewNop(), 3)
+	if _, err := s.FindEmailsBulk(context.Background(), req); !errors.Is(err, verifier.ErrCircuitOpen) {
+		t.Errorf("FindEmailsBulk() error = %v, want ErrCircuitOpen when every lookup failed with it", err)
+	}
+
+	// While some lookups succeed, the others are only reported as failed
+	s = NewEmailFinderService(unavailableVerifier{available: "roe"}, staticResolver{}, zap.NewNop(), 3)
+	resp, err := s.FindEmailsBulk(context.Background(), req)
+	if err != nil {
+		t.Fatalf("FindEmailsBulk() error = %v", err)
+	}
+	if resp.Failed != 1 || resp.Results[0].Error == "" || resp.Results[1].Result == nil {
+		t.Errorf("FindEmailsBulk() = %+v, want only the first lookup failed", resp)
+	}
+}
@@ -0,0 +1,41 @@
+package service
+
+import "email-finder/internal/verifier"
+
+// DomainProfiles keeps what lookups learn about a domain
+type DomainProfiles interface {
+	// ObserveVerifications records the verification results of a lookup at
+	// the domain; pattern is that of the only address found deliverable, if any
+	ObserveVerifications(domain string, results []*verifier.VerificationResult, pattern string)
+	// ObservePatterns records the patterns inferred from sample addresses
+	ObservePatterns(domain string, patterns []string)
+	// LearnedPatterns returns the patterns recorded by ObservePatterns
+	LearnedPatterns(domain string) []string
+}
+
+// SetDomainProfiles makes lookups record what they learn about domains in p,
+// and keeps the patterns inferred from sample addresses there. It must be
+// called before the service is used.
+func (s *EmailFinderService) SetDomainProfiles(p DomainProfiles) {
+	s.profiles = p
+}
+
+// observeDomain records what the verification results of a lookup tell about its domain
+func (s *EmailFinderService) observeDomain(domain string, results []*verifier.VerificationResult, emailToPattern map[string]string) {
+	if s.profiles == nil {
+		return
+	}
+
+	// Several deliverable addresses are aliases, which tell nothing about the pattern
+	pattern, deliverable := "", 0
+	for _, result := range results {
+		if result.IsReachable == "safe" {
+			pattern = emailToPattern[result.Email]
+			deliverable++
+		}
+	}
+	if deliverable != 1 {
+		pattern = ""
+	}
+	s.profiles.ObserveVerifications(domain, results, pattern)
+}
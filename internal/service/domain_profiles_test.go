@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"email-finder/internal/generator"
+	"email-finder/internal/verifier"
+	"slices"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// oneSafeVerifier reports only safeEmail as deliverable
+type oneSafeVerifier struct {
+	safeEmail string
+}
+
+func (v oneSafeVerifier) VerifyEmail(ctx context.Context, email string) (*verifier.VerificationResult, error) {
+	results, _ := v.VerifyEmailsBatch(ctx, []string{email})
+	return results[0], nil
+}
+
+func (v oneSafeVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*verifier.VerificationResult, error) {
+	results := make([]*verifier.VerificationResult, len(emails))
+	for i, email := range emails {
+		results[i] = &verifier.VerificationResult{Email: email, IsReachable: "invalid"}
+		if email == v.safeEmail {
+			results[i] = &verifier.VerificationResult{Email: email, IsReachable: "safe", IsValid: true, IsDeliverable: true}
+		}
+	}
+	return results, nil
+}
+
+// fakeProfiles records what the service reports about domains
+type fakeProfiles struct {
+	observed map[string]string
+	patterns map[string][]string
+}
+
+func (p *fakeProfiles) ObserveVerifications(domain string, results []*verifier.VerificationResult, pattern string) {
+	p.observed[domain] = pattern
+}
+
+func (p *fakeProfiles) ObservePatterns(domain string, patterns []string) {
+	p.patterns[domain] = patterns
+}
+
+func (p *fakeProfiles) LearnedPatterns(domain string) []string {
+	return p.patterns[domain]
+}
+
+func TestDomainProfiles(t *testing.T) {
+	profiles := &fakeProfiles{
+		observed: make(map[string]string),
+		patterns: map[string][]string{"example.org": {"flastname"}},
+	}
+	s := NewEmailFinderService(oneSafeVerifier{safeEmail: "jdoe@example.org"}, staticResolver{}, zap.NewNop(), 0)
+	s.SetDomainProfiles(profiles)
+
+	// Patterns remembered by the profiles are used, e.g. after a restart
+	resp, err := s.FindEmails(context.Background(), FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Acme"})
+	if err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if !slices.Equal(resp.InferredPatterns, []string{"flastname"}) || resp.TotalChecked != 1 {
+		t.Errorf("lookup tried %d addresses with patterns %v, want only flastname", resp.TotalChecked, resp.InferredPatterns)
+	}
+	if pattern, ok := profiles.observed["example.org"]; !ok || pattern != "flastname" {
+		t.Errorf("observed pattern = %q, %v, want flastname", pattern, ok)
+	}
+
+	// Newly inferred patterns are handed to the profiles
+	_, err = s.InferPattern(InferPatternRequest{Samples: []generator.SampleAddress{{Email: "john.doe@globex.com", FirstName: "John", LastName: "Doe"}}})
+	if err != nil {
+		t.Fatalf("InferPattern() error = %v", err)
+	}
+	if got := profiles.patterns["globex.com"]; !slices.Contains(got, "firstname.lastname") {
+		t.Errorf("patterns recorded for globex.com = %v, want firstname.lastname", got)
+	}
+}
+
+func TestDomainProfiles_ObservesRecheckedResults(t *testing.T) {
+	profiles := &fakeProfiles{observed: make(map[string]string), patterns: make(map[string][]string)}
+	v := &statusVerifier{statuses: map[string]string{
+		"john.doe@example.org": "unknown",
+		"johndoe@example.org":  "invalid",
+		"j.doe@example.org":    "invalid",
+	}}
+	s := newCachedService(v, time.Hour)
+	s.SetDomainProfiles(profiles)
+	req := FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Acme"}
+
+	if _, err := s.FindEmails(context.Background(), req); err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if pattern := profiles.observed["example.org"]; pattern != "" {
+		t.Fatalf("observed pattern = %q, want none while the address is unknown", pattern)
+	}
+
+	// The cached unknown result is checked again and now tells the pattern
+	v.mu.Lock()
+	v.statuses["john.doe@example.org"] = "safe"
+	v.mu.Unlock()
+
+	resp, err := s.FindEmails(context.Background(), req)
+	if err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if resp.CachedAt == nil {
+		t.Fatal("second lookup was not served from the cache")
+	}
+	if pattern := profiles.observed["example.org"]; pattern != "firstname.lastname" {
+		t.Errorf("observed pattern = %q, want firstname.lastname from the rechecked result", pattern)
+	}
+}
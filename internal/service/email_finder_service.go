@@ -1,29 +1,70 @@
 package service
 
 import (
+	"context"
 	"email-finder/internal/generator"
+	"email-finder/internal/metrics"
 	"email-finder/internal/resolver"
 	"email-finder/internal/verifier"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+var tracer = otel.Tracer("email-finder/internal/service")
+
+// DomainResolver finds the email domain of a company
+type DomainResolver interface {
+	ResolveDomain(ctx context.Context, companyName string) *resolver.DomainResult
+}
+
+// CompanyDomainRecorder is implemented by domain resolvers that can remember
+// the domain of a company learned from sample addresses
+type CompanyDomainRecorder interface {
+	AddCompanyDomain(companyName, domain string)
+}
+
 // EmailFinderService handles the core business logic for finding emails
 type EmailFinderService struct {
 	verifier       verifier.Verifier
-	domainResolver *resolver.DomainResolver
+	domainResolver DomainResolver
 	logger         *zap.Logger
-	maxPatterns    int
+	maxPatterns    atomic.Int64 // changeable at runtime
+	recorder       Recorder
+	lookups        *LookupCache
+	profiles       DomainProfiles
+
+	// learnedPatterns maps a domain to the patterns inferred from known addresses
+	learnedPatterns map[string][]string
+	patternsMutex   sync.RWMutex
 }
 
 // NewEmailFinderService creates a new email finder service
-func NewEmailFinderService(v verifier.Verifier, dr *resolver.DomainResolver, logger *zap.Logger, maxPatterns int) *EmailFinderService {
-	return &EmailFinderService{
+func NewEmailFinderService(v verifier.Verifier, dr DomainResolver, logger *zap.Logger, maxPatterns int) *EmailFinderService {
+	s := &EmailFinderService{
 		verifier:       v,
 		domainResolver: dr,
 		logger:         logger,
-		maxPatterns:    maxPatterns,
+
+		learnedPatterns: make(map[string][]string),
 	}
+	s.maxPatterns.Store(int64(maxPatterns))
+	return s
+}
+
+// SetMaxPatterns changes the maximum number of patterns verified per lookup; 0 means no limit
+func (s *EmailFinderService) SetMaxPatterns(maxPatterns int) {
+	s.maxPatterns.Store(int64(maxPatterns))
 }
 
 // FindEmailRequest represents the input for finding emails
@@ -31,8 +72,27 @@ type FindEmailRequest struct {
 	FirstName string `json:"first_name" binding:"required"`
 	LastName  string `json:"last_name" binding:"required"`
 	Company   string `json:"company" binding:"required"`
+
+	// SkipVerification returns the address built from the domain's inferred pattern
+	// without SMTP verification. It has no effect when no pattern has been inferred.
+	SkipVerification bool `json:"skip_verification,omitempty"`
+
+	// Include adds results with these is_reachable statuses to found_emails,
+	// on top of safe and deliverable risky ones: "risky", "unknown", "invalid"
+	Include []string `json:"include,omitempty"`
+
+	// CallbackURL makes the lookup asynchronous: the response is POSTed to it
+	// once the lookup finishes
+	CallbackURL string `json:"callback_url,omitempty"`
+
+	// Refresh verifies the addresses again instead of reusing the results of a
+	// recent lookup of the same person at the same domain
+	Refresh bool `json:"refresh,omitempty"`
 }
 
+// IncludableStatuses are the is_reachable values accepted in FindEmailRequest.Include
+var IncludableStatuses = []string{"risky", "unknown", "invalid"}
+
 // EmailResult represents a found email with verification details
 type EmailResult struct {
 	Email         string `json:"email"`
@@ -40,7 +100,22 @@ type EmailResult struct {
 	IsReachable   string `json:"is_reachable"`
 	IsValid       bool   `json:"is_valid"`
 	IsDeliverable bool   `json:"is_deliverable"`
-	Confidence    string `json:"confidence"` // high, medium, low
+	Confidence    string `json:"confidence"` // high, medium, low (derived from Score)
+
+	// Score is the probability-like confidence in the email, 0-100
+	Score          int            `json:"score"`
+	ScoreBreakdown ScoreBreakdown `json:"score_breakdown"`
+
+	// Verifier details, reported as-is from check-if-email-exists
+	MXRecords      []string             `json:"mx_records,omitempty"`
+	CanConnectSMTP bool                 `json:"can_connect_smtp"`
+	IsCatchAll     bool                 `json:"is_catch_all"`
+	IsDisabled     bool                 `json:"is_disabled"`
+	HasFullInbox   bool                 `json:"has_full_inbox"`
+	IsDisposable   bool                 `json:"is_disposable"`
+	IsRoleAccount  bool                 `json:"is_role_account"`
+	SMTPError      *verifier.CheckError `json:"smtp_error,omitempty"`
+	MXError        *verifier.CheckError `json:"mx_error,omitempty"`
 }
 
 // FindEmailResponse represents the response from finding emails
@@ -51,10 +126,68 @@ type FindEmailResponse struct {
 	Domain         string           `json:"domain"`
 	DomainResolved bool             `json:"domain_resolved"`
 	Request        FindEmailRequest `json:"request"`
+
+	InferredPatterns []string `json:"inferred_patterns,omitempty"`
+	Verified         bool     `json:"verified"`
+
+	// StatusBreakdown counts every checked email by its is_reachable status
+	StatusBreakdown map[string]int `json:"status_breakdown"`
+	// BestGuess is the most likely address when no email could be verified
+	BestGuess *EmailResult `json:"best_guess,omitempty"`
+
+	// CachedAt is when the addresses were verified, if the results of an
+	// earlier lookup were reused
+	CachedAt *time.Time `json:"cached_at,omitempty"`
+}
+
+// Validate checks that the request has the required fields and known include statuses
+func (r FindEmailRequest) Validate() error {
+	if strings.TrimSpace(r.FirstName) == "" || strings.TrimSpace(r.LastName) == "" || strings.TrimSpace(r.Company) == "" {
+		return fmt.Errorf("%w: first_name, last_name and company are required", ErrInvalidRequest)
+	}
+	for _, status := range r.Include {
+		if !slices.Contains(IncludableStatuses, status) {
+			return fmt.Errorf("%w: include only accepts risky, unknown and invalid, got %q", ErrInvalidRequest, status)
+		}
+	}
+	return validateCallbackURL(r.CallbackURL)
+}
+
+// validateCallbackURL checks that a callback URL, if any, is an absolute http(s) URL
+func validateCallbackURL(callbackURL string) error {
+	if callbackURL == "" {
+		return nil
+	}
+	u, err := url.Parse(callbackURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: callback_url must be an absolute http or https URL", ErrInvalidRequest)
+	}
+	return nil
 }
 
 // FindEmails finds and verifies emails based on the input
-func (s *EmailFinderService) FindEmails(req FindEmailRequest) (*FindEmailResponse, error) {
+func (s *EmailFinderService) FindEmails(ctx context.Context, req FindEmailRequest) (*FindEmailResponse, error) {
+	return s.findEmails(ctx, req, nil)
+}
+
+// FindEmailsStream is FindEmails, calling onResult with each verification result
+// as soon as it is final. onResult is never called concurrently.
+func (s *EmailFinderService) FindEmailsStream(ctx context.Context, req FindEmailRequest, onResult func(*verifier.VerificationResult)) (*FindEmailResponse, error) {
+	return s.findEmails(ctx, req, onResult)
+}
+
+func (s *EmailFinderService) findEmails(ctx context.Context, req FindEmailRequest, onResult func(*verifier.VerificationResult)) (resp *FindEmailResponse, err error) {
+	ctx, span := tracer.Start(ctx, "service.FindEmails")
+	defer span.End()
+
+	rec := &LookupRecord{
+		Request:       req,
+		Patterns:      []PatternTried{},
+		Verifications: []*verifier.VerificationResult{},
+		StartedAt:     time.Now().UTC(),
+	}
+	defer func() { s.record(rec, resp, err) }()
+
 	s.logger.Info("finding emails",
 		zap.String("first_name", req.FirstName),
 		zap.String("last_name", req.LastName),
@@ -62,8 +195,15 @@ func (s *EmailFinderService) FindEmails(req FindEmailRequest) (*FindEmailRespons
 	)
 
 	// Resolve domain from company name
-	domainResult := s.domainResolver.ResolveDomain(req.Company)
+	enterStage(ctx, StageResolving)
+	domainResult := s.domainResolver.ResolveDomain(ctx, req.Company)
+	metrics.ObserveResolution(domainResult.Method)
 	domain := domainResult.Domain
+	rec.Domain, rec.DomainMethod, rec.DomainResolved = domain, domainResult.Method, domainResult.Resolved
+	span.SetAttributes(
+		attribute.String("domain", domain),
+		attribute.String("domain_method", domainResult.Method),
+	)
 
 	if !domainResult.Resolved || domain == "" {
 		s.logger.Warn("failed to resolve domain",
@@ -76,6 +216,8 @@ func (s *EmailFinderService) FindEmails(req FindEmailRequest) (*FindEmailRespons
 			Domain:         "",
 			DomainResolved: false,
 			Request:        req,
+
+			StatusBreakdown: map[string]int{},
 		}, nil
 	}
 
@@ -86,14 +228,30 @@ func (s *EmailFinderService) FindEmails(req FindEmailRequest) (*FindEmailRespons
 	)
 
 	// Generate email patterns using resolved domain
+	_, genSpan := tracer.Start(ctx, "generator.GenerateEmailPatterns")
 	patterns := generator.GenerateEmailPatterns(req.FirstName, req.LastName, domain)
 
 	// Patterns are already generated in priority order (base patterns first, then numbered)
 	// This ensures common patterns are verified first, improving perceived latency
 
+	// Only try the patterns inferred for this domain from known addresses
+	learned := s.LearnedPatterns(domain)
+	if len(learned) > 0 {
+		patterns = generator.FilterPatterns(patterns, learned)
+		s.logger.Info("using inferred patterns",
+			zap.String("domain", domain),
+			zap.Strings("patterns", learned),
+		)
+	}
+
 	// Limit the number of patterns if configured
-	if s.maxPatterns > 0 && len(patterns) > s.maxPatterns {
-		patterns = patterns[:s.maxPatterns]
+	if maxPatterns := int(s.maxPatterns.Load()); maxPatterns > 0 && len(patterns) > maxPatterns {
+		patterns = patterns[:maxPatterns]
+	}
+	genSpan.SetAttributes(attribute.Int("patterns", len(patterns)))
+	genSpan.End()
+	for _, pattern := range patterns {
+		rec.Patterns = append(rec.Patterns, PatternTried{Email: pattern.Email, Pattern: pattern.Pattern})
 	}
 
 	if len(patterns) == 0 {
@@ -104,6 +262,30 @@ func (s *EmailFinderService) FindEmails(req FindEmailRequest) (*FindEmailRespons
 			Domain:         domain,
 			DomainResolved: true,
 			Request:        req,
+
+			InferredPatterns: learned,
+			StatusBreakdown:  map[string]int{},
+		}, nil
+	}
+
+	// The inferred pattern is trusted as-is when verification is skipped
+	if req.SkipVerification && len(learned) > 0 {
+		foundEmails := make([]EmailResult, 0, len(patterns))
+		for _, pattern := range patterns {
+			unverified := &verifier.VerificationResult{Email: pattern.Email, IsReachable: "unknown"}
+			foundEmails = append(foundEmails, s.buildEmailResult(unverified, pattern.Pattern, domainResult.Method, true))
+		}
+
+		return &FindEmailResponse{
+			FoundEmails:    foundEmails,
+			TotalChecked:   0,
+			TotalFound:     len(foundEmails),
+			Domain:         domain,
+			DomainResolved: true,
+			Request:        req,
+
+			InferredPatterns: learned,
+			StatusBreakdown:  map[string]int{},
 		}, nil
 	}
 
@@ -116,36 +298,66 @@ func (s *EmailFinderService) FindEmails(req FindEmailRequest) (*FindEmailRespons
 	}
 
 	// Verify emails
-	verificationResults, err := s.verifier.VerifyEmailsBatch(emails)
+	enterStage(ctx, StageVerifying)
+	verifyCtx, verifySpan := tracer.Start(ctx, "verifier.VerifyEmailsBatch",
+		trace.WithAttributes(attribute.Int("emails", len(emails))),
+	)
+	verificationResults, cachedAt, checked, err := s.verifyCandidates(verifyCtx, req, domain, emails, onResult)
 	if err != nil {
+		verifySpan.RecordError(err)
+		verifySpan.SetStatus(codes.Error, err.Error())
+		verifySpan.End()
 		s.logger.Error("failed to verify emails", zap.Error(err))
 		return nil, err
 	}
+	verifySpan.End()
+	rec.Verifications = verificationResults
+	if checked {
+		s.observeDomain(domain, verificationResults, emailToPattern)
+	}
+
+	include := make(map[string]bool, len(req.Include))
+	for _, status := range req.Include {
+		include[status] = true
+	}
 
 	// Process results and filter valid emails
-	// Only return emails that are verified and deliverable
+	// By default only return emails that are verified and deliverable
 	foundEmails := make([]EmailResult, 0)
+	candidates := make([]EmailResult, 0, len(verificationResults))
+	statusBreakdown := make(map[string]int)
+	verified := 0
 	for _, result := range verificationResults {
+		statusBreakdown[result.IsReachable]++
+		emailResult := s.buildEmailResult(result, emailToPattern[result.Email], domainResult.Method, len(learned) > 0)
+
 		// Only include emails that are verified (not unknown) and deliverable
-		if result.IsReachable != "unknown" && (result.IsReachable == "safe" || (result.IsReachable == "risky" && result.IsDeliverable)) {
-			confidence := s.calculateConfidence(result)
-			foundEmails = append(foundEmails, EmailResult{
-				Email:         result.Email,
-				Pattern:       emailToPattern[result.Email],
-				IsReachable:   result.IsReachable,
-				IsValid:       result.IsValid,
-				IsDeliverable: result.IsDeliverable,
-				Confidence:    confidence,
-			})
+		if result.IsReachable == "safe" || (result.IsReachable == "risky" && result.IsDeliverable) {
+			verified++
+			foundEmails = append(foundEmails, emailResult)
+		} else if include[result.IsReachable] {
+			foundEmails = append(foundEmails, emailResult)
+		}
+
+		if result.IsReachable != "invalid" {
+			candidates = append(candidates, emailResult)
 		}
 	}
 
-	// Sort by confidence (high to low)
-	foundEmails = s.sortByConfidence(foundEmails)
+	// Sort by score (high to low)
+	foundEmails = s.sortByScore(foundEmails)
+
+	// Nothing verified: fall back to the most likely address that wasn't rejected
+	var bestGuess *EmailResult
+	if verified == 0 && len(candidates) > 0 {
+		candidates = s.sortByScore(candidates)
+		bestGuess = &candidates[0]
+	}
 
 	s.logger.Info("email search completed",
 		zap.Int("total_checked", len(patterns)),
 		zap.Int("total_found", len(foundEmails)),
+		zap.Int("total_verified", verified),
 	)
 
 	return &FindEmailResponse{
@@ -155,45 +367,149 @@ func (s *EmailFinderService) FindEmails(req FindEmailRequest) (*FindEmailRespons
 		Domain:         domain,
 		DomainResolved: true,
 		Request:        req,
+
+		InferredPatterns: learned,
+		Verified:         true,
+		StatusBreakdown:  statusBreakdown,
+		BestGuess:        bestGuess,
+		CachedAt:         cachedAt,
 	}, nil
 }
 
-// calculateConfidence determines the confidence level for an email
-func (s *EmailFinderService) calculateConfidence(result *verifier.VerificationResult) string {
-	if result.IsReachable == "safe" && result.IsDeliverable {
-		return "high"
+// verifyCandidates verifies the candidate addresses of a lookup, reusing the
+// results of a recent lookup of the same person at the same domain unless
+// req.Refresh is set. Only the reused results that were unknown are checked
+// again. It returns when the reused results were verified, and whether any
+// address was checked by this lookup.
+func (s *EmailFinderService) verifyCandidates(ctx context.Context, req FindEmailRequest, domain string, emails []string, onResult func(*verifier.VerificationResult)) ([]*verifier.VerificationResult, *time.Time, bool, error) {
+	if s.lookups == nil {
+		results, err := s.verifyEmails(ctx, emails, onResult)
+		return results, nil, true, err
 	}
-	if result.IsReachable == "risky" && result.IsDeliverable {
-		return "medium"
+
+	key := lookupKey(req.FirstName, req.LastName, domain)
+	if !req.Refresh {
+		if entry, ok := s.lookups.get(key, emails); ok {
+			unknown := entry.unknown()
+			if onResult != nil {
+				for _, result := range entry.results {
+					if result.IsReachable != "unknown" {
+						onResult(result)
+					}
+				}
+			}
+			checkedAt := entry.checkedAt
+			if len(unknown) == 0 {
+				return entry.results, &checkedAt, false, nil
+			}
+
+			fresh, err := s.verifyShared(ctx, key, unknown, onResult, func(fresh []*verifier.VerificationResult) {
+				s.lookups.recheck(key, entry, fresh)
+			})
+			if err != nil {
+				return nil, nil, false, err
+			}
+			return entry.merge(fresh), &checkedAt, true, nil
+		}
 	}
-	if result.IsValid {
-		return "low"
+
+	results, err := s.verifyShared(ctx, key, emails, onResult, func(results []*verifier.VerificationResult) {
+		s.lookups.put(key, emails, results)
+	})
+	return results, nil, true, err
+}
+
+// verifyShared verifies emails in a run shared by the identical lookups of the
+// person at key, and passes the results of a complete run to store
+func (s *EmailFinderService) verifyShared(ctx context.Context, key string, emails []string, onResult func(*verifier.VerificationResult), store func([]*verifier.VerificationResult)) ([]*verifier.VerificationResult, error) {
+	// Streamed lookups report each result as it is verified, so they can't
+	// share a run
+	if onResult != nil {
+		results, err := s.verifyEmails(ctx, emails, onResult)
+		if err == nil {
+			store(results)
+		}
+		return results, err
 	}
-	return "low"
+
+	return s.lookups.do(ctx, key, emails, func(ctx context.Context) ([]*verifier.VerificationResult, error) {
+		results, err := s.verifyEmails(ctx, emails, nil)
+		if err == nil {
+			store(results)
+		}
+		return results, err
+	})
 }
 
-// sortByConfidence sorts emails by confidence level
-func (s *EmailFinderService) sortByConfidence(emails []EmailResult) []EmailResult {
-	// Simple sort: high, medium, low
-	high := make([]EmailResult, 0)
-	medium := make([]EmailResult, 0)
-	low := make([]EmailResult, 0)
+// verifyEmails verifies emails as one batch. With onResult, every email is
+// verified as a batch of its own, so its result is reported as soon as it is
+// final, retries included.
+func (s *EmailFinderService) verifyEmails(ctx context.Context, emails []string, onResult func(*verifier.VerificationResult)) ([]*verifier.VerificationResult, error) {
+	if onResult == nil {
+		return s.verifier.VerifyEmailsBatch(ctx, emails)
+	}
+
+	results := make([]*verifier.VerificationResult, len(emails))
+	errs := make([]error, len(emails))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i, email := range emails {
+		wg.Add(1)
+		go func(i int, email string) {
+			defer wg.Done()
 
-	for _, email := range emails {
-		switch email.Confidence {
-		case "high":
-			high = append(high, email)
-		case "medium":
-			medium = append(medium, email)
-		default:
-			low = append(low, email)
+			batch, err := s.verifier.VerifyEmailsBatch(ctx, []string{email})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i] = batch[0]
+
+			mu.Lock()
+			defer mu.Unlock()
+			onResult(batch[0])
+		}(i, email)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
 		}
 	}
+	return results, nil
+}
+
+// buildEmailResult converts a verification result into an API result with its score
+func (s *EmailFinderService) buildEmailResult(result *verifier.VerificationResult, pattern, domainMethod string, inferred bool) EmailResult {
+	score, breakdown := s.calculateScore(result, pattern, domainMethod, inferred)
+
+	emailResult := EmailResult{
+		Email:          result.Email,
+		Pattern:        pattern,
+		IsReachable:    result.IsReachable,
+		IsValid:        result.IsValid,
+		IsDeliverable:  result.IsDeliverable,
+		Confidence:     confidenceLabel(score),
+		Score:          score,
+		ScoreBreakdown: breakdown,
+		SMTPError:      result.SMTPError,
+		MXError:        result.MXError,
+	}
 
-	result := make([]EmailResult, 0, len(emails))
-	result = append(result, high...)
-	result = append(result, medium...)
-	result = append(result, low...)
+	if result.MX != nil {
+		emailResult.MXRecords = result.MX.Records
+	}
+	if result.SMTP != nil {
+		emailResult.CanConnectSMTP = result.SMTP.CanConnectSMTP
+		emailResult.IsCatchAll = result.SMTP.IsCatchAll
+		emailResult.IsDisabled = result.SMTP.IsDisabled
+		emailResult.HasFullInbox = result.SMTP.HasFullInbox
+	}
+	if result.Misc != nil {
+		emailResult.IsDisposable = result.Misc.IsDisposable
+		emailResult.IsRoleAccount = result.Misc.IsRoleAccount
+	}
 
-	return result
+	return emailResult
 }
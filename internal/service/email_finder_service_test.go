@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"email-finder/internal/generator"
+	"email-finder/internal/resolver"
+	"email-finder/internal/verifier"
+	"slices"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// scriptedVerifier reports the result scripted for each address, and invalid
+// for the others
+type scriptedVerifier struct {
+	results map[string]*verifier.VerificationResult
+
+	mu      sync.Mutex
+	checked []string
+}
+
+func (v *scriptedVerifier) VerifyEmail(ctx context.Context, email string) (*verifier.VerificationResult, error) {
+	results, _ := v.VerifyEmailsBatch(ctx, []string{email})
+	return results[0], nil
+}
+
+func (v *scriptedVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*verifier.VerificationResult, error) {
+	v.mu.Lock()
+	v.checked = append(v.checked, emails...)
+	v.mu.Unlock()
+
+	results := make([]*verifier.VerificationResult, len(emails))
+	for i, email := range emails {
+		results[i] = &verifier.VerificationResult{Email: email, IsReachable: "invalid"}
+		if result, ok := v.results[email]; ok {
+			copied := *result
+			copied.Email = email
+			results[i] = &copied
+		}
+	}
+	return results, nil
+}
+
+// unresolvedResolver finds no domain for any company
+type unresolvedResolver struct{}
+
+func (unresolvedResolver) ResolveDomain(ctx context.Context, companyName string) *resolver.DomainResult {
+	return &resolver.DomainResult{Method: "none"}
+}
+
+// scriptCandidates scripts the results of the first candidate addresses of
+// John Doe at example.org, in generator order, and returns those addresses
+func scriptCandidates(results ...*verifier.VerificationResult) (*scriptedVerifier, []string) {
+	patterns := generator.GenerateEmailPatterns("John", "Doe", "example.org")
+	v := &scriptedVerifier{results: make(map[string]*verifier.VerificationResult)}
+	emails := make([]string, len(results))
+	for i, result := range results {
+		emails[i] = patterns[i].Email
+		v.results[emails[i]] = result
+	}
+	return v, emails
+}
+
+func TestFindEmails_Include(t *testing.T) {
+	v, emails := scriptCandidates(
+		&verifier.VerificationResult{IsReachable: "safe", IsValid: true, IsDeliverable: true},
+		&verifier.VerificationResult{IsReachable: "risky", IsValid: true},
+		&verifier.VerificationResult{IsReachable: "unknown"},
+		&verifier.VerificationResult{IsReachable: "invalid"},
+	)
+	s := NewEmailFinderService(v, staticResolver{}, zap.NewNop(), len(emails))
+
+	tests := []struct {
+		include []string
+		want    []string
+	}{
+		{include: nil, want: emails[:1]},
+		{include: []string{"risky"}, want: []string{emails[0], emails[1]}},
+		{include: []string{"unknown"}, want: []string{emails[0], emails[2]}},
+		{include: []string{"invalid"}, want: []string{emails[0], emails[3]}},
+		{include: []string{"risky", "unknown", "invalid"}, want: emails},
+	}
+
+	for _, tt := range tests {
+		resp, err := s.FindEmails(context.Background(), FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Example", Include: tt.include})
+		if err != nil {
+			t.Fatalf("FindEmails(include %v) error = %v", tt.include, err)
+		}
+
+		found := make([]string, 0, len(resp.FoundEmails))
+		for _, result := range resp.FoundEmails {
+			found = append(found, result.Email)
+		}
+		slices.Sort(found)
+		want := slices.Clone(tt.want)
+		slices.Sort(want)
+		if !slices.Equal(found, want) || resp.TotalFound != len(want) {
+			t.Errorf("FindEmails(include %v) found %v (total %d), want %v", tt.include, found, resp.TotalFound, want)
+		}
+	}
+}
+
+func TestFindEmails_StatusBreakdown(t *testing.T) {
+	v, _ := scriptCandidates(
+		&verifier.VerificationResult{IsReachable: "safe", IsValid: true, IsDeliverable: true},
+		&verifier.VerificationResult{IsReachable: "risky", IsValid: true},
+		&verifier.VerificationResult{IsReachable: "unknown"},
+		&verifier.VerificationResult{IsReachable: "unknown"},
+	)
+	s := NewEmailFinderService(v, staticResolver{}, zap.NewNop(), 6)
+
+	resp, err := s.FindEmails(context.Background(), FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Example"})
+	if err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+
+	want := map[string]int{"safe": 1, "risky": 1, "unknown": 2, "invalid": 2}
+	total := 0
+	for status, count := range resp.StatusBreakdown {
+		total += count
+		if want[status] != count {
+			t.Errorf("StatusBreakdown[%q] = %d, want %d", status, count, want[status])
+		}
+	}
+	if total != resp.TotalChecked || resp.TotalChecked != 6 {
+		t.Errorf("StatusBreakdown adds up to %d, TotalChecked = %d, want both 6", total, resp.TotalChecked)
+	}
+}
+
+func TestFindEmails_BestGuess(t *testing.T) {
+	results := []*verifier.VerificationResult{
+		{IsReachable: "invalid"},
+		{IsReachable: "unknown"},
+		{IsReachable: "risky", IsValid: true},
+		{IsReachable: "unknown"},
+	}
+	v, emails := scriptCandidates(results...)
+	s := NewEmailFinderService(v, staticResolver{}, zap.NewNop(), len(emails))
+	req := FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Example"}
+
+	resp, err := s.FindEmails(context.Background(), req)
+	if err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if resp.TotalFound != 0 {
+		t.Fatalf("TotalFound = %d, want no deliverable address", resp.TotalFound)
+	}
+
+	// The best guess is the address with the highest score that wasn't rejected
+	patterns := generator.GenerateEmailPatterns("John", "Doe", "example.org")
+	var want EmailResult
+	for i, result := range results[1:] {
+		result.Email = emails[i+1]
+		candidate := s.buildEmailResult(result, patterns[i+1].Pattern, "company_map", false)
+		if candidate.Score > want.Score {
+			want = candidate
+		}
+	}
+	if resp.BestGuess == nil || resp.BestGuess.Email != want.Email || resp.BestGuess.Score != want.Score {
+		t.Errorf("BestGuess = %+v, want %s with score %d", resp.BestGuess, want.Email, want.Score)
+	}
+
+	// Without a domain nothing is checked, so there is nothing to guess
+	s = NewEmailFinderService(v, unresolvedResolver{}, zap.NewNop(), len(emails))
+	resp, err = s.FindEmails(context.Background(), req)
+	if err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if resp.TotalChecked != 0 || resp.BestGuess != nil {
+		t.Errorf("unresolved domain: TotalChecked = %d, BestGuess = %+v, want 0 and nil", resp.TotalChecked, resp.BestGuess)
+	}
+}
+
+func TestFindEmails_UsesInferredPatterns(t *testing.T) {
+	v, _ := scriptCandidates()
+	s := NewEmailFinderService(v, staticResolver{}, zap.NewNop(), 20)
+
+	inferred, err := s.InferPattern(InferPatternRequest{
+		Samples: []generator.SampleAddress{{FirstName: "Jane", LastName: "Roe", Email: "jane.roe@example.org"}},
+	})
+	if err != nil {
+		t.Fatalf("InferPattern() error = %v", err)
+	}
+	want := generator.FilterPatterns(generator.GenerateEmailPatterns("John", "Doe", "example.org"), inferred.InferredPatterns)
+	if len(want) == 0 {
+		t.Fatalf("InferPattern() = %+v, want patterns that generate John Doe's address", inferred)
+	}
+
+	resp, err := s.FindEmails(context.Background(), FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Example"})
+	if err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if !slices.Equal(resp.InferredPatterns, inferred.InferredPatterns) {
+		t.Errorf("InferredPatterns = %v, want %v", resp.InferredPatterns, inferred.InferredPatterns)
+	}
+	if len(v.checked) != len(want) || v.checked[0] != want[0].Email {
+		t.Errorf("checked %v, want the addresses of the inferred patterns, starting with %s", v.checked, want[0].Email)
+	}
+
+	// Skipping verification returns the inferred address without checking it
+	v.checked = nil
+	resp, err = s.FindEmails(context.Background(), FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Example", SkipVerification: true})
+	if err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if len(v.checked) != 0 {
+		t.Errorf("checked %v with SkipVerification, want the verifier not called", v.checked)
+	}
+	if resp.Verified || resp.TotalFound == 0 || resp.FoundEmails[0].Email != want[0].Email {
+		t.Errorf("FindEmails() = %+v, want %s unverified", resp, want[0].Email)
+	}
+}
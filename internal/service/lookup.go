@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"email-finder/internal/metrics"
+	"email-finder/internal/resolver"
+	"email-finder/internal/verifier"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxVerifyEmails is the most emails a single verify request may check
+const MaxVerifyEmails = 100
+
+// ErrInvalidRequest is returned when a lookup request is missing required input
+var ErrInvalidRequest = errors.New("invalid request")
+
+// VerifyEmailsRequest represents a list of addresses to verify as-is
+type VerifyEmailsRequest struct {
+	Emails []string `json:"emails" binding:"required"`
+}
+
+// VerifyEmailsResponse holds one verification result per requested email, in order
+type VerifyEmailsResponse struct {
+	Results []*verifier.VerificationResult `json:"results"`
+}
+
+// ResolveDomainRequest represents a company whose email domain is looked up
+type ResolveDomainRequest struct {
+	Company string `json:"company" binding:"required"`
+}
+
+// VerifyEmails verifies the given addresses without generating any patterns
+func (s *EmailFinderService) VerifyEmails(ctx context.Context, req VerifyEmailsRequest) (*VerifyEmailsResponse, error) {
+	emails := make([]string, 0, len(req.Emails))
+	for _, email := range req.Emails {
+		if email = strings.TrimSpace(email); email != "" {
+			emails = append(emails, email)
+		}
+	}
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("%w: at least one email is required", ErrInvalidRequest)
+	}
+	if len(emails) > MaxVerifyEmails {
+		return nil, fmt.Errorf("%w: at most %d emails can be verified at once", ErrInvalidRequest, MaxVerifyEmails)
+	}
+
+	results, err := s.verifier.VerifyEmailsBatch(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+	return &VerifyEmailsResponse{Results: results}, nil
+}
+
+// ResolveDomain looks up the email domain of a company
+func (s *EmailFinderService) ResolveDomain(ctx context.Context, req ResolveDomainRequest) (*resolver.DomainResult, error) {
+	if strings.TrimSpace(req.Company) == "" {
+		return nil, fmt.Errorf("%w: company is required", ErrInvalidRequest)
+	}
+
+	result := s.domainResolver.ResolveDomain(ctx, req.Company)
+	metrics.ObserveResolution(result.Method)
+	return result, nil
+}
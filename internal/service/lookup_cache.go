@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"email-finder/internal/metrics"
+	"email-finder/internal/verifier"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LookupCache remembers the verification results of recent lookups by person
+// and domain, and makes identical lookups running at the same time share one
+// verification run
+type LookupCache struct {
+	ttl        time.Duration
+	maxEntries int
+
+	mu       sync.Mutex
+	entries  map[string]*lookupEntry
+	inflight map[string]*lookupCall
+}
+
+// lookupEntry is the outcome of a completed verification run
+type lookupEntry struct {
+	// emails are the candidates that were verified; a lookup generating other
+	// candidates, e.g. after new patterns were learned, doesn't use the entry
+	emails    []string
+	results   []*verifier.VerificationResult
+	checkedAt time.Time
+}
+
+// lookupCall is a verification run shared by identical lookups
+type lookupCall struct {
+	done     chan struct{}
+	priority verifier.Priority
+	results  []*verifier.VerificationResult
+	err      error
+
+	// waiters is the number of lookups waiting for the run; it is cancelled
+	// when all of them have given up
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// NewLookupCache creates a lookup cache keeping up to maxEntries results for
+// ttl. With a ttl of 0, nothing is cached but identical lookups are still coalesced.
+func NewLookupCache(ttl time.Duration, maxEntries int) *LookupCache {
+	return &LookupCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]*lookupEntry),
+		inflight:   make(map[string]*lookupCall),
+	}
+}
+
+// SetLookupCache makes lookups reuse recent results of the same person at the
+// same domain. It must be called before the service is used.
+func (s *EmailFinderService) SetLookupCache(c *LookupCache) {
+	s.lookups = c
+}
+
+// lookupKey identifies a person at a domain, ignoring case and surrounding spaces
+func lookupKey(firstName, lastName, domain string) string {
+	normalize := func(value string) string {
+		return strings.ToLower(strings.TrimSpace(value))
+	}
+	return normalize(firstName) + "\x00" + normalize(lastName) + "\x00" + normalize(domain)
+}
+
+// get returns the cached results for key if they are recent and were verified
+// for the same candidates. Their unknown results are to be checked again.
+func (c *LookupCache) get(key string, emails []string) (*lookupEntry, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, exists := c.entries[key]
+	hit := exists && time.Since(entry.checkedAt) <= c.ttl && slices.Equal(entry.emails, emails)
+	metrics.ObserveCacheLookup("lookup", hit)
+	if !hit {
+		return nil, false
+	}
+	return entry, true
+}
+
+// put caches the results of a verification run
+func (c *LookupCache) put(key string, emails []string, results []*verifier.VerificationResult) {
+	if c.ttl <= 0 || c.maxEntries <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= c.maxEntries {
+		c.evict()
+	}
+	c.entries[key] = &lookupEntry{emails: emails, results: results, checkedAt: time.Now().UTC()}
+}
+
+// recheck replaces the results of the addresses of entry that were checked
+// again, unless the entry was replaced in the meantime. The entry keeps its
+// age, so the results that were reused still expire on time.
+func (c *LookupCache) recheck(key string, entry *lookupEntry, fresh []*verifier.VerificationResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries[key] == entry {
+		c.entries[key] = &lookupEntry{emails: entry.emails, results: entry.merge(fresh), checkedAt: entry.checkedAt}
+	}
+}
+
+// unknown returns the addresses whose result was unknown, e.g. because their
+// server greylisted or timed out
+func (e *lookupEntry) unknown() []string {
+	emails := make([]string, 0)
+	for _, result := range e.results {
+		if result.IsReachable == "unknown" {
+			emails = append(emails, result.Email)
+		}
+	}
+	return emails
+}
+
+// merge returns the results of the entry with those of the addresses checked
+// again replaced by fresh
+func (e *lookupEntry) merge(fresh []*verifier.VerificationResult) []*verifier.VerificationResult {
+	byEmail := make(map[string]*verifier.VerificationResult, len(fresh))
+	for _, result := range fresh {
+		byEmail[result.Email] = result
+	}
+	results := make([]*verifier.VerificationResult, len(e.results))
+	for i, result := range e.results {
+		if replaced, ok := byEmail[result.Email]; ok {
+			result = replaced
+		}
+		results[i] = result
+	}
+	return results
+}
+
+// evict removes the expired entries, or the oldest one if none has expired.
+// Callers must hold c.mu.
+func (c *LookupCache) evict() {
+	oldestKey := ""
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if time.Since(entry.checkedAt) > c.ttl {
+			delete(c.entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.checkedAt.Before(oldest) {
+			oldestKey, oldest = key, entry.checkedAt
+		}
+	}
+	if len(c.entries) >= c.maxEntries {
+		delete(c.entries, oldestKey)
+	}
+}
+
+// do runs verify once for all the identical lookups calling it at the same
+// time. The run keeps going while at least one of the lookups is still waiting
+// for it. A lookup doesn't join a run queued at a lower priority, e.g. an
+// interactive lookup of a person a bulk request is looking up: it starts its
+// own run, which later lookups join instead.
+func (c *LookupCache) do(ctx context.Context, key string, emails []string, verify func(ctx context.Context) ([]*verifier.VerificationResult, error)) ([]*verifier.VerificationResult, error) {
+	callKey := key + "\x00" + strings.Join(emails, ",")
+
+	priority := verifier.PriorityFromContext(ctx)
+
+	c.mu.Lock()
+	call, exists := c.inflight[callKey]
+	if exists && priority < call.priority {
+		exists = false
+	}
+	if !exists {
+		// The run outlives the lookup that started it, but keeps its values,
+		// such as the priority and the trace
+		runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &lookupCall{done: make(chan struct{}), priority: priority, cancel: cancel}
+		c.inflight[callKey] = call
+
+		go func() {
+			defer cancel()
+			call.results, call.err = verify(runCtx)
+
+			c.mu.Lock()
+			c.forget(callKey, call)
+			c.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	c.mu.Unlock()
+	metrics.ObserveCacheLookup("lookup_inflight", exists)
+
+	select {
+	case <-call.done:
+		return call.results, call.err
+	case <-ctx.Done():
+		c.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// Later lookups start a new run instead of joining a cancelled one
+			call.cancel()
+			c.forget(callKey, call)
+		}
+		c.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// forget removes a run from the runs in progress, unless it was already
+// replaced by a new one. Callers must hold c.mu.
+func (c *LookupCache) forget(callKey string, call *lookupCall) {
+	if c.inflight[callKey] == call {
+		delete(c.inflight, callKey)
+	}
+}
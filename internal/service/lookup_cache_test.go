@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"email-finder/internal/resolver"
+	"email-finder/internal/verifier"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestLookupCache_InteractiveDoesNotJoinBulk(t *testing.T) {
+	v := newGatedVerifier("safe")
+	s := newCachedService(v, 0)
+	release := v.hold()
+	req := FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Acme"}
+	bulkCtx := verifier.WithPriority(context.Background(), verifier.PriorityBulk)
+
+	responses := make(chan *FindEmailResponse, 3)
+	lookup := func(ctx context.Context) {
+		resp, _ := s.FindEmails(ctx, req)
+		responses <- resp
+	}
+	go lookup(bulkCtx)
+	waitForWaiters(t, s.lookups, 1)
+
+	// The interactive lookup starts its own run rather than waiting behind bulk work
+	go lookup(context.Background())
+	deadline := time.Now().Add(2 * time.Second)
+	for v.count() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if v.count() != 2 {
+		t.Fatalf("verification runs = %d, want the interactive lookup to start its own", v.count())
+	}
+	waitForWaiters(t, s.lookups, 1)
+
+	// A bulk lookup may join the interactive run
+	go lookup(bulkCtx)
+	waitForWaiters(t, s.lookups, 2)
+
+	release()
+	for i := 0; i < 3; i++ {
+		if resp := <-responses; resp == nil || resp.TotalFound != 3 {
+			t.Errorf("lookup = %+v, want 3 found emails", resp)
+		}
+	}
+	if v.count() != 2 {
+		t.Errorf("verification runs = %d, want 2", v.count())
+	}
+}
+
+// gatedVerifier counts verification runs and holds them until release is closed
+type gatedVerifier struct {
+	mu      sync.Mutex
+	runs    int
+	release chan struct{}
+	status  string
+}
+
+func newGatedVerifier(status string) *gatedVerifier {
+	v := &gatedVerifier{release: make(chan struct{}), status: status}
+	close(v.release)
+	return v
+}
+
+func (v *gatedVerifier) VerifyEmail(ctx context.Context, email string) (*verifier.VerificationResult, error) {
+	results, err := v.VerifyEmailsBatch(ctx, []string{email})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (v *gatedVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*verifier.VerificationResult, error) {
+	v.mu.Lock()
+	v.runs++
+	release := v.release
+	v.mu.Unlock()
+
+	select {
+	case <-release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	results := make([]*verifier.VerificationResult, 0, len(emails))
+	for _, email := range emails {
+		results = append(results, &verifier.VerificationResult{Email: email, IsReachable: v.status, IsValid: true, IsDeliverable: v.status == "safe"})
+	}
+	return results, nil
+}
+
+// hold makes the next runs wait until the returned function is called
+func (v *gatedVerifier) hold() func() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.release = make(chan struct{})
+	return func() { close(v.release) }
+}
+
+func (v *gatedVerifier) count() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.runs
+}
+
+// staticResolver maps every company to one domain
+type staticResolver struct{}
+
+func (staticResolver) ResolveDomain(ctx context.Context, companyName string) *resolver.DomainResult {
+	return &resolver.DomainResult{Domain: "example.org", Resolved: true, Method: "company_map"}
+}
+
+func newCachedService(v verifier.Verifier, ttl time.Duration) *EmailFinderService {
+	s := NewEmailFinderService(v, staticResolver{}, zap.NewNop(), 3)
+	s.SetLookupCache(NewLookupCache(ttl, 10))
+	return s
+}
+
+// waitForWaiters waits until n lookups share the run in progress
+func waitForWaiters(t *testing.T, c *LookupCache, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		waiters := 0
+		for _, call := range c.inflight {
+			waiters += call.waiters
+		}
+		c.mu.Unlock()
+		if waiters == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%d lookups did not join the run in time", n)
+}
+
+func TestLookupCache_ReusesRecentLookups(t *testing.T) {
+	v := newGatedVerifier("safe")
+	s := newCachedService(v, time.Hour)
+	ctx := context.Background()
+
+	first, err := s.FindEmails(ctx, FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Acme"})
+	if err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if first.CachedAt != nil {
+		t.Error("CachedAt is set on the first lookup, want nil")
+	}
+
+	second, err := s.FindEmails(ctx, FindEmailRequest{FirstName: " JOHN", LastName: "doe", Company: "Acme Inc"})
+	if err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if v.count() != 1 {
+		t.Errorf("verification runs = %d, want 1", v.count())
+	}
+	if second.CachedAt == nil || second.TotalFound != first.TotalFound {
+		t.Errorf("second lookup = %d found, cached at %v, want the cached result", second.TotalFound, second.CachedAt)
+	}
+
+	refreshed, err := s.FindEmails(ctx, FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Acme", Refresh: true})
+	if err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if v.count() != 2 || refreshed.CachedAt != nil {
+		t.Errorf("refresh: runs = %d, cached at %v, want a new run", v.count(), refreshed.CachedAt)
+	}
+}
+
+func TestLookupCache_AppliesIncludeToCachedResults(t *testing.T) {
+	v := newGatedVerifier("risky")
+	s := newCachedService(v, time.Hour)
+	ctx := context.Background()
+
+	plain, _ := s.FindEmails(ctx, FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Acme"})
+	withRisky, _ := s.FindEmails(ctx, FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Acme", Include: []string{"risky"}})
+
+	if v.count() != 1 {
+		t.Errorf("verification runs = %d, want 1", v.count())
+	}
+	if plain.TotalFound != 0 || withRisky.TotalFound != 3 {
+		t.Errorf("found %d without include and %d with risky, want 0 and 3", plain.TotalFound, withRisky.TotalFound)
+	}
+}
+
+// statusVerifier answers with a status per address, safe by default, and
+// records the addresses it checked
+type statusVerifier struct {
+	mu       sync.Mutex
+	statuses map[string]string
+	checked  []string
+}
+
+func (v *statusVerifier) VerifyEmail(ctx context.Context, email string) (*verifier.VerificationResult, error) {
+	results, err := v.VerifyEmailsBatch(ctx, []string{email})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (v *statusVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*verifier.VerificationResult, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	results := make([]*verifier.VerificationResult, 0, len(emails))
+	for _, email := range emails {
+		v.checked = append(v.checked, email)
+		status := v.statuses[email]
+		if status == "" {
+			status = "safe"
+		}
+		results = append(results, &verifier.VerificationResult{Email: email, IsReachable: status, IsValid: true, IsDeliverable: status == "safe"})
+	}
+	return results, nil
+}
+
+// take returns the addresses checked since the last call
+func (v *statusVerifier) take() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	checked := v.checked
+	v.checked = nil
+	return checked
+}
+
+func TestLookupCache_RechecksOnlyUnknownResults(t *testing.T) {
+	v := &statusVerifier{statuses: map[string]string{"john.doe@example.org": "unknown"}}
+	s := newCachedService(v, time.Hour)
+	req := FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Acme"}
+
+	first, err := s.FindEmails(context.Background(), req)
+	if err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if checked := v.take(); len(checked) != 3 {
+		t.Fatalf("first lookup checked %v, want the 3 candidates", checked)
+	}
+
+	// The greylisting server answers now
+	v.mu.Lock()
+	v.statuses["john.doe@example.org"] = "safe"
+	v.mu.Unlock()
+
+	second, err := s.FindEmails(context.Background(), req)
+	if err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if checked := v.take(); len(checked) != 1 || checked[0] != "john.doe@example.org" {
+		t.Errorf("second lookup checked %v, want only the unknown address", checked)
+	}
+	if second.CachedAt == nil || second.TotalFound != first.TotalFound+1 {
+		t.Errorf("second lookup = %d found, cached at %v, want the cached result with the rechecked address", second.TotalFound, second.CachedAt)
+	}
+
+	if _, err := s.FindEmails(context.Background(), req); err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if checked := v.take(); len(checked) != 0 {
+		t.Errorf("third lookup checked %v, want every result from the cache", checked)
+	}
+}
+
+func TestLookupCache_CoalescesConcurrentLookups(t *testing.T) {
+	v := newGatedVerifier("safe")
+	s := newCachedService(v, 0)
+	release := v.hold()
+
+	const lookups = 5
+	var wg sync.WaitGroup
+	results := make([]*FindEmailResponse, lookups)
+	for i := 0; i < lookups; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = s.FindEmails(context.Background(), FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Acme"})
+		}(i)
+	}
+	waitForWaiters(t, s.lookups, lookups)
+	release()
+	wg.Wait()
+
+	if v.count() != 1 {
+		t.Errorf("verification runs = %d, want 1", v.count())
+	}
+	for i, resp := range results {
+		if resp == nil || resp.TotalFound != 3 {
+			t.Errorf("lookup %d = %+v, want 3 found emails", i, resp)
+		}
+	}
+}
+
+func TestLookupCache_FollowerOutlivesCancelledLeader(t *testing.T) {
+	v := newGatedVerifier("safe")
+	s := newCachedService(v, time.Hour)
+	release := v.hold()
+	req := FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Acme"}
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := s.FindEmails(leaderCtx, req)
+		leaderErr <- err
+	}()
+	waitForWaiters(t, s.lookups, 1)
+
+	follower := make(chan *FindEmailResponse, 1)
+	go func() {
+		resp, _ := s.FindEmails(context.Background(), req)
+		follower <- resp
+	}()
+	waitForWaiters(t, s.lookups, 2)
+
+	cancelLeader()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("leader error = %v, want context.Canceled", err)
+	}
+	release()
+	if resp := <-follower; resp == nil || resp.TotalFound != 3 {
+		t.Errorf("follower = %+v, want 3 found emails", resp)
+	}
+	if v.count() != 1 {
+		t.Errorf("verification runs = %d, want 1", v.count())
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"email-finder/internal/generator"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// ErrInvalidSamples is returned when sample addresses cannot be used for inference
+var ErrInvalidSamples = errors.New("invalid sample addresses")
+
+// InferPatternRequest represents the input for inferring a company's email pattern
+type InferPatternRequest struct {
+	Company string                    `json:"company,omitempty"`
+	Samples []generator.SampleAddress `json:"samples" binding:"required"`
+}
+
+// InferPatternResponse represents the patterns that explain the sample addresses
+type InferPatternResponse struct {
+	Domain           string                   `json:"domain"`
+	InferredPatterns []string                 `json:"inferred_patterns"`
+	Candidates       []generator.PatternMatch `json:"candidates"`
+	TotalSamples     int                      `json:"total_samples"`
+	Request          InferPatternRequest      `json:"request"`
+}
+
+// InferPattern works out which pattern(s) a company uses from known sample addresses
+// and remembers them, so later FindEmails calls for the same domain only try those.
+// If a company name is given it is also mapped to the samples' domain.
+func (s *EmailFinderService) InferPattern(req InferPatternRequest) (*InferPatternResponse, error) {
+	if len(req.Samples) == 0 {
+		return nil, fmt.Errorf("%w: at least one sample is required", ErrInvalidSamples)
+	}
+
+	domain := ""
+	for _, sample := range req.Samples {
+		if strings.TrimSpace(sample.FirstName) == "" || strings.TrimSpace(sample.LastName) == "" {
+			return nil, fmt.Errorf("%w: first_name and last_name are required for %q", ErrInvalidSamples, sample.Email)
+		}
+
+		email := strings.TrimSpace(strings.ToLower(sample.Email))
+		at := strings.LastIndex(email, "@")
+		if at <= 0 || at == len(email)-1 {
+			return nil, fmt.Errorf("%w: %q is not an email address", ErrInvalidSamples, sample.Email)
+		}
+
+		sampleDomain := email[at+1:]
+		if domain == "" {
+			domain = sampleDomain
+		} else if sampleDomain != domain {
+			return nil, fmt.Errorf("%w: samples span multiple domains (%s, %s)", ErrInvalidSamples, domain, sampleDomain)
+		}
+	}
+
+	candidates := generator.InferPatterns(req.Samples)
+
+	// The inferred patterns are those explaining the most samples
+	inferred := make([]string, 0)
+	for _, c := range candidates {
+		if c.Matches != candidates[0].Matches {
+			break
+		}
+		inferred = append(inferred, c.Pattern)
+	}
+
+	if len(inferred) > 0 {
+		s.setLearnedPatterns(domain, inferred)
+	}
+
+	if recorder, ok := s.domainResolver.(CompanyDomainRecorder); ok && req.Company != "" {
+		recorder.AddCompanyDomain(req.Company, domain)
+	}
+
+	s.logger.Info("email pattern inferred",
+		zap.String("domain", domain),
+		zap.Int("samples", len(req.Samples)),
+		zap.Strings("patterns", inferred),
+	)
+
+	return &InferPatternResponse{
+		Domain:           domain,
+		InferredPatterns: inferred,
+		Candidates:       candidates,
+		TotalSamples:     len(req.Samples),
+		Request:          req,
+	}, nil
+}
+
+// LearnedPatterns returns the patterns inferred for a domain, if any. With
+// domain profiles, patterns inferred before a restart are remembered.
+func (s *EmailFinderService) LearnedPatterns(domain string) []string {
+	s.patternsMutex.RLock()
+	patterns, exists := s.learnedPatterns[strings.ToLower(domain)]
+	s.patternsMutex.RUnlock()
+
+	if !exists && s.profiles != nil {
+		return s.profiles.LearnedPatterns(domain)
+	}
+	return patterns
+}
+
+// setLearnedPatterns records the patterns inferred for a domain
+func (s *EmailFinderService) setLearnedPatterns(domain string, patterns []string) {
+	s.patternsMutex.Lock()
+	s.learnedPatterns[strings.ToLower(domain)] = patterns
+	s.patternsMutex.Unlock()
+
+	if s.profiles != nil {
+		s.profiles.ObservePatterns(domain, patterns)
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"email-finder/internal/verifier"
+	"time"
+)
+
+// LookupRecord is everything a lookup did: the domain it resolved, the
+// addresses it tried, every verification result and what it returned
+type LookupRecord struct {
+	Request        FindEmailRequest `json:"request"`
+	Domain         string           `json:"domain,omitempty"`
+	DomainMethod   string           `json:"domain_method,omitempty"`
+	DomainResolved bool             `json:"domain_resolved"`
+
+	Patterns      []PatternTried                 `json:"patterns"`
+	Verifications []*verifier.VerificationResult `json:"verifications"`
+
+	Response *FindEmailResponse `json:"response,omitempty"`
+	Error    string             `json:"error,omitempty"`
+
+	StartedAt  time.Time `json:"started_at"`
+	DurationMS int64     `json:"duration_ms"`
+}
+
+// PatternTried is a candidate address generated by a lookup
+type PatternTried struct {
+	Email   string `json:"email"`
+	Pattern string `json:"pattern"`
+}
+
+// Recorder receives a record of every finished lookup. Record must not block.
+type Recorder interface {
+	Record(rec *LookupRecord)
+}
+
+// SetRecorder records every lookup with r. It must be called before the
+// service is used.
+func (s *EmailFinderService) SetRecorder(r Recorder) {
+	s.recorder = r
+}
+
+// record completes rec with the outcome of the lookup and hands it to the recorder
+func (s *EmailFinderService) record(rec *LookupRecord, resp *FindEmailResponse, err error) {
+	if s.recorder == nil {
+		return
+	}
+	rec.Response = resp
+	if err != nil {
+		rec.Error = err.Error()
+	}
+	rec.DurationMS = time.Since(rec.StartedAt).Milliseconds()
+	s.recorder.Record(rec)
+}
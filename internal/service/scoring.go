@@ -0,0 +1,132 @@
+package service
+
+import (
+	"email-finder/internal/verifier"
+	"sort"
+)
+
+// ScoreBreakdown documents the components that make up an email's score.
+// The components always sum to the score before it is clamped to 0-100.
+type ScoreBreakdown struct {
+	// Status reflects the verifier's is_reachable verdict:
+	// safe 45, risky 20, unknown 5, invalid 0
+	Status int `json:"status"`
+	// Deliverability is 20 when the SMTP server accepted the mailbox
+	Deliverability int `json:"deliverability"`
+	// CatchAll is -15 when the domain accepts mail for any address,
+	// since acceptance then says nothing about this particular mailbox
+	CatchAll int `json:"catch_all"`
+	// PatternPrior is 0-20 depending on how common the pattern is in practice;
+	// patterns inferred from known addresses at the domain always get 20
+	PatternPrior int `json:"pattern_prior"`
+	// DomainResolution is 0-15 depending on how confidently the company
+	// was mapped to its domain
+	DomainResolution int `json:"domain_resolution"`
+}
+
+// Total returns the sum of all components clamped to 0-100
+func (b ScoreBreakdown) Total() int {
+	total := b.Status + b.Deliverability + b.CatchAll + b.PatternPrior + b.DomainResolution
+	if total < 0 {
+		return 0
+	}
+	if total > 100 {
+		return 100
+	}
+	return total
+}
+
+// statusScores maps verifier is_reachable values to score points
+var statusScores = map[string]int{
+	"safe":    45,
+	"risky":   20,
+	"unknown": 5,
+	"invalid": 0,
+}
+
+// patternPriors maps base pattern names to how often they occur in practice.
+// Numbered variations and anything not listed get numberedPatternPrior.
+var patternPriors = map[string]int{
+	"firstname.lastname":   20,
+	"flastname":            16,
+	"firstname":            16,
+	"firstnamelastname":    14,
+	"f.lastname":           14,
+	"firstname_lastname":   10,
+	"firstname.l":          8,
+	"firstnamel":           8,
+	"lastname.firstname":   8,
+	"lastname":             6,
+	"lastnamefirstname":    6,
+	"firstname-lastname":   6,
+	"f_lastname":           5,
+	"l.firstname":          4,
+	"lfirstname":           4,
+	"lastname_firstname":   4,
+	"fl":                   3,
+	"firstname.f.lastname": 3,
+	"lastname.f":           3,
+	"f.firstname.lastname": 2,
+}
+
+const (
+	inferredPatternPrior = 20
+	numberedPatternPrior = 1
+	catchAllPenalty      = -15
+	deliverableScore     = 20
+)
+
+// domainMethodScores maps resolver methods to score points
+var domainMethodScores = map[string]int{
+	"direct":       15,
+	"company_map":  15,
+	"dns_verified": 10,
+	"pattern":      0,
+}
+
+// confidenceLabel buckets a score into the legacy high/medium/low label
+func confidenceLabel(score int) string {
+	switch {
+	case score >= 70:
+		return "high"
+	case score >= 40:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// calculateScore computes the numeric score for an email and its breakdown
+func (s *EmailFinderService) calculateScore(result *verifier.VerificationResult, pattern, domainMethod string, inferred bool) (int, ScoreBreakdown) {
+	breakdown := ScoreBreakdown{
+		Status:           statusScores[result.IsReachable],
+		DomainResolution: domainMethodScores[domainMethod],
+	}
+
+	if result.IsDeliverable {
+		breakdown.Deliverability = deliverableScore
+	}
+
+	if result.SMTP != nil && result.SMTP.IsCatchAll {
+		breakdown.CatchAll = catchAllPenalty
+	}
+
+	switch prior, ok := patternPriors[pattern]; {
+	case inferred:
+		breakdown.PatternPrior = inferredPatternPrior
+	case ok:
+		breakdown.PatternPrior = prior
+	default:
+		breakdown.PatternPrior = numberedPatternPrior
+	}
+
+	return breakdown.Total(), breakdown
+}
+
+// sortByScore sorts emails by score, highest first, keeping pattern order for ties
+func (s *EmailFinderService) sortByScore(emails []EmailResult) []EmailResult {
+	sort.SliceStable(emails, func(i, j int) bool {
+		return emails[i].Score > emails[j].Score
+	})
+	return emails
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"email-finder/internal/verifier"
+	"testing"
+)
+
+func TestCalculateScore(t *testing.T) {
+	s := &EmailFinderService{}
+
+	tests := []struct {
+		name         string
+		result       *verifier.VerificationResult
+		pattern      string
+		domainMethod string
+		inferred     bool
+		want         int
+	}{
+		{
+			name:         "safe and deliverable on common pattern",
+			result:       &verifier.VerificationResult{IsReachable: "safe", IsDeliverable: true},
+			pattern:      "firstname.lastname",
+			domainMethod: "company_map",
+			want:         100,
+		},
+		{
+			name: "risky catch-all",
+			result: &verifier.VerificationResult{
+				IsReachable:   "risky",
+				IsDeliverable: true,
+				SMTP:          &verifier.SMTPDetails{IsDeliverable: true, IsCatchAll: true},
+			},
+			pattern:      "flastname",
+			domainMethod: "dns_verified",
+			want:         20 + 20 - 15 + 16 + 10,
+		},
+		{
+			name:         "unknown numbered pattern on guessed domain",
+			result:       &verifier.VerificationResult{IsReachable: "unknown"},
+			pattern:      "firstname.lastname7",
+			domainMethod: "pattern",
+			want:         5 + 1,
+		},
+		{
+			name:         "inferred pattern overrides prior",
+			result:       &verifier.VerificationResult{IsReachable: "unknown"},
+			pattern:      "f.firstname.lastname",
+			domainMethod: "direct",
+			inferred:     true,
+			want:         5 + 20 + 15,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, breakdown := s.calculateScore(tt.result, tt.pattern, tt.domainMethod, tt.inferred)
+			if got != tt.want {
+				t.Errorf("calculateScore() = %v, want %v (breakdown %+v)", got, tt.want, breakdown)
+			}
+			if got != breakdown.Total() {
+				t.Errorf("calculateScore() = %v, breakdown total = %v", got, breakdown.Total())
+			}
+		})
+	}
+}
+
+func TestSortByScore(t *testing.T) {
+	s := &EmailFinderService{}
+	emails := []EmailResult{
+		{Email: "a@example.com", Score: 40},
+		{Email: "b@example.com", Score: 90},
+		{Email: "c@example.com", Score: 40},
+	}
+
+	sorted := s.sortByScore(emails)
+	want := []string{"b@example.com", "a@example.com", "c@example.com"}
+	for i, email := range want {
+		if sorted[i].Email != email {
+			t.Errorf("sortByScore()[%d] = %v, want %v", i, sorted[i].Email, email)
+		}
+	}
+}
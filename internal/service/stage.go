@@ -0,0 +1,28 @@
+package service
+
+import "context"
+
+// Stage is a step of a lookup
+type Stage string
+
+const (
+	// StageResolving is resolving the company's email domain
+	StageResolving Stage = "resolving"
+	// StageVerifying is verifying the candidate addresses
+	StageVerifying Stage = "verifying"
+)
+
+// stageHookKey is the context key for the stage hook
+type stageHookKey struct{}
+
+// WithStageHook returns a context whose lookups call hook as they enter each stage
+func WithStageHook(ctx context.Context, hook func(Stage)) context.Context {
+	return context.WithValue(ctx, stageHookKey{}, hook)
+}
+
+// enterStage calls the stage hook of ctx, if any
+func enterStage(ctx context.Context, stage Stage) {
+	if hook, ok := ctx.Value(stageHookKey{}).(func(Stage)); ok {
+		hook(stage)
+	}
+}
@@ -0,0 +1,45 @@
+package tracing
+
+import (
+	"context"
+	"email-finder/config"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+)
+
+func TestSetup(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+	}{
+		{name: "disabled", enabled: false},
+		{name: "enabled", enabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.TracingConfig{
+				Enabled:      tt.enabled,
+				OTLPEndpoint: "localhost:4318",
+				Insecure:     true,
+				SampleRatio:  1,
+				ServiceName:  "email-finder-test",
+			}
+
+			shutdown, err := Setup(context.Background(), cfg, zap.NewNop())
+			if err != nil {
+				t.Fatalf("Setup() error = %v", err)
+			}
+			if fields := otel.GetTextMapPropagator().Fields(); len(fields) == 0 {
+				t.Error("Setup() did not install a trace context propagator")
+			}
+
+			// Nothing was recorded, so shutdown has nothing to flush
+			if err := shutdown(context.Background()); err != nil {
+				t.Errorf("shutdown() error = %v", err)
+			}
+		})
+	}
+}
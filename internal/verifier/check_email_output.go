@@ -0,0 +1,184 @@
+package verifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SyntaxDetails holds the syntax section of a check-if-email-exists result
+type SyntaxDetails struct {
+	Address       string `json:"address"`
+	Domain        string `json:"domain"`
+	Username      string `json:"username"`
+	IsValidSyntax bool   `json:"is_valid_syntax"`
+}
+
+// MXDetails holds the MX section of a check-if-email-exists result
+type MXDetails struct {
+	AcceptsMail bool     `json:"accepts_mail"`
+	Records     []string `json:"records"`
+}
+
+// SMTPDetails holds the SMTP section of a check-if-email-exists result
+type SMTPDetails struct {
+	CanConnectSMTP bool `json:"can_connect_smtp"`
+	HasFullInbox   bool `json:"has_full_inbox"`
+	IsCatchAll     bool `json:"is_catch_all"`
+	IsDeliverable  bool `json:"is_deliverable"`
+	IsDisabled     bool `json:"is_disabled"`
+}
+
+// MiscDetails holds the misc section of a check-if-email-exists result
+type MiscDetails struct {
+	IsDisposable  bool   `json:"is_disposable"`
+	IsRoleAccount bool   `json:"is_role_account"`
+	GravatarURL   string `json:"gravatar_url,omitempty"`
+}
+
+// CheckError is an error reported by check-if-email-exists for one section of
+// the result. Code holds the SMTP reply code when the message contains one.
+type CheckError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	Code    int    `json:"code,omitempty"`
+}
+
+// smtpReplyCode matches an SMTP reply code such as "550" or "421" where a reply
+// starts: at the start of a line of the message or after a colon, as in "server
+// responded: 550 5.1.1 ...". Other numbers in the message, such as the port in
+// "203.0.113.7:465", are not reply codes.
+var smtpReplyCode = regexp.MustCompile(`(?m)(?:^|:[ \t]+)([45][0-9]{2})(?:[ \t-]|$)`)
+
+// checkEmailOutput mirrors the JSON produced by the check-if-email-exists
+// HTTP API and CLI. The misc, mx and smtp sections are either the details
+// object or an error object, so they are decoded in a second step.
+type checkEmailOutput struct {
+	Input       string          `json:"input"`
+	IsReachable string          `json:"is_reachable"`
+	Misc        json.RawMessage `json:"misc"`
+	MX          json.RawMessage `json:"mx"`
+	SMTP        json.RawMessage `json:"smtp"`
+	Syntax      SyntaxDetails   `json:"syntax"`
+}
+
+// parseCheckEmailOutput converts check-if-email-exists output into a VerificationResult.
+// Both a single object and an array of objects (first element used) are accepted.
+func parseCheckEmailOutput(data []byte) (*VerificationResult, error) {
+	var output checkEmailOutput
+
+	if err := json.Unmarshal(data, &output); err != nil {
+		// Try to parse as array (some APIs return array)
+		var outputs []checkEmailOutput
+		if err2 := json.Unmarshal(data, &outputs); err2 != nil || len(outputs) == 0 {
+			return nil, err
+		}
+		output = outputs[0]
+	}
+
+	result := &VerificationResult{
+		Email:       output.Input,
+		IsReachable: output.IsReachable,
+		Syntax:      &output.Syntax,
+	}
+
+	var mx MXDetails
+	if found, checkErr, err := decodeSection(output.MX, &mx); err != nil {
+		return nil, fmt.Errorf("failed to parse mx section: %w", err)
+	} else if checkErr != nil {
+		result.MXError = checkErr
+	} else if found {
+		result.MX = &mx
+	}
+
+	var smtp SMTPDetails
+	if found, checkErr, err := decodeSection(output.SMTP, &smtp); err != nil {
+		return nil, fmt.Errorf("failed to parse smtp section: %w", err)
+	} else if checkErr != nil {
+		result.SMTPError = checkErr
+	} else if found {
+		result.SMTP = &smtp
+	}
+
+	var misc MiscDetails
+	if found, checkErr, err := decodeSection(output.Misc, &misc); err != nil {
+		return nil, fmt.Errorf("failed to parse misc section: %w", err)
+	} else if checkErr != nil {
+		result.MiscError = checkErr
+	} else if found {
+		result.Misc = &misc
+	}
+
+	result.IsValid = output.Syntax.IsValidSyntax && mx.AcceptsMail
+	result.IsDeliverable = smtp.IsDeliverable
+	result.Details = map[string]interface{}{
+		"syntax_valid": output.Syntax.IsValidSyntax,
+		"mx_accepts":   mx.AcceptsMail,
+		"is_catch_all": smtp.IsCatchAll,
+	}
+
+	if result.IsReachable == "unknown" {
+		result.Failure = smtpFailure(result.SMTPError)
+	}
+
+	return result, nil
+}
+
+// smtpFailure classifies the SMTP error behind an unknown result. 5xx replies are
+// permanent; 4xx replies (typically greylisting) and errors without a reply code,
+// such as connection timeouts, are worth retrying.
+func smtpFailure(checkErr *CheckError) *Failure {
+	if checkErr == nil {
+		return nil
+	}
+
+	greylisted := (checkErr.Code >= 400 && checkErr.Code < 500) ||
+		strings.Contains(strings.ToLower(checkErr.Message), "greylist")
+
+	return &Failure{
+		Kind:       FailureSMTP,
+		Message:    checkErr.Message,
+		Transient:  checkErr.Code < 500,
+		Greylisted: greylisted,
+	}
+}
+
+// decodeSection decodes a result section into details and reports whether it was
+// present, or returns the error the section reports instead. Errors are serialized
+// either as {"error": {...}} or directly as {"type": ..., "message": ...}.
+func decodeSection(raw json.RawMessage, details interface{}) (bool, *CheckError, error) {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return false, nil, nil
+	}
+
+	var probe struct {
+		Error   *CheckError `json:"error"`
+		Type    string      `json:"type"`
+		Message string      `json:"message"`
+		Code    int         `json:"code"`
+	}
+	if err := json.Unmarshal(raw, &probe); err == nil {
+		checkErr := probe.Error
+		if checkErr == nil && probe.Type != "" {
+			checkErr = &CheckError{Type: probe.Type, Message: probe.Message, Code: probe.Code}
+		}
+		if checkErr != nil {
+			// A reported code is used as-is; otherwise it is read from the reply
+			if checkErr.Code != 0 {
+				return false, checkErr, nil
+			}
+			if match := smtpReplyCode.FindStringSubmatch(checkErr.Message); match != nil {
+				checkErr.Code, _ = strconv.Atoi(match[1])
+			}
+			return false, checkErr, nil
+		}
+	}
+
+	if err := json.Unmarshal(raw, details); err != nil {
+		return false, nil, err
+	}
+	return true, nil, nil
+}
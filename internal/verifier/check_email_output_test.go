@@ -0,0 +1,127 @@
+package verifier
+
+import (
+	"testing"
+)
+
+func TestParseCheckEmailOutput(t *testing.T) {
+	body := []byte(`{
+		"input": "john.doe@example.com",
+		"is_reachable": "risky",
+		"misc": {"is_disposable": false, "is_role_account": true, "gravatar_url": null},
+		"mx": {"accepts_mail": true, "records": ["mx1.example.com.", "mx2.example.com."]},
+		"smtp": {"can_connect_smtp": true, "has_full_inbox": false, "is_catch_all": true, "is_deliverable": true, "is_disabled": false},
+		"syntax": {"address": "john.doe@example.com", "domain": "example.com", "is_valid_syntax": true, "username": "john.doe"}
+	}`)
+
+	result, err := parseCheckEmailOutput(body)
+	if err != nil {
+		t.Fatalf("parseCheckEmailOutput() error = %v", err)
+	}
+
+	if result.Email != "john.doe@example.com" || result.IsReachable != "risky" {
+		t.Errorf("parseCheckEmailOutput() = %s/%s, want john.doe@example.com/risky", result.Email, result.IsReachable)
+	}
+	if !result.IsValid || !result.IsDeliverable {
+		t.Errorf("parseCheckEmailOutput() IsValid = %v, IsDeliverable = %v, want both true", result.IsValid, result.IsDeliverable)
+	}
+	if result.MX == nil || len(result.MX.Records) != 2 {
+		t.Errorf("parseCheckEmailOutput() MX = %+v, want 2 records", result.MX)
+	}
+	if result.SMTP == nil || !result.SMTP.IsCatchAll || !result.SMTP.CanConnectSMTP {
+		t.Errorf("parseCheckEmailOutput() SMTP = %+v, want catch-all and connectable", result.SMTP)
+	}
+	if result.Misc == nil || !result.Misc.IsRoleAccount {
+		t.Errorf("parseCheckEmailOutput() Misc = %+v, want role account", result.Misc)
+	}
+	if result.Syntax == nil || result.Syntax.Username != "john.doe" {
+		t.Errorf("parseCheckEmailOutput() Syntax = %+v, want username john.doe", result.Syntax)
+	}
+}
+
+func TestParseCheckEmailOutput_SectionErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		wantType       string
+		wantCode       int
+		wantTransient  bool
+		wantGreylisted bool
+	}{
+		{
+			name:           "nested error object",
+			body:           `{"input": "a@example.com", "is_reachable": "unknown", "smtp": {"error": {"type": "SmtpError", "message": "451 4.7.1 Greylisted, please try again later"}}}`,
+			wantType:       "SmtpError",
+			wantCode:       451,
+			wantTransient:  true,
+			wantGreylisted: true,
+		},
+		{
+			name:           "flat error object",
+			body:           `[{"input": "a@example.com", "is_reachable": "unknown", "smtp": {"type": "Timeout", "message": "connection timed out"}}]`,
+			wantType:       "Timeout",
+			wantCode:       0,
+			wantTransient:  true,
+			wantGreylisted: false,
+		},
+		{
+			name:           "port in the message is not a reply code",
+			body:           `{"input": "a@example.com", "is_reachable": "unknown", "smtp": {"error": {"type": "IoError", "message": "connection to 203.0.113.7:465 refused"}}}`,
+			wantType:       "IoError",
+			wantCode:       0,
+			wantTransient:  true,
+			wantGreylisted: false,
+		},
+		{
+			name:           "reply after an address",
+			body:           `{"input": "a@example.com", "is_reachable": "unknown", "smtp": {"error": {"type": "SmtpError", "message": "Mail server 203.0.113.7:465 responded: 550 5.1.1 User unknown"}}}`,
+			wantType:       "SmtpError",
+			wantCode:       550,
+			wantTransient:  false,
+			wantGreylisted: false,
+		},
+		{
+			name:           "reply on its own line",
+			body:           `{"input": "a@example.com", "is_reachable": "unknown", "smtp": {"error": {"type": "SmtpError", "message": "RCPT TO rejected by 198.51.100.20\n421-4.7.0 Try again later"}}}`,
+			wantType:       "SmtpError",
+			wantCode:       421,
+			wantTransient:  true,
+			wantGreylisted: true,
+		},
+		{
+			name:           "code field",
+			body:           `{"input": "a@example.com", "is_reachable": "unknown", "smtp": {"type": "SmtpError", "message": "rejected: 421 try later", "code": 554}}`,
+			wantType:       "SmtpError",
+			wantCode:       554,
+			wantTransient:  false,
+			wantGreylisted: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseCheckEmailOutput([]byte(tt.body))
+			if err != nil {
+				t.Fatalf("parseCheckEmailOutput() error = %v", err)
+			}
+			if result.SMTP != nil {
+				t.Errorf("parseCheckEmailOutput() SMTP = %+v, want nil", result.SMTP)
+			}
+			if result.SMTPError == nil {
+				t.Fatalf("parseCheckEmailOutput() SMTPError = nil, want %s", tt.wantType)
+			}
+			if result.SMTPError.Type != tt.wantType {
+				t.Errorf("parseCheckEmailOutput() SMTPError.Type = %v, want %v", result.SMTPError.Type, tt.wantType)
+			}
+			if result.SMTPError.Code != tt.wantCode {
+				t.Errorf("parseCheckEmailOutput() SMTPError.Code = %v, want %v", result.SMTPError.Code, tt.wantCode)
+			}
+			if result.IsTransientFailure() != tt.wantTransient {
+				t.Errorf("parseCheckEmailOutput() IsTransientFailure() = %v, want %v", result.IsTransientFailure(), tt.wantTransient)
+			}
+			if result.Failure.Greylisted != tt.wantGreylisted {
+				t.Errorf("parseCheckEmailOutput() Failure.Greylisted = %v, want %v", result.Failure.Greylisted, tt.wantGreylisted)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package verifier
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrCircuitOpen is returned when a verification backend is failing and calls
+// are rejected without trying it
+var ErrCircuitOpen = errors.New("verification backend unavailable: circuit breaker open")
+
+// Circuit breaker states
+const (
+	CircuitClosed   = "closed"
+	CircuitOpen     = "open"
+	CircuitHalfOpen = "half_open"
+)
+
+// CircuitBreaker stops calls to a backend after consecutive failures, so callers
+// fail fast instead of each waiting for a timeout. After openTimeout a single
+// probe call is let through (half-open); its outcome closes or re-opens the circuit.
+type CircuitBreaker struct {
+	name        string
+	threshold   int
+	openTimeout time.Duration
+	logger      *zap.Logger
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates a new circuit breaker that opens after threshold
+// consecutive failures and probes again after openTimeout
+func NewCircuitBreaker(name string, threshold int, openTimeout time.Duration, logger *zap.Logger) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = 5 // Default threshold
+	}
+	return &CircuitBreaker{
+		name:        name,
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		logger:      logger,
+		state:       CircuitClosed,
+	}
+}
+
+// Allow returns ErrCircuitOpen if a call must not be made. Every allowed call
+// must be followed by Success, Failure or Ignore.
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return ErrCircuitOpen
+		}
+		b.setState(CircuitHalfOpen)
+		b.probing = true
+		return nil
+	case CircuitHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Success records a call that reached a working backend
+func (b *CircuitBreaker) Success() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.probing = false
+	if b.state != CircuitClosed {
+		b.setState(CircuitClosed)
+	}
+}
+
+// Failure records a call that failed because of the backend
+func (b *CircuitBreaker) Failure() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.state == CircuitHalfOpen || (b.state == CircuitClosed && b.failures >= b.threshold) {
+		b.openedAt = time.Now()
+		b.setState(CircuitOpen)
+	}
+}
+
+// Ignore records a call whose outcome says nothing about the backend, such as
+// one cancelled by the caller
+func (b *CircuitBreaker) Ignore() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// State returns the current state of the circuit
+func (b *CircuitBreaker) State() string {
+	if b == nil {
+		return CircuitClosed
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// setState changes state and logs the transition. Callers must hold b.mu.
+func (b *CircuitBreaker) setState(state string) {
+	b.logger.Warn("circuit breaker state changed",
+		zap.String("backend", b.name),
+		zap.String("from", b.state),
+		zap.String("to", state),
+		zap.Int("consecutive_failures", b.failures),
+	)
+	b.state = state
+}
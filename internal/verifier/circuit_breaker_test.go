@@ -0,0 +1,77 @@
+package verifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := NewCircuitBreaker("test", 3, 20*time.Millisecond, zap.NewNop())
+
+	// Failures below the threshold keep the circuit closed
+	for i := 0; i < 2; i++ {
+		if err := breaker.Allow(); err != nil {
+			t.Fatalf("Allow() error = %v, want nil", err)
+		}
+		breaker.Failure()
+	}
+	if breaker.State() != CircuitClosed {
+		t.Fatalf("State() = %v, want %v", breaker.State(), CircuitClosed)
+	}
+
+	// The third consecutive failure opens it
+	breaker.Allow()
+	breaker.Failure()
+	if breaker.State() != CircuitOpen {
+		t.Fatalf("State() = %v, want %v", breaker.State(), CircuitOpen)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Allow() error = %v, want ErrCircuitOpen", err)
+	}
+
+	// After the open timeout exactly one probe is let through
+	time.Sleep(25 * time.Millisecond)
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("Allow() probe error = %v, want nil", err)
+	}
+	if breaker.State() != CircuitHalfOpen {
+		t.Fatalf("State() = %v, want %v", breaker.State(), CircuitHalfOpen)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Allow() second probe error = %v, want ErrCircuitOpen", err)
+	}
+
+	// A failed probe re-opens the circuit
+	breaker.Failure()
+	if breaker.State() != CircuitOpen {
+		t.Fatalf("State() = %v, want %v", breaker.State(), CircuitOpen)
+	}
+
+	// A successful probe closes it
+	time.Sleep(25 * time.Millisecond)
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("Allow() probe error = %v, want nil", err)
+	}
+	breaker.Success()
+	if breaker.State() != CircuitClosed {
+		t.Fatalf("State() = %v, want %v", breaker.State(), CircuitClosed)
+	}
+}
+
+func TestVerifyBatch_CircuitOpen(t *testing.T) {
+	breaker := NewCircuitBreaker("test", 1, time.Minute, zap.NewNop())
+	breaker.Allow()
+	breaker.Failure()
+
+	v := NewHTTPVerifier("http://127.0.0.1:0", "/v0/check_email", time.Second, 2, zap.NewNop())
+	v.SetCircuitBreaker(breaker)
+
+	_, err := v.VerifyEmailsBatch(context.Background(), []string{"a@example.com", "b@example.com"})
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("VerifyEmailsBatch() error = %v, want ErrCircuitOpen", err)
+	}
+}
@@ -0,0 +1,183 @@
+package verifier
+
+import (
+	"context"
+	"email-finder/internal/metrics"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// CoalescingVerifier makes concurrent verifications of the same address share
+// one check of another verifier. Overlapping lookups, such as bulk rows for the
+// same company, generate many of the same candidates; without coalescing each
+// of them is checked again while the first check is still running.
+//
+// Every address is checked on its own, so a caller sharing one address of a
+// batch gets its result as soon as that address is done. The checks still
+// queue on the inner verifier's worker pool.
+type CoalescingVerifier struct {
+	inner  Verifier
+	logger *zap.Logger
+
+	mu sync.Mutex
+	// inflight maps a normalized address to its check in progress
+	inflight map[string]*inflightCheck
+}
+
+// inflightCheck is one call to the inner verifier for one address
+type inflightCheck struct {
+	key string
+	// priority is the priority the check was queued at
+	priority Priority
+	cancel   context.CancelFunc
+
+	// waiters is the number of callers waiting for the check; it is cancelled
+	// when all of them have given up
+	waiters int
+
+	done   chan struct{}
+	result *VerificationResult
+	err    error
+}
+
+// NewCoalescingVerifier creates a coalescing verifier around inner
+func NewCoalescingVerifier(inner Verifier, logger *zap.Logger) *CoalescingVerifier {
+	return &CoalescingVerifier{
+		inner:    inner,
+		logger:   logger,
+		inflight: make(map[string]*inflightCheck),
+	}
+}
+
+// VerifyEmail verifies a single email, sharing a check already in progress
+func (v *CoalescingVerifier) VerifyEmail(ctx context.Context, email string) (*VerificationResult, error) {
+	results, err := v.verify(ctx, []string{email}, true)
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// VerifyEmailsBatch verifies multiple emails. Addresses already being checked
+// wait for those checks; the others are checked one by one.
+func (v *CoalescingVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*VerificationResult, error) {
+	return v.verify(ctx, emails, false)
+}
+
+func (v *CoalescingVerifier) verify(ctx context.Context, emails []string, single bool) ([]*VerificationResult, error) {
+	priority := PriorityFromContext(ctx)
+	checks := make([]*inflightCheck, len(emails))
+	waiting := make(map[*inflightCheck]bool)
+	started := 0
+
+	v.mu.Lock()
+	for i, email := range emails {
+		key := normalizeEmail(email)
+		check, exists := v.inflight[key]
+		// A caller doesn't wait behind a check queued at a lower priority,
+		// such as an interactive lookup behind a bulk row; later callers
+		// share its own check instead
+		if exists && check.priority > priority {
+			exists = false
+		}
+		if !exists {
+			// The check outlives the caller that started it, but keeps its
+			// values, such as the priority and the trace
+			runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+			check = &inflightCheck{key: key, priority: priority, cancel: cancel, done: make(chan struct{})}
+			v.inflight[key] = check
+			go v.run(runCtx, check, email, single)
+			started++
+		}
+		metrics.ObserveCacheLookup("verification_inflight", exists && !waiting[check])
+		checks[i] = check
+
+		if !waiting[check] {
+			waiting[check] = true
+			check.waiters++
+		}
+	}
+	v.mu.Unlock()
+	defer v.leave(waiting)
+
+	if shared := len(emails) - started; shared > 0 {
+		v.logger.Debug("sharing verifications in progress", zap.Int("emails", shared))
+	}
+
+	results := make([]*VerificationResult, len(emails))
+	for i, check := range checks {
+		select {
+		case <-check.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if check.err != nil {
+			return nil, check.err
+		}
+
+		// Callers may spell the address differently; each gets it back as given
+		result := *check.result
+		result.Email = emails[i]
+		results[i] = &result
+	}
+	return results, nil
+}
+
+// run checks the address of a check and hands the result to everyone waiting
+func (v *CoalescingVerifier) run(ctx context.Context, check *inflightCheck, email string, single bool) {
+	defer check.cancel()
+
+	var result *VerificationResult
+	var err error
+	if single {
+		result, err = v.inner.VerifyEmail(ctx, email)
+	} else {
+		var results []*VerificationResult
+		results, err = v.inner.VerifyEmailsBatch(ctx, []string{email})
+		if err == nil && len(results) != 1 {
+			err = fmt.Errorf("verifier returned %d results for 1 email", len(results))
+		}
+		if err == nil {
+			result = results[0]
+		}
+	}
+
+	v.mu.Lock()
+	v.forget(check)
+	v.mu.Unlock()
+
+	check.result, check.err = result, err
+	close(check.done)
+}
+
+// leave stops waiting for checks, cancelling those nobody waits for anymore
+func (v *CoalescingVerifier) leave(checks map[*inflightCheck]bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for check := range checks {
+		check.waiters--
+		if check.waiters == 0 {
+			// Later callers start a new check instead of joining a cancelled one
+			check.cancel()
+			v.forget(check)
+		}
+	}
+}
+
+// forget removes a check from the checks in progress, unless it was already
+// replaced. Callers must hold v.mu.
+func (v *CoalescingVerifier) forget(check *inflightCheck) {
+	if v.inflight[check.key] == check {
+		delete(v.inflight, check.key)
+	}
+}
+
+// normalizeEmail makes addresses that only differ in case or surrounding spaces
+// share a check
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
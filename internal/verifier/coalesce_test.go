@@ -0,0 +1,337 @@
+package verifier
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// blockingVerifier records the batches it is asked for and holds them until released
+type blockingVerifier struct {
+	mu      sync.Mutex
+	batches [][]string
+	release chan struct{}
+	err     error
+}
+
+func newBlockingVerifier() *blockingVerifier {
+	return &blockingVerifier{release: make(chan struct{})}
+}
+
+func (b *blockingVerifier) VerifyEmail(ctx context.Context, email string) (*VerificationResult, error) {
+	results, err := b.VerifyEmailsBatch(ctx, []string{email})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (b *blockingVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*VerificationResult, error) {
+	b.mu.Lock()
+	b.batches = append(b.batches, emails)
+	b.mu.Unlock()
+
+	select {
+	case <-b.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if b.err != nil {
+		return nil, b.err
+	}
+	results := make([]*VerificationResult, len(emails))
+	for i, email := range emails {
+		results[i] = &VerificationResult{Email: email, IsReachable: "safe"}
+	}
+	return results, nil
+}
+
+func (b *blockingVerifier) calls() [][]string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return slices.Clone(b.batches)
+}
+
+// waitForCalls waits until the inner verifier was called n times
+func (b *blockingVerifier) waitForCalls(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for len(b.calls()) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("inner verifier called %d times, want %d", len(b.calls()), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// waitForWaiters waits until n callers wait for the check of email
+func waitForWaiters(t *testing.T, v *CoalescingVerifier, email string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		v.mu.Lock()
+		check, ok := v.inflight[email]
+		waiters := 0
+		if ok {
+			waiters = check.waiters
+		}
+		v.mu.Unlock()
+		if waiters == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%d callers did not join the check of %s in time", n, email)
+}
+
+func TestCoalescingVerifier_SharesConcurrentChecks(t *testing.T) {
+	inner := newBlockingVerifier()
+	v := NewCoalescingVerifier(inner, zap.NewNop())
+
+	spellings := []string{"john@acme.com", "John@Acme.com", " JOHN@ACME.COM "}
+	results := make([]*VerificationResult, len(spellings))
+	var wg sync.WaitGroup
+	for i, email := range spellings {
+		wg.Add(1)
+		go func(i int, email string) {
+			defer wg.Done()
+			results[i], _ = v.VerifyEmail(context.Background(), email)
+		}(i, email)
+	}
+	waitForWaiters(t, v, "john@acme.com", len(spellings))
+	close(inner.release)
+	wg.Wait()
+
+	if calls := inner.calls(); len(calls) != 1 {
+		t.Errorf("inner verifier called %d times, want 1", len(calls))
+	}
+	for i, result := range results {
+		if result == nil || result.Email != spellings[i] || result.IsReachable != "safe" {
+			t.Errorf("result %d = %+v, want safe for %q", i, result, spellings[i])
+		}
+	}
+}
+
+func TestCoalescingVerifier_OverlappingBatches(t *testing.T) {
+	inner := newBlockingVerifier()
+	v := NewCoalescingVerifier(inner, zap.NewNop())
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		v.VerifyEmailsBatch(context.Background(), []string{"a@acme.com", "b@acme.com"})
+	}()
+	inner.waitForCalls(t, 1)
+
+	var second []*VerificationResult
+	go func() {
+		defer wg.Done()
+		second, _ = v.VerifyEmailsBatch(context.Background(), []string{"b@acme.com", "c@acme.com", "c@acme.com"})
+	}()
+	inner.waitForCalls(t, 2)
+	waitForWaiters(t, v, "b@acme.com", 2)
+	close(inner.release)
+	wg.Wait()
+
+	calls := inner.calls()
+	checked := make([]string, 0, len(calls))
+	for _, call := range calls {
+		checked = append(checked, call...)
+	}
+	slices.Sort(checked)
+	if !slices.Equal(checked, []string{"a@acme.com", "b@acme.com", "c@acme.com"}) {
+		t.Errorf("inner verifier checked %v, want a, b and c once each", checked)
+	}
+	if len(second) != 3 || second[0].Email != "b@acme.com" || second[2].Email != "c@acme.com" {
+		t.Errorf("second batch = %+v, want results for b, c and c in order", second)
+	}
+}
+
+// addressVerifier holds the check of each address until that address is released
+type addressVerifier struct {
+	mu    sync.Mutex
+	gates map[string]chan struct{}
+	calls int
+}
+
+func newAddressVerifier(emails ...string) *addressVerifier {
+	v := &addressVerifier{gates: make(map[string]chan struct{})}
+	for _, email := range emails {
+		v.gates[email] = make(chan struct{})
+	}
+	return v
+}
+
+func (a *addressVerifier) VerifyEmail(ctx context.Context, email string) (*VerificationResult, error) {
+	results, err := a.VerifyEmailsBatch(ctx, []string{email})
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (a *addressVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*VerificationResult, error) {
+	results := make([]*VerificationResult, len(emails))
+	for i, email := range emails {
+		a.mu.Lock()
+		a.calls++
+		gate := a.gates[email]
+		a.mu.Unlock()
+		select {
+		case <-gate:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		results[i] = &VerificationResult{Email: email, IsReachable: "safe"}
+	}
+	return results, nil
+}
+
+func (a *addressVerifier) count() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.calls
+}
+
+func TestCoalescingVerifier_ReleasesEachAddressWhenDone(t *testing.T) {
+	inner := newAddressVerifier("a@acme.com", "b@acme.com")
+	v := NewCoalescingVerifier(inner, zap.NewNop())
+
+	batchDone := make(chan struct{})
+	go func() {
+		defer close(batchDone)
+		v.VerifyEmailsBatch(context.Background(), []string{"a@acme.com", "b@acme.com"})
+	}()
+	waitForWaiters(t, v, "a@acme.com", 1)
+
+	single := make(chan *VerificationResult, 1)
+	go func() {
+		result, _ := v.VerifyEmail(context.Background(), "a@acme.com")
+		single <- result
+	}()
+	waitForWaiters(t, v, "a@acme.com", 2)
+
+	// Only a is done: the caller sharing it doesn't wait for b
+	close(inner.gates["a@acme.com"])
+	select {
+	case result := <-single:
+		if result == nil || result.IsReachable != "safe" {
+			t.Errorf("shared result = %+v, want safe", result)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("the shared address was held until the rest of the batch was done")
+	}
+
+	close(inner.gates["b@acme.com"])
+	<-batchDone
+	if n := inner.count(); n != 2 {
+		t.Errorf("inner verifier checked %d addresses, want 2", n)
+	}
+}
+
+func TestCoalescingVerifier_InteractiveDoesNotJoinBulk(t *testing.T) {
+	inner := newAddressVerifier("john@acme.com")
+	v := NewCoalescingVerifier(inner, zap.NewNop())
+	bulk := WithPriority(context.Background(), PriorityBulk)
+
+	var wg sync.WaitGroup
+	verify := func(ctx context.Context) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v.VerifyEmail(ctx, "john@acme.com")
+		}()
+	}
+
+	verify(bulk)
+	waitForWaiters(t, v, "john@acme.com", 1)
+
+	// The interactive caller starts its own check, which later bulk callers share
+	verify(context.Background())
+	deadline := time.Now().Add(2 * time.Second)
+	for inner.count() < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	verify(bulk)
+	waitForWaiters(t, v, "john@acme.com", 2)
+
+	close(inner.gates["john@acme.com"])
+	wg.Wait()
+	if n := inner.count(); n != 2 {
+		t.Errorf("inner verifier called %d times, want 2: one bulk and one interactive check", n)
+	}
+}
+
+func TestCoalescingVerifier_Cancellation(t *testing.T) {
+	inner := newBlockingVerifier()
+	v := NewCoalescingVerifier(inner, zap.NewNop())
+
+	// The caller that started the check gives up, another one still waits for it
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := v.VerifyEmail(firstCtx, "john@acme.com")
+		firstErr <- err
+	}()
+	waitForWaiters(t, v, "john@acme.com", 1)
+
+	secondCtx, cancelSecond := context.WithCancel(context.Background())
+	secondErr := make(chan error, 1)
+	go func() {
+		_, err := v.VerifyEmail(secondCtx, "john@acme.com")
+		secondErr <- err
+	}()
+	waitForWaiters(t, v, "john@acme.com", 2)
+
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("first caller error = %v, want context.Canceled", err)
+	}
+	waitForWaiters(t, v, "john@acme.com", 1)
+
+	// Once nobody waits, the check is cancelled and the next caller starts a new one
+	cancelSecond()
+	<-secondErr
+	v.mu.Lock()
+	remaining := len(v.inflight)
+	v.mu.Unlock()
+	if remaining != 0 {
+		t.Errorf("%d checks in progress after every caller gave up, want 0", remaining)
+	}
+
+	close(inner.release)
+	if result, err := v.VerifyEmail(context.Background(), "john@acme.com"); err != nil || result.IsReachable != "safe" {
+		t.Errorf("VerifyEmail() after cancellation = %+v, %v, want a new check", result, err)
+	}
+	if calls := inner.calls(); len(calls) != 2 {
+		t.Errorf("inner verifier called %d times, want 2", len(calls))
+	}
+}
+
+func TestCoalescingVerifier_SharesErrors(t *testing.T) {
+	inner := newBlockingVerifier()
+	inner.err = ErrCircuitOpen
+	v := NewCoalescingVerifier(inner, zap.NewNop())
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := v.VerifyEmailsBatch(context.Background(), []string{"john@acme.com"})
+			errs <- err
+		}()
+	}
+	waitForWaiters(t, v, "john@acme.com", 2)
+	close(inner.release)
+
+	for i := 0; i < 2; i++ {
+		if err := <-errs; !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("error = %v, want ErrCircuitOpen", err)
+		}
+	}
+}
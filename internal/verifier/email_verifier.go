@@ -3,17 +3,28 @@ package verifier
 import (
 	"bytes"
 	"context"
+	"email-finder/internal/metrics"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"os/exec"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+var tracer = otel.Tracer("email-finder/internal/verifier")
+
 // VerificationResult represents the result of email verification
 type VerificationResult struct {
 	Email         string                 `json:"email"`
@@ -21,12 +32,60 @@ type VerificationResult struct {
 	IsValid       bool                   `json:"is_valid"`
 	IsDeliverable bool                   `json:"is_deliverable"`
 	Details       map[string]interface{} `json:"details,omitempty"`
+
+	// Full check-if-email-exists sections; nil when the backend did not report them
+	Syntax *SyntaxDetails `json:"syntax,omitempty"`
+	MX     *MXDetails     `json:"mx,omitempty"`
+	SMTP   *SMTPDetails   `json:"smtp,omitempty"`
+	Misc   *MiscDetails   `json:"misc,omitempty"`
+
+	// Errors reported by check-if-email-exists in place of a section
+	MXError   *CheckError `json:"mx_error,omitempty"`
+	SMTPError *CheckError `json:"smtp_error,omitempty"`
+	MiscError *CheckError `json:"misc_error,omitempty"`
+
+	// Failure explains why the result is unknown; nil when the check completed
+	Failure *Failure `json:"failure,omitempty"`
+}
+
+// Failure kinds
+const (
+	FailureTimeout = "timeout" // the check did not finish in time
+	FailureBackend = "backend" // the HTTP API or CLI failed
+	FailureSMTP    = "smtp"    // the recipient's mail server refused or deferred the check
+)
+
+// Failure describes why a verification could not reach a verdict
+type Failure struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+	// Transient failures may succeed if retried later
+	Transient bool `json:"transient"`
+	// Greylisted is set when the mail server temporarily deferred us (4xx reply)
+	Greylisted bool `json:"greylisted,omitempty"`
+}
+
+// IsTransientFailure reports whether the result is unknown because of a failure
+// that may go away when retried
+func (r *VerificationResult) IsTransientFailure() bool {
+	return r.Failure != nil && r.Failure.Transient
+}
+
+// unknownResult creates an unknown result for an email that could not be checked
+func unknownResult(email string, failure *Failure) *VerificationResult {
+	return &VerificationResult{
+		Email:         email,
+		IsReachable:   "unknown",
+		IsValid:       false,
+		IsDeliverable: false,
+		Failure:       failure,
+	}
 }
 
 // Verifier interface for email verification
 type Verifier interface {
-	VerifyEmail(email string) (*VerificationResult, error)
-	VerifyEmailsBatch(emails []string) ([]*VerificationResult, error)
+	VerifyEmail(ctx context.Context, email string) (*VerificationResult, error)
+	VerifyEmailsBatch(ctx context.Context, emails []string) ([]*VerificationResult, error)
 }
 
 // HTTPVerifier uses the check-if-email-exists HTTP API
@@ -35,16 +94,22 @@ type HTTPVerifier struct {
 	apiEndpoint string
 	client      *http.Client
 	logger      *zap.Logger
-	timeout     time.Duration
+	timeout     atomic.Int64 // time.Duration, changeable at runtime
 	concurrency int
+	breaker     *CircuitBreaker
+	throttler   *Throttler
+	pool        *WorkerPool
 }
 
 // CLIVerifier uses the check-if-email-exists CLI binary
 type CLIVerifier struct {
 	cliPath     string
 	logger      *zap.Logger
-	timeout     time.Duration
+	timeout     atomic.Int64 // time.Duration, changeable at runtime
 	concurrency int
+	breaker     *CircuitBreaker
+	throttler   *Throttler
+	pool        *WorkerPool
 }
 
 // NewHTTPVerifier creates a new HTTP-based verifier
@@ -52,16 +117,18 @@ func NewHTTPVerifier(apiURL, apiEndpoint string, timeout time.Duration, concurre
 	if concurrency <= 0 {
 		concurrency = 10 // Default concurrency
 	}
-	return &HTTPVerifier{
+	v := &HTTPVerifier{
 		apiURL:      apiURL,
 		apiEndpoint: apiEndpoint,
 		client: &http.Client{
-			Timeout: timeout,
+			// Propagates the trace context to the backend
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
 		},
 		logger:      logger,
-		timeout:     timeout,
 		concurrency: concurrency,
 	}
+	v.timeout.Store(int64(timeout))
+	return v
 }
 
 // NewCLIVerifier creates a new CLI-based verifier
@@ -69,16 +136,117 @@ func NewCLIVerifier(cliPath string, timeout time.Duration, concurrency int, logg
 	if concurrency <= 0 {
 		concurrency = 10 // Default concurrency
 	}
-	return &CLIVerifier{
+	v := &CLIVerifier{
 		cliPath:     cliPath,
 		logger:      logger,
-		timeout:     timeout,
 		concurrency: concurrency,
 	}
+	v.timeout.Store(int64(timeout))
+	return v
+}
+
+// SetTimeout changes the verification timeout of later checks
+func (v *HTTPVerifier) SetTimeout(timeout time.Duration) {
+	v.timeout.Store(int64(timeout))
+}
+
+// SetTimeout changes the verification timeout of later checks
+func (v *CLIVerifier) SetTimeout(timeout time.Duration) {
+	v.timeout.Store(int64(timeout))
+}
+
+// SetThrottler limits checks per recipient domain and MX host
+func (v *HTTPVerifier) SetThrottler(throttler *Throttler) {
+	v.throttler = throttler
+}
+
+// SetThrottler limits checks per recipient domain and MX host
+func (v *CLIVerifier) SetThrottler(throttler *Throttler) {
+	v.throttler = throttler
+}
+
+// SetWorkerPool runs batch checks on a pool shared with other verifiers, instead
+// of on up to concurrency goroutines per batch
+func (v *HTTPVerifier) SetWorkerPool(pool *WorkerPool) {
+	v.pool = pool
+}
+
+// SetWorkerPool runs batch checks on a pool shared with other verifiers, instead
+// of on up to concurrency goroutines per batch
+func (v *CLIVerifier) SetWorkerPool(pool *WorkerPool) {
+	v.pool = pool
+}
+
+// SetCircuitBreaker makes the verifier fail fast with ErrCircuitOpen while the API is down
+func (v *HTTPVerifier) SetCircuitBreaker(breaker *CircuitBreaker) {
+	v.breaker = breaker
+}
+
+// SetCircuitBreaker makes the verifier fail fast with ErrCircuitOpen while the CLI keeps crashing
+func (v *CLIVerifier) SetCircuitBreaker(breaker *CircuitBreaker) {
+	v.breaker = breaker
+}
+
+// HealthCheck reports an error if the check-if-email-exists API cannot be reached.
+// Any response below 500 counts as healthy, since the API has no dedicated health route.
+func (v *HTTPVerifier) HealthCheck(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", v.apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("verification API unreachable: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return fmt.Errorf("verification API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// HealthCheck reports an error if the check-if-email-exists CLI binary is missing or not executable
+func (v *CLIVerifier) HealthCheck(ctx context.Context) error {
+	info, err := os.Stat(v.cliPath)
+	if err != nil {
+		return fmt.Errorf("verification CLI unavailable: %w", err)
+	}
+	if info.IsDir() || info.Mode().Perm()&0o111 == 0 {
+		return fmt.Errorf("verification CLI %s is not executable", v.cliPath)
+	}
+	return nil
 }
 
 // VerifyEmail verifies a single email using HTTP API
-func (v *HTTPVerifier) VerifyEmail(email string) (*VerificationResult, error) {
+func (v *HTTPVerifier) VerifyEmail(ctx context.Context, email string) (result *VerificationResult, err error) {
+	ctx, span := startCheckSpan(ctx, v.apiURL, email)
+	defer func() { endCheckSpan(span, result, err) }()
+
+	release, err := v.throttler.Acquire(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	span.AddEvent("throttle acquired")
+
+	if err := v.breaker.Allow(); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	result, err = v.check(ctx, email)
+	observeVerification(v.apiURL, start, result, err)
+	return result, err
+}
+
+// check calls the API for one email and records the outcome with the circuit breaker
+func (v *HTTPVerifier) check(ctx context.Context, email string) (*VerificationResult, error) {
+	// The timeout is read per request so that it can be changed at runtime
+	reqCtx, cancel := context.WithTimeout(ctx, time.Duration(v.timeout.Load()))
+	defer cancel()
+
 	// Prepare request body
 	requestBody := map[string]interface{}{
 		"to_email": email,
@@ -86,13 +254,15 @@ func (v *HTTPVerifier) VerifyEmail(email string) (*VerificationResult, error) {
 
 	jsonData, err := json.Marshal(requestBody)
 	if err != nil {
+		v.breaker.Ignore()
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	// Make HTTP request
 	url := fmt.Sprintf("%s%s", v.apiURL, v.apiEndpoint)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(reqCtx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
+		v.breaker.Ignore()
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
@@ -100,12 +270,18 @@ func (v *HTTPVerifier) VerifyEmail(email string) (*VerificationResult, error) {
 
 	resp, err := v.client.Do(req)
 	if err != nil {
+		if ctx.Err() != nil {
+			v.breaker.Ignore()
+		} else {
+			v.breaker.Failure()
+		}
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
+		v.breaker.Failure()
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
@@ -115,284 +291,255 @@ func (v *HTTPVerifier) VerifyEmail(email string) (*VerificationResult, error) {
 			zap.Int("status", resp.StatusCode),
 			zap.String("response", string(body)),
 		)
-		return &VerificationResult{
-			Email:         email,
-			IsReachable:   "unknown",
-			IsValid:       false,
-			IsDeliverable: false,
-		}, nil
-	}
-
-	// Parse response
-	var apiResponse struct {
-		Input       string `json:"input"`
-		IsReachable string `json:"is_reachable"`
-		SMTP        struct {
-			IsDeliverable bool `json:"is_deliverable"`
-		} `json:"smtp"`
-		Syntax struct {
-			IsValidSyntax bool `json:"is_valid_syntax"`
-		} `json:"syntax"`
-		MX struct {
-			AcceptsMail bool `json:"accepts_mail"`
-		} `json:"mx"`
-	}
-
-	if err := json.Unmarshal(body, &apiResponse); err != nil {
-		// Try to parse as array (some APIs return array)
-		var responses []struct {
-			Input       string `json:"input"`
-			IsReachable string `json:"is_reachable"`
-			SMTP        struct {
-				IsDeliverable bool `json:"is_deliverable"`
-			} `json:"smtp"`
-			Syntax struct {
-				IsValidSyntax bool `json:"is_valid_syntax"`
-			} `json:"syntax"`
-			MX struct {
-				AcceptsMail bool `json:"accepts_mail"`
-			} `json:"mx"`
-		}
-
-		if err2 := json.Unmarshal(body, &responses); err2 != nil || len(responses) == 0 {
-			return nil, fmt.Errorf("failed to parse response: %w", err)
-		}
-		apiResponse = struct {
-			Input       string `json:"input"`
-			IsReachable string `json:"is_reachable"`
-			SMTP        struct {
-				IsDeliverable bool `json:"is_deliverable"`
-			} `json:"smtp"`
-			Syntax struct {
-				IsValidSyntax bool `json:"is_valid_syntax"`
-			} `json:"syntax"`
-			MX struct {
-				AcceptsMail bool `json:"accepts_mail"`
-			} `json:"mx"`
-		}{
-			Input:       responses[0].Input,
-			IsReachable: responses[0].IsReachable,
-			SMTP:        responses[0].SMTP,
-			Syntax:      responses[0].Syntax,
-			MX:          responses[0].MX,
+		// Server errors and throttling may clear up; other 4xx responses won't
+		transient := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		if resp.StatusCode >= 500 {
+			v.breaker.Failure()
+		} else {
+			v.breaker.Success()
 		}
+		return unknownResult(email, &Failure{
+			Kind:      FailureBackend,
+			Message:   fmt.Sprintf("verification API returned status %d", resp.StatusCode),
+			Transient: transient,
+		}), nil
 	}
+	v.breaker.Success()
 
-	result := &VerificationResult{
-		Email:         apiResponse.Input,
-		IsReachable:   apiResponse.IsReachable,
-		IsValid:       apiResponse.Syntax.IsValidSyntax && apiResponse.MX.AcceptsMail,
-		IsDeliverable: apiResponse.SMTP.IsDeliverable,
-		Details: map[string]interface{}{
-			"syntax_valid": apiResponse.Syntax.IsValidSyntax,
-			"mx_accepts":   apiResponse.MX.AcceptsMail,
-		},
+	// Parse response
+	result, err := parseCheckEmailOutput(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	return result, nil
 }
 
 // VerifyEmailsBatch verifies multiple emails in parallel
-func (v *HTTPVerifier) VerifyEmailsBatch(emails []string) ([]*VerificationResult, error) {
-	if len(emails) == 0 {
-		return []*VerificationResult{}, nil
-	}
-
-	results := make([]*VerificationResult, len(emails))
-	semaphore := make(chan struct{}, v.concurrency)
-	var wg sync.WaitGroup
+func (v *HTTPVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*VerificationResult, error) {
+	return verifyBatch(ctx, emails, v.pool, v.concurrency, v.throttler, v.logger, v.VerifyEmail)
+}
 
-	for i, email := range emails {
-		wg.Add(1)
-		go func(idx int, emailAddr string) {
-			defer wg.Done()
+// VerifyEmail verifies a single email using CLI
+func (v *CLIVerifier) VerifyEmail(ctx context.Context, email string) (*VerificationResult, error) {
+	// Use a shorter timeout per email to prevent hanging
+	emailTimeout := 10 * time.Second
+	if timeout := time.Duration(v.timeout.Load()); timeout < emailTimeout {
+		emailTimeout = timeout
+	}
 
-			// Acquire semaphore
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
+	return v.verify(ctx, email, emailTimeout)
+}
 
-			result, err := v.VerifyEmail(emailAddr)
-			if err != nil {
-				v.logger.Error("failed to verify email",
-					zap.String("email", emailAddr),
-					zap.Error(err),
-				)
-				result = &VerificationResult{
-					Email:         emailAddr,
-					IsReachable:   "unknown",
-					IsValid:       false,
-					IsDeliverable: false,
-				}
-			}
-			results[idx] = result
-		}(i, email)
+// VerifyEmailsBatch verifies multiple emails in parallel using CLI, with a shorter per-email timeout
+func (v *CLIVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*VerificationResult, error) {
+	// Use a shorter timeout per email to prevent slow verifications from blocking others
+	// Aggressively reduced to 3 seconds - most verifications complete in 1-2 seconds
+	// Slow verifications will timeout and be marked as unknown, allowing faster overall completion
+	perEmailTimeout := 3 * time.Second
+	if timeout := time.Duration(v.timeout.Load()); timeout < perEmailTimeout {
+		perEmailTimeout = timeout
 	}
 
-	wg.Wait()
-	return results, nil
+	return verifyBatch(ctx, emails, v.pool, v.concurrency, v.throttler, v.logger, func(ctx context.Context, email string) (*VerificationResult, error) {
+		return v.verify(ctx, email, perEmailTimeout)
+	})
 }
 
-// VerifyEmail verifies a single email using CLI
-func (v *CLIVerifier) VerifyEmail(email string) (*VerificationResult, error) {
-	// Use a shorter timeout per email to prevent hanging
-	emailTimeout := 10 * time.Second
-	if v.timeout < emailTimeout {
-		emailTimeout = v.timeout
+// verify runs the CLI for one email with the given timeout
+func (v *CLIVerifier) verify(ctx context.Context, email string, timeout time.Duration) (result *VerificationResult, err error) {
+	ctx, span := startCheckSpan(ctx, "cli", email)
+	defer func() { endCheckSpan(span, result, err) }()
+
+	release, err := v.throttler.Acquire(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	span.AddEvent("throttle acquired")
+
+	if err := v.breaker.Allow(); err != nil {
+		return nil, err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), emailTimeout)
+	start := time.Now()
+	result, err = v.run(ctx, email, timeout)
+	observeVerification("cli", start, result, err)
+	return result, err
+}
+
+// run executes the CLI for one email and records the outcome with the circuit breaker
+func (v *CLIVerifier) run(ctx context.Context, email string, timeout time.Duration) (*VerificationResult, error) {
+	emailCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	// Use exec.CommandContext for timeout support
-	cmd := exec.CommandContext(ctx, v.cliPath, email)
+	cmd := exec.CommandContext(emailCtx, v.cliPath, email)
+	done := metrics.CLIProcessStarted()
 	output, err := cmd.Output()
+	done()
 	if err != nil {
-		// Check if it's a timeout error
-		if ctx.Err() == context.DeadlineExceeded {
-			return &VerificationResult{
-				Email:         email,
-				IsReachable:   "unknown",
-				IsValid:       false,
-				IsDeliverable: false,
-			}, nil
+		// A slow SMTP server or a cancelled request says nothing about the CLI itself
+		if ctx.Err() != nil {
+			v.breaker.Ignore()
+			return nil, fmt.Errorf("failed to execute CLI: %w", ctx.Err())
 		}
+		if emailCtx.Err() == context.DeadlineExceeded {
+			v.breaker.Ignore()
+			return unknownResult(email, &Failure{
+				Kind:      FailureTimeout,
+				Message:   fmt.Sprintf("CLI did not finish within %s", timeout),
+				Transient: true,
+			}), nil
+		}
+		v.breaker.Failure()
 		return nil, fmt.Errorf("failed to execute CLI: %w", err)
 	}
 
 	// Parse JSON output from CLI
-	var apiResponse struct {
-		Input       string `json:"input"`
-		IsReachable string `json:"is_reachable"`
-		SMTP        struct {
-			IsDeliverable bool `json:"is_deliverable"`
-		} `json:"smtp"`
-		Syntax struct {
-			IsValidSyntax bool `json:"is_valid_syntax"`
-		} `json:"syntax"`
-		MX struct {
-			AcceptsMail bool `json:"accepts_mail"`
-		} `json:"mx"`
-	}
-
-	if err := json.Unmarshal(output, &apiResponse); err != nil {
+	result, err := parseCheckEmailOutput(output)
+	if err != nil {
+		v.breaker.Failure()
 		return nil, fmt.Errorf("failed to parse CLI output: %w", err)
 	}
+	v.breaker.Success()
 
-	result := &VerificationResult{
-		Email:         apiResponse.Input,
-		IsReachable:   apiResponse.IsReachable,
-		IsValid:       apiResponse.Syntax.IsValidSyntax && apiResponse.MX.AcceptsMail,
-		IsDeliverable: apiResponse.SMTP.IsDeliverable,
-		Details: map[string]interface{}{
-			"syntax_valid": apiResponse.Syntax.IsValidSyntax,
-			"mx_accepts":   apiResponse.MX.AcceptsMail,
-		},
+	return result, nil
+}
+
+// startCheckSpan starts the span covering one check of email against backend
+func startCheckSpan(ctx context.Context, backend, email string) (context.Context, trace.Span) {
+	return tracer.Start(ctx, "verifier.check", trace.WithAttributes(
+		attribute.String("backend", backend),
+		attribute.String("email", email),
+	))
+}
+
+// endCheckSpan records the outcome of a check on its span and ends it
+func endCheckSpan(span trace.Span, result *VerificationResult, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetAttributes(attribute.String("is_reachable", result.IsReachable))
+		if result.Failure != nil {
+			span.SetAttributes(
+				attribute.String("failure.kind", result.Failure.Kind),
+				attribute.String("failure.message", result.Failure.Message),
+			)
+		}
 	}
+	span.End()
+}
 
-	return result, nil
+// observeVerification records the outcome and latency of one check against backend
+func observeVerification(backend string, start time.Time, result *VerificationResult, err error) {
+	outcome := "error"
+	if err == nil {
+		outcome = result.IsReachable
+	}
+	metrics.ObserveVerification(backend, outcome, time.Since(start))
 }
 
-// VerifyEmailsBatch verifies multiple emails in parallel using CLI
-// Optimized: Inlines VerifyEmail to avoid function call overhead and uses per-email timeout
-func (v *CLIVerifier) VerifyEmailsBatch(emails []string) ([]*VerificationResult, error) {
+// verifyBatch verifies emails in parallel on pool, or with at most concurrency
+// checks at a time if pool is nil. Each check first waits for throttler, so a
+// check held back by a busy domain doesn't keep a worker from other requests.
+// Failed checks become unknown results; if any check was rejected by an open
+// circuit breaker, ErrCircuitOpen is returned. verify must not submit work to
+// pool itself, or the pool could deadlock.
+func verifyBatch(ctx context.Context, emails []string, pool *WorkerPool, concurrency int, throttler *Throttler, logger *zap.Logger, verify func(context.Context, string) (*VerificationResult, error)) ([]*VerificationResult, error) {
 	if len(emails) == 0 {
 		return []*VerificationResult{}, nil
 	}
 
 	results := make([]*VerificationResult, len(emails))
-	semaphore := make(chan struct{}, v.concurrency)
 	var wg sync.WaitGroup
-
-	// Use a shorter timeout per email to prevent slow verifications from blocking others
-	// Aggressively reduced to 3 seconds - most verifications complete in 1-2 seconds
-	// Slow verifications will timeout and be marked as unknown, allowing faster overall completion
-	perEmailTimeout := 3 * time.Second
-	if v.timeout < perEmailTimeout {
-		perEmailTimeout = v.timeout
+	var circuitOpen atomic.Bool
+
+	fail := func(idx int, err error) {
+		emailAddr := emails[idx]
+		if errors.Is(err, ErrCircuitOpen) {
+			circuitOpen.Store(true)
+		} else {
+			logger.Error("failed to verify email",
+				zap.String("email", emailAddr),
+				zap.Error(err),
+			)
+		}
+		results[idx] = unknownResult(emailAddr, &Failure{
+			Kind:      FailureBackend,
+			Message:   err.Error(),
+			Transient: ctx.Err() == nil && !errors.Is(err, ErrCircuitOpen),
+		})
 	}
 
-	for i, email := range emails {
-		wg.Add(1)
-		go func(idx int, emailAddr string) {
-			defer wg.Done()
-
-			// Acquire semaphore
-			semaphore <- struct{}{}
-			defer func() { <-semaphore }()
+	queuedAt := time.Now()
+	check := func(idx int) {
+		emailAddr := emails[idx]
 
-			// Inline verification to avoid function call overhead
-			ctx, cancel := context.WithTimeout(context.Background(), perEmailTimeout)
-			defer cancel()
+		// The span starts when the email is queued, so time spent waiting for
+		// the throttle and a worker shows up in the trace
+		ctx, span := tracer.Start(withThrottled(ctx, throttler), "verifier.VerifyEmail",
+			trace.WithTimestamp(queuedAt),
+			trace.WithAttributes(attribute.String("email", emailAddr)),
+		)
+		defer span.End()
+		span.AddEvent("worker acquired", trace.WithAttributes(
+			attribute.Int64("queue_wait_ms", time.Since(queuedAt).Milliseconds()),
+		))
+
+		result, err := verify(ctx, emailAddr)
+		if err != nil {
+			fail(idx, err)
+			return
+		}
+		results[idx] = result
+	}
 
-			cmd := exec.CommandContext(ctx, v.cliPath, emailAddr)
-			output, err := cmd.Output()
+	// start runs check(idx) once a worker is free
+	start := func(idx int, run func()) error {
+		if pool != nil {
+			return pool.Submit(ctx, run)
+		}
+		go run()
+		return nil
+	}
+	var semaphore chan struct{}
+	if pool == nil {
+		semaphore = make(chan struct{}, concurrency)
+	}
 
-			var result *VerificationResult
+	for i := range emails {
+		wg.Add(1)
+		go func(idx int) {
+			release, err := throttler.Acquire(ctx, emails[idx])
 			if err != nil {
-				if ctx.Err() == context.DeadlineExceeded {
-					// Timeout - mark as unknown
-					result = &VerificationResult{
-						Email:         emailAddr,
-						IsReachable:   "unknown",
-						IsValid:       false,
-						IsDeliverable: false,
-					}
-				} else {
-					v.logger.Error("failed to verify email",
-						zap.String("email", emailAddr),
-						zap.Error(err),
-					)
-					result = &VerificationResult{
-						Email:         emailAddr,
-						IsReachable:   "unknown",
-						IsValid:       false,
-						IsDeliverable: false,
-					}
-				}
-			} else {
-				// Parse JSON output from CLI
-				var apiResponse struct {
-					Input       string `json:"input"`
-					IsReachable string `json:"is_reachable"`
-					SMTP        struct {
-						IsDeliverable bool `json:"is_deliverable"`
-					} `json:"smtp"`
-					Syntax struct {
-						IsValidSyntax bool `json:"is_valid_syntax"`
-					} `json:"syntax"`
-					MX struct {
-						AcceptsMail bool `json:"accepts_mail"`
-					} `json:"mx"`
-				}
+				fail(idx, err)
+				wg.Done()
+				return
+			}
 
-				if err := json.Unmarshal(output, &apiResponse); err != nil {
-					v.logger.Error("failed to parse CLI output",
-						zap.String("email", emailAddr),
-						zap.Error(err),
-					)
-					result = &VerificationResult{
-						Email:         emailAddr,
-						IsReachable:   "unknown",
-						IsValid:       false,
-						IsDeliverable: false,
-					}
-				} else {
-					result = &VerificationResult{
-						Email:         apiResponse.Input,
-						IsReachable:   apiResponse.IsReachable,
-						IsValid:       apiResponse.Syntax.IsValidSyntax && apiResponse.MX.AcceptsMail,
-						IsDeliverable: apiResponse.SMTP.IsDeliverable,
-						Details: map[string]interface{}{
-							"syntax_valid": apiResponse.Syntax.IsValidSyntax,
-							"mx_accepts":   apiResponse.MX.AcceptsMail,
-						},
-					}
+			err = start(idx, func() {
+				defer wg.Done()
+				defer release()
+				if semaphore != nil {
+					semaphore <- struct{}{}
+					defer func() { <-semaphore }()
 				}
+				check(idx)
+			})
+			if err != nil {
+				release()
+				results[idx] = unknownResult(emails[idx], &Failure{Kind: FailureBackend, Message: err.Error()})
+				wg.Done()
 			}
-			results[idx] = result
-		}(i, email)
+		}(i)
 	}
 
 	wg.Wait()
+
+	if circuitOpen.Load() {
+		return nil, ErrCircuitOpen
+	}
 	return results, nil
 }
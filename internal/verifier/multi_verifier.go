@@ -0,0 +1,284 @@
+package verifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Load balancing strategies for MultiVerifier
+const (
+	StrategyRoundRobin    = "round_robin"
+	StrategyLeastInFlight = "least_in_flight"
+)
+
+// ErrNoBackend is returned when no verification backend is configured, or none
+// is healthy and the unhealthy ones failed too
+var ErrNoBackend = errors.New("no verification backend available")
+
+// HealthChecker is implemented by verifiers that can report whether their backend is usable
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// Backend is a named verifier that MultiVerifier can send checks to
+type Backend struct {
+	Name     string
+	Verifier Verifier
+	// Breaker is the circuit breaker used by Verifier, if any; only used for status
+	Breaker *CircuitBreaker
+}
+
+// BackendStatus describes the state of one backend
+type BackendStatus struct {
+	Name      string `json:"name"`
+	Healthy   bool   `json:"healthy"`
+	InFlight  int64  `json:"in_flight"`
+	Circuit   string `json:"circuit"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+// backendState tracks load and health of a backend
+type backendState struct {
+	Backend
+	inFlight atomic.Int64
+	healthy  atomic.Bool
+
+	mu        sync.Mutex
+	lastError string
+}
+
+// MultiVerifier spreads checks across several backends and fails over to the
+// next backend when one errors. Backends failing their periodic health check
+// are skipped while at least one healthy backend remains.
+type MultiVerifier struct {
+	backends    []*backendState
+	strategy    string
+	concurrency int
+	logger      *zap.Logger
+	pool        *WorkerPool
+	throttler   *Throttler
+	next        atomic.Uint64
+}
+
+// NewMultiVerifier creates a new verifier over the given backends
+func NewMultiVerifier(backends []Backend, strategy string, concurrency int, logger *zap.Logger) *MultiVerifier {
+	if concurrency <= 0 {
+		concurrency = 10 // Default concurrency
+	}
+	if strategy != StrategyLeastInFlight {
+		strategy = StrategyRoundRobin
+	}
+
+	states := make([]*backendState, 0, len(backends))
+	for _, b := range backends {
+		state := &backendState{Backend: b}
+		state.healthy.Store(true)
+		states = append(states, state)
+	}
+
+	return &MultiVerifier{
+		backends:    states,
+		strategy:    strategy,
+		concurrency: concurrency,
+		logger:      logger,
+	}
+}
+
+// SetWorkerPool runs batch checks on a shared pool instead of on up to
+// concurrency goroutines per batch
+func (m *MultiVerifier) SetWorkerPool(pool *WorkerPool) {
+	m.pool = pool
+}
+
+// SetThrottler makes batch checks wait for the throttler shared by the backends
+// before they take a worker
+func (m *MultiVerifier) SetThrottler(throttler *Throttler) {
+	m.throttler = throttler
+}
+
+// VerifyEmail verifies a single email, trying backends in load-balancing order
+// until one gives an answer
+func (m *MultiVerifier) VerifyEmail(ctx context.Context, email string) (*VerificationResult, error) {
+	if len(m.backends) == 0 {
+		return nil, ErrNoBackend
+	}
+
+	var lastResult *VerificationResult
+	var lastErr error
+	circuitOpen := 0
+
+	backends, healthy := m.order()
+	for _, b := range backends {
+		b.inFlight.Add(1)
+		result, err := b.Verifier.VerifyEmail(ctx, email)
+		b.inFlight.Add(-1)
+
+		if err == nil && (result.Failure == nil || result.Failure.Kind != FailureBackend) {
+			return result, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if errors.Is(err, ErrCircuitOpen) {
+			circuitOpen++
+			continue
+		}
+		if err != nil {
+			lastErr = err
+		} else {
+			lastResult = result
+		}
+		m.logger.Warn("verification backend failed, failing over",
+			zap.String("backend", b.Name),
+			zap.String("email", email),
+			zap.Error(err),
+		)
+	}
+
+	// Prefer an answer over an error: an unknown result still carries the failure
+	if lastResult != nil {
+		return lastResult, nil
+	}
+
+	var err error
+	switch {
+	case circuitOpen == len(m.backends):
+		err = ErrCircuitOpen
+	case lastErr != nil:
+		err = lastErr
+	default:
+		err = fmt.Errorf("all %d verification backends failed", len(m.backends))
+	}
+	if healthy == 0 {
+		// The cause is kept, so open circuits still fail fast as unavailable
+		return nil, fmt.Errorf("%w: every backend is unhealthy: %w", ErrNoBackend, err)
+	}
+	return nil, err
+}
+
+// VerifyEmailsBatch verifies multiple emails in parallel across the backends
+func (m *MultiVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*VerificationResult, error) {
+	return verifyBatch(ctx, emails, m.pool, m.concurrency, m.throttler, m.logger, m.VerifyEmail)
+}
+
+// HealthCheck reports an error if no backend is healthy
+func (m *MultiVerifier) HealthCheck(ctx context.Context) error {
+	for _, b := range m.backends {
+		if b.healthy.Load() {
+			return nil
+		}
+	}
+	return errors.New("no healthy verification backend")
+}
+
+// StartHealthChecks probes every backend that implements HealthChecker each
+// interval until ctx is done
+func (m *MultiVerifier) StartHealthChecks(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			m.checkBackends(ctx, interval)
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Status returns the state of every backend
+func (m *MultiVerifier) Status() []BackendStatus {
+	statuses := make([]BackendStatus, 0, len(m.backends))
+	for _, b := range m.backends {
+		b.mu.Lock()
+		lastError := b.lastError
+		b.mu.Unlock()
+
+		statuses = append(statuses, BackendStatus{
+			Name:      b.Name,
+			Healthy:   b.healthy.Load(),
+			InFlight:  b.inFlight.Load(),
+			Circuit:   b.Breaker.State(),
+			LastError: lastError,
+		})
+	}
+	return statuses
+}
+
+// checkBackends runs one round of health checks
+func (m *MultiVerifier) checkBackends(ctx context.Context, timeout time.Duration) {
+	for _, b := range m.backends {
+		checker, ok := b.Verifier.(HealthChecker)
+		if !ok {
+			continue
+		}
+
+		checkCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := checker.HealthCheck(checkCtx)
+		cancel()
+
+		healthy := err == nil
+		if b.healthy.Swap(healthy) != healthy {
+			m.logger.Warn("verification backend health changed",
+				zap.String("backend", b.Name),
+				zap.Bool("healthy", healthy),
+				zap.Error(err),
+			)
+		}
+
+		b.mu.Lock()
+		if err != nil {
+			b.lastError = err.Error()
+		} else {
+			b.lastError = ""
+		}
+		b.mu.Unlock()
+	}
+}
+
+// order returns the backends in the order they should be tried: healthy backends
+// by strategy, then unhealthy ones as a last resort. It also returns how many
+// are healthy.
+func (m *MultiVerifier) order() ([]*backendState, int) {
+	healthy := make([]*backendState, 0, len(m.backends))
+	unhealthy := make([]*backendState, 0)
+	for _, b := range m.backends {
+		if b.healthy.Load() {
+			healthy = append(healthy, b)
+		} else {
+			unhealthy = append(unhealthy, b)
+		}
+	}
+
+	if len(healthy) > 1 {
+		switch m.strategy {
+		case StrategyLeastInFlight:
+			// The counts change while checks start and finish, so sort one snapshot
+			inFlight := make(map[*backendState]int64, len(healthy))
+			for _, b := range healthy {
+				inFlight[b] = b.inFlight.Load()
+			}
+			sort.SliceStable(healthy, func(i, j int) bool {
+				return inFlight[healthy[i]] < inFlight[healthy[j]]
+			})
+		default:
+			start := int(m.next.Add(1) % uint64(len(healthy)))
+			rotated := make([]*backendState, 0, len(healthy))
+			rotated = append(rotated, healthy[start:]...)
+			healthy = append(rotated, healthy[:start]...)
+		}
+	}
+
+	return append(healthy, unhealthy...), len(healthy)
+}
@@ -0,0 +1,109 @@
+package verifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+// stubVerifier returns a fixed result or error and counts calls
+type stubVerifier struct {
+	result *VerificationResult
+	err    error
+	calls  int
+}
+
+func (s *stubVerifier) VerifyEmail(ctx context.Context, email string) (*VerificationResult, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	result := *s.result
+	result.Email = email
+	return &result, nil
+}
+
+func (s *stubVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*VerificationResult, error) {
+	return verifyBatch(ctx, emails, nil, 1, nil, zap.NewNop(), s.VerifyEmail)
+}
+
+func TestMultiVerifier_Failover(t *testing.T) {
+	down := &stubVerifier{err: errors.New("connection refused")}
+	up := &stubVerifier{result: &VerificationResult{IsReachable: "safe"}}
+
+	m := NewMultiVerifier([]Backend{
+		{Name: "down", Verifier: down},
+		{Name: "up", Verifier: up},
+	}, StrategyRoundRobin, 1, zap.NewNop())
+
+	for i := 0; i < 4; i++ {
+		result, err := m.VerifyEmail(context.Background(), "a@example.com")
+		if err != nil {
+			t.Fatalf("VerifyEmail() error = %v", err)
+		}
+		if result.IsReachable != "safe" {
+			t.Errorf("VerifyEmail() = %v, want safe", result.IsReachable)
+		}
+	}
+
+	if up.calls != 4 {
+		t.Errorf("healthy backend calls = %d, want 4", up.calls)
+	}
+	// Round robin starts on the failing backend half of the time
+	if down.calls != 2 {
+		t.Errorf("failing backend calls = %d, want 2", down.calls)
+	}
+}
+
+func TestMultiVerifier_AllCircuitsOpen(t *testing.T) {
+	m := NewMultiVerifier([]Backend{
+		{Name: "a", Verifier: &stubVerifier{err: ErrCircuitOpen}},
+		{Name: "b", Verifier: &stubVerifier{err: ErrCircuitOpen}},
+	}, StrategyLeastInFlight, 1, zap.NewNop())
+
+	if _, err := m.VerifyEmail(context.Background(), "a@example.com"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("VerifyEmail() error = %v, want ErrCircuitOpen", err)
+	}
+	if _, err := m.VerifyEmailsBatch(context.Background(), []string{"a@example.com"}); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("VerifyEmailsBatch() error = %v, want ErrCircuitOpen", err)
+	}
+}
+
+func TestMultiVerifier_NoBackend(t *testing.T) {
+	m := NewMultiVerifier(nil, StrategyRoundRobin, 1, zap.NewNop())
+	if _, err := m.VerifyEmail(context.Background(), "a@example.com"); !errors.Is(err, ErrNoBackend) || errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("VerifyEmail() without backends error = %v, want ErrNoBackend", err)
+	}
+
+	// Unhealthy backends are still tried; when they fail too, both causes are reported
+	m = NewMultiVerifier([]Backend{
+		{Name: "a", Verifier: &stubVerifier{err: ErrCircuitOpen}},
+		{Name: "b", Verifier: &stubVerifier{err: ErrCircuitOpen}},
+	}, StrategyRoundRobin, 1, zap.NewNop())
+	for _, b := range m.backends {
+		b.healthy.Store(false)
+	}
+	_, err := m.VerifyEmail(context.Background(), "a@example.com")
+	if !errors.Is(err, ErrNoBackend) || !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("VerifyEmail() with unhealthy backends error = %v, want ErrNoBackend and ErrCircuitOpen", err)
+	}
+}
+
+func TestMultiVerifier_LeastInFlight(t *testing.T) {
+	busy := &stubVerifier{result: &VerificationResult{IsReachable: "safe"}}
+	idle := &stubVerifier{result: &VerificationResult{IsReachable: "safe"}}
+	m := NewMultiVerifier([]Backend{
+		{Name: "busy", Verifier: busy},
+		{Name: "idle", Verifier: idle},
+	}, StrategyLeastInFlight, 1, zap.NewNop())
+	m.backends[0].inFlight.Store(5)
+
+	if _, err := m.VerifyEmail(context.Background(), "a@example.com"); err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	if idle.calls != 1 || busy.calls != 0 {
+		t.Errorf("calls = busy %d, idle %d, want only the idle backend", busy.calls, idle.calls)
+	}
+}
@@ -0,0 +1,186 @@
+package verifier
+
+import (
+	"context"
+	"email-finder/internal/metrics"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// recheckResultTTL is how long a deferred re-check result answers later lookups
+const recheckResultTTL = 24 * time.Hour
+
+// recheckResult is a completed deferred re-check
+type recheckResult struct {
+	result    *VerificationResult
+	checkedAt time.Time
+}
+
+// RecheckQueue re-verifies greylisted emails after the greylisting window has
+// passed. Greylisting servers reject the first delivery attempt from an unknown
+// sender with a 4xx reply and accept a retry a few minutes later, which is far
+// longer than a request can wait. Completed re-checks are kept so that later
+// lookups of the same email get the real verdict without verifying again.
+type RecheckQueue struct {
+	verifier    Verifier
+	delay       time.Duration
+	maxRechecks int
+	logger      *zap.Logger
+
+	mu      sync.Mutex
+	pending map[string]*time.Timer
+	results map[string]recheckResult
+	closed  bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewRecheckQueue creates a new re-check queue. Each email is re-checked after
+// delay, and again with doubling delays, up to maxRechecks times.
+func NewRecheckQueue(v Verifier, delay time.Duration, maxRechecks int, logger *zap.Logger) *RecheckQueue {
+	if maxRechecks <= 0 {
+		maxRechecks = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &RecheckQueue{
+		verifier:    v,
+		delay:       delay,
+		maxRechecks: maxRechecks,
+		logger:      logger,
+		pending:     make(map[string]*time.Timer),
+		results:     make(map[string]recheckResult),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Enqueue schedules a deferred re-check of email, unless one is already pending
+func (q *RecheckQueue) Enqueue(email string) {
+	if q == nil {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+	if _, exists := q.pending[email]; exists {
+		return
+	}
+
+	q.schedule(email, 1)
+	q.logger.Info("scheduled deferred re-check for greylisted email",
+		zap.String("email", email),
+		zap.Duration("delay", q.delay),
+	)
+}
+
+// Lookup returns the result of a completed re-check of email, if there is a recent one
+func (q *RecheckQueue) Lookup(email string) (*VerificationResult, bool) {
+	if q == nil {
+		return nil, false
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	entry, exists := q.results[email]
+	hit := exists && time.Since(entry.checkedAt) <= recheckResultTTL
+	metrics.ObserveCacheLookup("recheck", hit)
+	if !hit {
+		return nil, false
+	}
+	return entry.result, true
+}
+
+// Pending returns the number of emails waiting for a re-check
+func (q *RecheckQueue) Pending() int {
+	if q == nil {
+		return 0
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.pending)
+}
+
+// Close cancels all pending re-checks
+func (q *RecheckQueue) Close() {
+	if q == nil {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	q.cancel()
+	for email, timer := range q.pending {
+		timer.Stop()
+		delete(q.pending, email)
+	}
+}
+
+// schedule arms the timer for the given re-check of email. Callers must hold q.mu.
+func (q *RecheckQueue) schedule(email string, recheck int) {
+	delay := q.delay << uint(recheck-1)
+	q.pending[email] = time.AfterFunc(delay, func() {
+		q.run(email, recheck)
+	})
+}
+
+// run performs a re-check and either stores the verdict or schedules another attempt
+func (q *RecheckQueue) run(email string, recheck int) {
+	// Re-checks are background work, so they queue behind interactive lookups
+	var result *VerificationResult
+	results, err := q.verifier.VerifyEmailsBatch(WithPriority(q.ctx, PriorityBulk), []string{email})
+	if err == nil {
+		result = results[0]
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+
+	if err == nil && !result.IsTransientFailure() {
+		delete(q.pending, email)
+		q.prune()
+		q.results[email] = recheckResult{result: result, checkedAt: time.Now()}
+		q.logger.Info("deferred re-check completed",
+			zap.String("email", email),
+			zap.String("is_reachable", result.IsReachable),
+			zap.Int("recheck", recheck),
+		)
+		return
+	}
+
+	if recheck < q.maxRechecks {
+		q.schedule(email, recheck+1)
+		return
+	}
+
+	delete(q.pending, email)
+	q.logger.Warn("deferred re-check gave up",
+		zap.String("email", email),
+		zap.Int("rechecks", recheck),
+		zap.Error(err),
+	)
+}
+
+// prune drops re-check results that are too old to be used. Callers must hold q.mu.
+func (q *RecheckQueue) prune() {
+	for email, entry := range q.results {
+		if time.Since(entry.checkedAt) > recheckResultTTL {
+			delete(q.results, email)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package verifier
+
+import (
+	"context"
+	"email-finder/internal/backoff"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// RetryPolicy controls how transient verification failures are retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per email, including the first
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt; it doubles every attempt
+	BaseDelay time.Duration
+	// MaxDelay caps a single backoff
+	MaxDelay time.Duration
+	// Budget caps the total time spent on one call, retries included.
+	// No retry is started if its backoff would exceed the budget.
+	Budget time.Duration
+}
+
+// RetryVerifier retries transient failures of another verifier with exponential
+// backoff and jitter. Results that are still greylisted when the retries are used
+// up are handed to an optional RecheckQueue for a deferred re-check.
+type RetryVerifier struct {
+	inner   Verifier
+	policy  RetryPolicy
+	recheck *RecheckQueue
+	logger  *zap.Logger
+}
+
+// NewRetryVerifier creates a new retrying verifier around inner. recheck may be nil.
+func NewRetryVerifier(inner Verifier, policy RetryPolicy, recheck *RecheckQueue, logger *zap.Logger) *RetryVerifier {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+	return &RetryVerifier{
+		inner:   inner,
+		policy:  policy,
+		recheck: recheck,
+		logger:  logger,
+	}
+}
+
+// VerifyEmail verifies a single email, retrying transient failures
+func (v *RetryVerifier) VerifyEmail(ctx context.Context, email string) (*VerificationResult, error) {
+	if result, ok := v.recheck.Lookup(email); ok {
+		return result, nil
+	}
+
+	start := time.Now()
+	var result *VerificationResult
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		result, err = v.inner.VerifyEmail(ctx, email)
+		if err == nil && !result.IsTransientFailure() {
+			return result, nil
+		}
+		if errors.Is(err, ErrCircuitOpen) {
+			return nil, err
+		}
+		if attempt >= v.policy.MaxAttempts || !v.wait(ctx, start, attempt) {
+			break
+		}
+		v.logger.Debug("retrying email verification",
+			zap.String("email", email),
+			zap.Int("attempt", attempt+1),
+		)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	v.deferGreylisted(result)
+	return result, nil
+}
+
+// VerifyEmailsBatch verifies multiple emails, retrying the transient failures of
+// each round as a smaller batch
+func (v *RetryVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*VerificationResult, error) {
+	results := make([]*VerificationResult, len(emails))
+
+	// Answers from the deferred re-check queue don't need to be verified again
+	pending := make([]int, 0, len(emails))
+	for i, email := range emails {
+		if result, ok := v.recheck.Lookup(email); ok {
+			results[i] = result
+		} else {
+			pending = append(pending, i)
+		}
+	}
+
+	start := time.Now()
+	for attempt := 1; len(pending) > 0; attempt++ {
+		batch := make([]string, len(pending))
+		for i, idx := range pending {
+			batch[i] = emails[idx]
+		}
+
+		batchResults, err := v.inner.VerifyEmailsBatch(ctx, batch)
+		if err != nil {
+			return nil, err
+		}
+
+		retry := make([]int, 0)
+		for i, idx := range pending {
+			results[idx] = batchResults[i]
+			if batchResults[i].IsTransientFailure() {
+				retry = append(retry, idx)
+			}
+		}
+		pending = retry
+
+		if len(pending) == 0 || attempt >= v.policy.MaxAttempts || !v.wait(ctx, start, attempt) {
+			break
+		}
+		v.logger.Info("retrying transient verification failures",
+			zap.Int("emails", len(pending)),
+			zap.Int("attempt", attempt+1),
+		)
+	}
+
+	for _, idx := range pending {
+		v.deferGreylisted(results[idx])
+	}
+
+	return results, nil
+}
+
+// wait sleeps for the backoff after the given attempt. It returns false without
+// sleeping if the backoff would exceed the budget, or if ctx ends while waiting.
+func (v *RetryVerifier) wait(ctx context.Context, start time.Time, attempt int) bool {
+	delay := backoff.Exponential(v.policy.BaseDelay, v.policy.MaxDelay, attempt)
+	if v.policy.Budget > 0 && time.Since(start)+delay > v.policy.Budget {
+		return false
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// deferGreylisted hands a still-greylisted result to the re-check queue
+func (v *RetryVerifier) deferGreylisted(result *VerificationResult) {
+	if result != nil && result.Failure != nil && result.Failure.Greylisted {
+		v.recheck.Enqueue(result.Email)
+	}
+}
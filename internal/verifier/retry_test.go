@@ -0,0 +1,142 @@
+package verifier
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// flakyVerifier fails transiently for the first failures calls per email
+type flakyVerifier struct {
+	mu       sync.Mutex
+	calls    map[string]int
+	failures int
+	failure  Failure
+}
+
+func newFlakyVerifier(failures int, failure Failure) *flakyVerifier {
+	return &flakyVerifier{calls: make(map[string]int), failures: failures, failure: failure}
+}
+
+func (f *flakyVerifier) VerifyEmail(ctx context.Context, email string) (*VerificationResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls[email]++
+	if f.calls[email] <= f.failures {
+		failure := f.failure
+		return unknownResult(email, &failure), nil
+	}
+	return &VerificationResult{Email: email, IsReachable: "safe", IsValid: true, IsDeliverable: true}, nil
+}
+
+func (f *flakyVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*VerificationResult, error) {
+	results := make([]*VerificationResult, len(emails))
+	for i, email := range emails {
+		results[i], _ = f.VerifyEmail(ctx, email)
+	}
+	return results, nil
+}
+
+func TestRetryVerifier_VerifyEmailsBatch(t *testing.T) {
+	logger := zap.NewNop()
+	transient := Failure{Kind: FailureTimeout, Transient: true}
+	permanent := Failure{Kind: FailureSMTP, Transient: false}
+
+	tests := []struct {
+		name        string
+		failures    int
+		failure     Failure
+		policy      RetryPolicy
+		wantStatus  string
+		wantAttempt int
+	}{
+		{
+			name:        "recovers after transient failures",
+			failures:    2,
+			failure:     transient,
+			policy:      RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond},
+			wantStatus:  "safe",
+			wantAttempt: 3,
+		},
+		{
+			name:        "gives up after max attempts",
+			failures:    5,
+			failure:     transient,
+			policy:      RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond},
+			wantStatus:  "unknown",
+			wantAttempt: 2,
+		},
+		{
+			name:        "does not retry permanent failures",
+			failures:    1,
+			failure:     permanent,
+			policy:      RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond},
+			wantStatus:  "unknown",
+			wantAttempt: 1,
+		},
+		{
+			name:        "stops when the budget is spent",
+			failures:    5,
+			failure:     transient,
+			policy:      RetryPolicy{MaxAttempts: 5, BaseDelay: time.Second, MaxDelay: time.Second, Budget: 10 * time.Millisecond},
+			wantStatus:  "unknown",
+			wantAttempt: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := newFlakyVerifier(tt.failures, tt.failure)
+			v := NewRetryVerifier(inner, tt.policy, nil, logger)
+
+			results, err := v.VerifyEmailsBatch(context.Background(), []string{"a@example.com", "b@example.com"})
+			if err != nil {
+				t.Fatalf("VerifyEmailsBatch() error = %v", err)
+			}
+			for _, result := range results {
+				if result.IsReachable != tt.wantStatus {
+					t.Errorf("VerifyEmailsBatch() %s = %v, want %v", result.Email, result.IsReachable, tt.wantStatus)
+				}
+				if inner.calls[result.Email] != tt.wantAttempt {
+					t.Errorf("VerifyEmailsBatch() %s attempts = %v, want %v", result.Email, inner.calls[result.Email], tt.wantAttempt)
+				}
+			}
+		})
+	}
+}
+
+func TestRecheckQueue(t *testing.T) {
+	inner := newFlakyVerifier(1, Failure{Kind: FailureSMTP, Transient: true, Greylisted: true})
+	queue := NewRecheckQueue(inner, 10*time.Millisecond, 2, zap.NewNop())
+	defer queue.Close()
+
+	v := NewRetryVerifier(inner, RetryPolicy{MaxAttempts: 1}, queue, zap.NewNop())
+
+	result, err := v.VerifyEmail(context.Background(), "a@example.com")
+	if err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	if result.IsReachable != "unknown" || queue.Pending() != 1 {
+		t.Fatalf("VerifyEmail() = %v with %d pending, want unknown with 1 pending", result.IsReachable, queue.Pending())
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for queue.Pending() > 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	result, err = v.VerifyEmail(context.Background(), "a@example.com")
+	if err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	if result.IsReachable != "safe" {
+		t.Errorf("VerifyEmail() after re-check = %v, want safe", result.IsReachable)
+	}
+	if inner.calls["a@example.com"] != 2 {
+		t.Errorf("VerifyEmail() inner calls = %v, want 2", inner.calls["a@example.com"])
+	}
+}
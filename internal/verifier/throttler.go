@@ -0,0 +1,311 @@
+package verifier
+
+import (
+	"context"
+	"email-finder/internal/metrics"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// mxCacheTTL is how long a domain's primary MX host is remembered
+	mxCacheTTL = time.Hour
+	// mxFailureTTL is how long a failed MX lookup is remembered
+	mxFailureTTL = 5 * time.Minute
+	// throttleIdleTTL is how long an unused throttle key is kept
+	throttleIdleTTL = 10 * time.Minute
+)
+
+// ThrottleLimit limits the checks against one recipient domain or MX host.
+// A zero value disables that limit.
+type ThrottleLimit struct {
+	// Concurrency is the maximum number of simultaneous checks
+	Concurrency int
+	// PerSecond is the maximum rate at which checks may start
+	PerSecond float64
+}
+
+// ThrottleConfig configures a Throttler
+type ThrottleConfig struct {
+	Domain ThrottleLimit
+	MX     ThrottleLimit
+	// ProviderOverrides replaces the MX limit for MX hosts ending in the key,
+	// e.g. "google.com" for Google Workspace or "outlook.com" for Microsoft 365
+	ProviderOverrides map[string]ThrottleLimit
+}
+
+// Throttler limits concurrent and per-second checks per recipient domain and per
+// MX host, across every request in the process, so that a burst of candidate
+// addresses at one company doesn't get our IP blocklisted by its mail server.
+type Throttler struct {
+	config ThrottleConfig
+	logger *zap.Logger
+	lookup func(ctx context.Context, domain string) ([]*net.MX, error)
+
+	mu        sync.Mutex
+	keys      map[string]*throttleKey
+	mxCache   map[string]mxCacheEntry
+	lastPrune time.Time
+}
+
+// mxCacheEntry is a cached primary MX host for a domain
+type mxCacheEntry struct {
+	host    string
+	expires time.Time
+}
+
+// throttleKey is the limiter state for one domain or MX host
+type throttleKey struct {
+	slots chan struct{} // nil when concurrency is unlimited
+	limit ThrottleLimit
+
+	mu       sync.Mutex
+	tokens   float64
+	last     time.Time
+	inUse    int
+	lastUsed time.Time
+}
+
+// NewThrottler creates a new throttler
+func NewThrottler(config ThrottleConfig, logger *zap.Logger) *Throttler {
+	return &Throttler{
+		config:  config,
+		logger:  logger,
+		lookup:  net.DefaultResolver.LookupMX,
+		keys:    make(map[string]*throttleKey),
+		mxCache: make(map[string]mxCacheEntry),
+	}
+}
+
+// SetConfig changes the limits. Checks already holding a slot finish under the
+// old limits; later checks use the new ones.
+func (t *Throttler) SetConfig(config ThrottleConfig) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.config = config
+	t.keys = make(map[string]*throttleKey)
+}
+
+// throttledKey is the context key marking a check that already holds its
+// throttle slot from the throttler stored under it
+type throttledKey struct{}
+
+// withThrottled marks ctx as holding a slot of t, so the check run with it
+// doesn't wait for t again
+func withThrottled(ctx context.Context, t *Throttler) context.Context {
+	if t == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, throttledKey{}, t)
+}
+
+// Acquire waits until a check of email is allowed by the domain and MX limits.
+// The returned release function must be called when the check is done.
+func (t *Throttler) Acquire(ctx context.Context, email string) (func(), error) {
+	if t == nil {
+		return func() {}, nil
+	}
+	if held, _ := ctx.Value(throttledKey{}).(*Throttler); held == t {
+		return func() {}, nil
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return func() {}, nil
+	}
+	domain := strings.ToLower(email[at+1:])
+
+	releases := make([]func(), 0, 2)
+	release := func() {
+		for _, r := range releases {
+			r()
+		}
+	}
+
+	t.mu.Lock()
+	config := t.config
+	t.mu.Unlock()
+
+	r, err := t.key("domain:"+domain, config.Domain).acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	releases = append(releases, r)
+
+	if mx := t.mxHost(ctx, domain); mx != "" {
+		r, err := t.key("mx:"+mx, mxLimit(config, mx)).acquire(ctx)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+
+	return release, nil
+}
+
+// mxLimit returns the limit for an MX host, applying provider overrides
+func mxLimit(config ThrottleConfig, mx string) ThrottleLimit {
+	for suffix, limit := range config.ProviderOverrides {
+		if mx == suffix || strings.HasSuffix(mx, "."+suffix) {
+			return limit
+		}
+	}
+	return config.MX
+}
+
+// mxHost returns the primary MX host of domain, or "" if it cannot be looked up
+func (t *Throttler) mxHost(ctx context.Context, domain string) string {
+	t.mu.Lock()
+	entry, exists := t.mxCache[domain]
+	t.mu.Unlock()
+	hit := exists && time.Now().Before(entry.expires)
+	metrics.ObserveCacheLookup("mx", hit)
+	if hit {
+		return entry.host
+	}
+
+	host := ""
+	ttl := mxFailureTTL
+	records, err := t.lookup(ctx, domain)
+	if err == nil && len(records) > 0 {
+		sort.Slice(records, func(i, j int) bool { return records[i].Pref < records[j].Pref })
+		host = strings.ToLower(strings.TrimSuffix(records[0].Host, "."))
+		ttl = mxCacheTTL
+	} else if ctx.Err() != nil {
+		return ""
+	} else {
+		t.logger.Debug("MX lookup failed, throttling by domain only",
+			zap.String("domain", domain),
+			zap.Error(err),
+		)
+	}
+
+	t.mu.Lock()
+	t.mxCache[domain] = mxCacheEntry{host: host, expires: time.Now().Add(ttl)}
+	t.mu.Unlock()
+
+	return host
+}
+
+// key returns the limiter for name, creating it if needed
+func (t *Throttler) key(name string, limit ThrottleLimit) *throttleKey {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if k, exists := t.keys[name]; exists {
+		return k
+	}
+
+	t.prune()
+
+	now := time.Now()
+	k := &throttleKey{limit: limit, tokens: burst(limit), last: now, lastUsed: now}
+	if limit.Concurrency > 0 {
+		k.slots = make(chan struct{}, limit.Concurrency)
+	}
+	t.keys[name] = k
+	return k
+}
+
+// prune drops limiters and cached MX hosts that haven't been used for a while.
+// It runs at most once a minute. Callers must hold t.mu.
+func (t *Throttler) prune() {
+	now := time.Now()
+	if now.Sub(t.lastPrune) < time.Minute {
+		return
+	}
+	t.lastPrune = now
+
+	for name, k := range t.keys {
+		k.mu.Lock()
+		idle := k.inUse == 0 && now.Sub(k.lastUsed) > throttleIdleTTL
+		k.mu.Unlock()
+		if idle {
+			delete(t.keys, name)
+		}
+	}
+	for domain, entry := range t.mxCache {
+		if now.After(entry.expires) {
+			delete(t.mxCache, domain)
+		}
+	}
+}
+
+// burst is the number of checks that may start at once under a rate limit
+func burst(limit ThrottleLimit) float64 {
+	if limit.PerSecond < 1 {
+		return 1
+	}
+	return limit.PerSecond
+}
+
+// acquire waits for a concurrency slot and a rate token
+func (k *throttleKey) acquire(ctx context.Context) (func(), error) {
+	if k.slots != nil {
+		select {
+		case k.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	k.mu.Lock()
+	k.inUse++
+	k.mu.Unlock()
+
+	release := func() {
+		k.mu.Lock()
+		k.inUse--
+		k.lastUsed = time.Now()
+		k.mu.Unlock()
+		if k.slots != nil {
+			<-k.slots
+		}
+	}
+
+	if err := k.waitToken(ctx); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+// waitToken waits for the token bucket to allow another check
+func (k *throttleKey) waitToken(ctx context.Context) error {
+	if k.limit.PerSecond <= 0 {
+		return nil
+	}
+
+	for {
+		k.mu.Lock()
+		now := time.Now()
+		k.tokens += now.Sub(k.last).Seconds() * k.limit.PerSecond
+		if capacity := burst(k.limit); k.tokens > capacity {
+			k.tokens = capacity
+		}
+		k.last = now
+
+		if k.tokens >= 1 {
+			k.tokens--
+			k.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - k.tokens) / k.limit.PerSecond * float64(time.Second))
+		k.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package verifier
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func newTestThrottler(config ThrottleConfig) *Throttler {
+	t := NewThrottler(config, zap.NewNop())
+	t.lookup = func(ctx context.Context, domain string) ([]*net.MX, error) {
+		if domain == "gmail-hosted.com" {
+			return []*net.MX{{Host: "alt1.aspmx.l.google.com.", Pref: 5}, {Host: "aspmx.l.google.com.", Pref: 1}}, nil
+		}
+		return []*net.MX{{Host: "mx." + domain + ".", Pref: 10}}, nil
+	}
+	return t
+}
+
+func TestThrottler_DomainConcurrency(t *testing.T) {
+	throttler := newTestThrottler(ThrottleConfig{Domain: ThrottleLimit{Concurrency: 1}})
+
+	release, err := throttler.Acquire(context.Background(), "a@example.com")
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+
+	// A second check at the same domain waits for the first
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := throttler.Acquire(ctx, "b@example.com"); err == nil {
+		t.Fatalf("Acquire() at busy domain succeeded, want timeout")
+	}
+
+	// Other domains are not affected
+	other, err := throttler.Acquire(context.Background(), "a@example.org")
+	if err != nil {
+		t.Fatalf("Acquire() at other domain error = %v", err)
+	}
+	other()
+
+	release()
+	again, err := throttler.Acquire(context.Background(), "b@example.com")
+	if err != nil {
+		t.Fatalf("Acquire() after release error = %v", err)
+	}
+	again()
+}
+
+func TestThrottler_Rate(t *testing.T) {
+	throttler := newTestThrottler(ThrottleConfig{Domain: ThrottleLimit{PerSecond: 20}})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		release, err := throttler.Acquire(context.Background(), "a@example.com")
+		if err != nil {
+			t.Fatalf("Acquire() error = %v", err)
+		}
+		release()
+	}
+
+	// The burst of 20 allows all three checks immediately
+	if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+		t.Errorf("Acquire() within burst took %v", elapsed)
+	}
+
+	throttler = newTestThrottler(ThrottleConfig{Domain: ThrottleLimit{PerSecond: 0.5}})
+	release, _ := throttler.Acquire(context.Background(), "a@example.com")
+	release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := throttler.Acquire(ctx, "a@example.com"); err == nil {
+		t.Errorf("Acquire() over rate succeeded, want timeout")
+	}
+}
+
+func TestThrottler_ProviderOverrides(t *testing.T) {
+	throttler := newTestThrottler(ThrottleConfig{
+		MX:                ThrottleLimit{Concurrency: 1},
+		ProviderOverrides: map[string]ThrottleLimit{"google.com": {Concurrency: 50}},
+	})
+
+	if mx := throttler.mxHost(context.Background(), "gmail-hosted.com"); mx != "aspmx.l.google.com" {
+		t.Errorf("mxHost() = %v, want aspmx.l.google.com", mx)
+	}
+	if limit := mxLimit(throttler.config, "aspmx.l.google.com"); limit.Concurrency != 50 {
+		t.Errorf("mxLimit() google = %+v, want concurrency 50", limit)
+	}
+	if limit := mxLimit(throttler.config, "mx.example.com"); limit.Concurrency != 1 {
+		t.Errorf("mxLimit() default = %+v, want concurrency 1", limit)
+	}
+}
+
+func TestVerifyBatch_ThrottleWaitHoldsNoWorker(t *testing.T) {
+	throttler := newTestThrottler(ThrottleConfig{Domain: ThrottleLimit{Concurrency: 1}})
+	pool := NewWorkerPool(1, zap.NewNop())
+	defer pool.Close()
+
+	// verify throttles like the backends do; it must not wait again for the
+	// slot the batch already took for it
+	verify := func(ctx context.Context, email string) (*VerificationResult, error) {
+		release, err := throttler.Acquire(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		return &VerificationResult{Email: email, IsReachable: "safe"}, nil
+	}
+
+	// Keep example.com busy so a batch there waits for the throttle
+	busy, err := throttler.Acquire(context.Background(), "someone@example.com")
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	blocked := make(chan []*VerificationResult)
+	go func() {
+		results, _ := verifyBatch(WithPriority(context.Background(), PriorityBulk), []string{"a@example.com", "b@example.com"}, pool, 1, throttler, zap.NewNop(), verify)
+		blocked <- results
+	}()
+
+	// The only worker is still free for other domains
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	results, err := verifyBatch(ctx, []string{"a@example.org"}, pool, 1, throttler, zap.NewNop(), verify)
+	if err != nil || results[0].IsReachable != "safe" {
+		t.Fatalf("verifyBatch() at another domain = %v, %v, want a safe result", results, err)
+	}
+
+	busy()
+	for _, result := range <-blocked {
+		if result.IsReachable != "safe" {
+			t.Errorf("result for %s = %q after the domain was released, want safe", result.Email, result.IsReachable)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package verifier
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// ErrPoolClosed is returned when work is submitted to a closed worker pool
+var ErrPoolClosed = errors.New("verification worker pool closed")
+
+// Priority orders queued verifications; lower values run first
+type Priority int
+
+// Verification priorities
+const (
+	// PriorityInteractive is for lookups a user is waiting on
+	PriorityInteractive Priority = iota
+	// PriorityBulk is for batch jobs that can wait
+	PriorityBulk
+)
+
+// String returns the name of the priority
+func (p Priority) String() string {
+	if p == PriorityBulk {
+		return "bulk"
+	}
+	return "interactive"
+}
+
+// priorityKey is the context key for the verification priority
+type priorityKey struct{}
+
+// WithPriority returns a context whose verifications are queued at priority p
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// PriorityFromContext returns the verification priority of ctx, interactive by default
+func PriorityFromContext(ctx context.Context) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+	return PriorityInteractive
+}
+
+// PoolStats describes the load on a worker pool
+type PoolStats struct {
+	Workers           int `json:"workers"`
+	Active            int `json:"active"`
+	Queued            int `json:"queued"`
+	QueuedInteractive int `json:"queued_interactive"`
+	QueuedBulk        int `json:"queued_bulk"`
+}
+
+// WorkerPool runs verifications on a fixed number of workers shared by every
+// request in the process, so total subprocess and HTTP concurrency is capped no
+// matter how many requests arrive at once. Queued work runs by priority, then
+// in submission order.
+type WorkerPool struct {
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   taskQueue
+	seq     uint64
+	workers int // target number of workers
+	running int // workers started and not yet exited
+	active  int
+	queued  map[Priority]int
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// poolTask is one queued unit of work
+type poolTask struct {
+	priority Priority
+	seq      uint64
+	run      func()
+}
+
+// NewWorkerPool creates a new worker pool and starts its workers
+func NewWorkerPool(workers int, logger *zap.Logger) *WorkerPool {
+	if workers <= 0 {
+		workers = 10 // Default concurrency
+	}
+	p := &WorkerPool{
+		logger:  logger,
+		workers: workers,
+		queued:  make(map[Priority]int),
+	}
+	p.cond = sync.NewCond(&p.mu)
+
+	p.mu.Lock()
+	p.startWorkers()
+	p.mu.Unlock()
+	return p
+}
+
+// Resize changes the number of workers. Extra workers exit once their current
+// task is done.
+func (p *WorkerPool) Resize(workers int) {
+	if workers <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return
+	}
+	p.workers = workers
+	p.startWorkers()
+	p.cond.Broadcast()
+}
+
+// startWorkers starts workers up to the target. Callers must hold p.mu.
+func (p *WorkerPool) startWorkers() {
+	for p.running < p.workers {
+		p.running++
+		p.wg.Add(1)
+		go p.work()
+	}
+}
+
+// Submit queues run at the priority of ctx. It does not wait for run to finish.
+func (p *WorkerPool) Submit(ctx context.Context, run func()) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+
+	priority := PriorityFromContext(ctx)
+	p.seq++
+	heap.Push(&p.queue, &poolTask{priority: priority, seq: p.seq, run: run})
+	p.queued[priority]++
+	p.cond.Signal()
+	return nil
+}
+
+// Stats returns the current load on the pool
+func (p *WorkerPool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return PoolStats{
+		Workers:           p.workers,
+		Active:            p.active,
+		Queued:            p.queue.Len(),
+		QueuedInteractive: p.queued[PriorityInteractive],
+		QueuedBulk:        p.queued[PriorityBulk],
+	}
+}
+
+// Close stops accepting work and waits until all queued work has run
+func (p *WorkerPool) Close() {
+	p.mu.Lock()
+	p.closed = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
+
+// work runs queued tasks until the pool is closed and drained, or shrunk
+func (p *WorkerPool) work() {
+	defer p.wg.Done()
+
+	for {
+		p.mu.Lock()
+		for p.queue.Len() == 0 && !p.closed && p.running <= p.workers {
+			p.cond.Wait()
+		}
+		if p.queue.Len() == 0 || p.running > p.workers {
+			p.running--
+			p.mu.Unlock()
+			return
+		}
+		task := heap.Pop(&p.queue).(*poolTask)
+		p.queued[task.priority]--
+		p.active++
+		p.mu.Unlock()
+
+		p.runTask(task)
+
+		p.mu.Lock()
+		p.active--
+		p.mu.Unlock()
+	}
+}
+
+// runTask runs a task, keeping the worker alive if it panics
+func (p *WorkerPool) runTask(task *poolTask) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.logger.Error("verification task panicked", zap.Any("panic", r))
+		}
+	}()
+	task.run()
+}
+
+// taskQueue is a heap of tasks ordered by priority, then submission order
+type taskQueue []*poolTask
+
+func (q taskQueue) Len() int { return len(q) }
+
+func (q taskQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority < q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q taskQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *taskQueue) Push(x interface{}) { *q = append(*q, x.(*poolTask)) }
+
+func (q *taskQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	task := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return task
+}
@@ -0,0 +1,148 @@
+package verifier
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestWorkerPool_CapsConcurrency(t *testing.T) {
+	pool := NewWorkerPool(3, zap.NewNop())
+	defer pool.Close()
+
+	var running, peak atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		err := pool.Submit(context.Background(), func() {
+			defer wg.Done()
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(2 * time.Millisecond)
+			running.Add(-1)
+		})
+		if err != nil {
+			t.Fatalf("Submit() error = %v", err)
+		}
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", got)
+	}
+}
+
+func TestWorkerPool_Priority(t *testing.T) {
+	pool := NewWorkerPool(1, zap.NewNop())
+	defer pool.Close()
+
+	// Block the only worker so the rest of the work queues up
+	block := make(chan struct{})
+	started := make(chan struct{})
+	pool.Submit(context.Background(), func() {
+		close(started)
+		<-block
+	})
+	<-started
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	submit := func(ctx context.Context, name string) {
+		wg.Add(1)
+		pool.Submit(ctx, func() {
+			defer wg.Done()
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		})
+	}
+
+	bulk := WithPriority(context.Background(), PriorityBulk)
+	submit(bulk, "bulk-1")
+	submit(bulk, "bulk-2")
+	submit(context.Background(), "interactive-1")
+	submit(context.Background(), "interactive-2")
+
+	stats := pool.Stats()
+	if stats.Active != 1 || stats.QueuedInteractive != 2 || stats.QueuedBulk != 2 {
+		t.Errorf("Stats() = %+v, want 1 active, 2 interactive and 2 bulk queued", stats)
+	}
+
+	close(block)
+	wg.Wait()
+
+	want := []string{"interactive-1", "interactive-2", "bulk-1", "bulk-2"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("run order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestWorkerPool_Close(t *testing.T) {
+	pool := NewWorkerPool(2, zap.NewNop())
+
+	var ran atomic.Int32
+	for i := 0; i < 5; i++ {
+		pool.Submit(context.Background(), func() { ran.Add(1) })
+	}
+	pool.Close()
+
+	if got := ran.Load(); got != 5 {
+		t.Errorf("ran %d tasks before Close returned, want 5", got)
+	}
+	if err := pool.Submit(context.Background(), func() {}); err != ErrPoolClosed {
+		t.Errorf("Submit() after Close error = %v, want ErrPoolClosed", err)
+	}
+}
+
+func TestWorkerPool_Resize(t *testing.T) {
+	pool := NewWorkerPool(4, zap.NewNop())
+	defer pool.Close()
+
+	pool.Resize(1)
+
+	// Shrinking takes effect as idle workers wake up and exit
+	deadline := time.Now().Add(time.Second)
+	for {
+		pool.mu.Lock()
+		running := pool.running
+		pool.mu.Unlock()
+		if running == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("running workers = %d after Resize(1), want 1", running)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	pool.Resize(3)
+	if stats := pool.Stats(); stats.Workers != 3 {
+		t.Errorf("Stats().Workers = %d after Resize(3), want 3", stats.Workers)
+	}
+
+	var ran atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		pool.Submit(context.Background(), func() {
+			defer wg.Done()
+			ran.Add(1)
+		})
+	}
+	wg.Wait()
+	if got := ran.Load(); got != 10 {
+		t.Errorf("ran %d tasks after resizing, want 10", got)
+	}
+}
@@ -0,0 +1,179 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// deliveriesBucket holds the deliveries keyed by ID, so in creation order
+var deliveriesBucket = []byte("deliveries")
+
+// BoltStore keeps deliveries in an embedded BoltDB file
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// storedDelivery is a delivery with the callback body that is resent after a restart
+type storedDelivery struct {
+	*Delivery
+	Payload []byte `json:"payload,omitempty"`
+}
+
+// OpenBoltStore opens or creates the delivery database at path
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open webhook database %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(deliveriesBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize webhook database %s: %w", path, err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+// Save stores a delivery, replacing the one with the same ID
+func (s *BoltStore) Save(delivery *Delivery) error {
+	data, err := json.Marshal(storedDelivery{Delivery: delivery, Payload: delivery.payload})
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(deliveriesBucket).Put([]byte(delivery.ID), data)
+	})
+}
+
+// Get returns a delivery by ID
+func (s *BoltStore) Get(id string) (*Delivery, error) {
+	var delivery *Delivery
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(deliveriesBucket).Get([]byte(id))
+		if data == nil {
+			return ErrNotFound
+		}
+		var err error
+		delivery, err = decodeDelivery(data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// List returns up to limit deliveries, newest first
+func (s *BoltStore) List(limit int) ([]*Delivery, error) {
+	deliveries := make([]*Delivery, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(deliveriesBucket).Cursor()
+		for k, v := c.Last(); k != nil && (limit <= 0 || len(deliveries) < limit); k, v = c.Prev() {
+			delivery, err := decodeDelivery(v)
+			if err != nil {
+				return err
+			}
+			deliveries = append(deliveries, delivery)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// Unfinished returns the deliveries that are neither delivered nor failed, oldest first
+func (s *BoltStore) Unfinished() ([]*Delivery, error) {
+	deliveries, err := s.all()
+	if err != nil {
+		return nil, err
+	}
+	unfinished := make([]*Delivery, 0)
+	for _, delivery := range deliveries {
+		if !delivery.finished() {
+			unfinished = append(unfinished, delivery)
+		}
+	}
+	return unfinished, nil
+}
+
+// Prune removes the oldest finished deliveries until at most keep are left
+func (s *BoltStore) Prune(keep int) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(deliveriesBucket)
+		if bucket.Stats().KeyN <= keep {
+			return nil
+		}
+		deliveries := make([]*Delivery, 0)
+		err := bucket.ForEach(func(k, v []byte) error {
+			delivery, err := decodeDelivery(v)
+			if err != nil {
+				return err
+			}
+			deliveries = append(deliveries, delivery)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, id := range pruned(deliveries, keep) {
+			if err := bucket.Delete([]byte(id)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// HealthCheck reads the database in a transaction, for the readiness probe
+func (s *BoltStore) HealthCheck(ctx context.Context) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		if tx.Bucket(deliveriesBucket) == nil {
+			return fmt.Errorf("bucket %s is missing", deliveriesBucket)
+		}
+		return nil
+	})
+}
+
+// Close closes the database file
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// all returns every delivery, oldest first
+func (s *BoltStore) all() ([]*Delivery, error) {
+	deliveries := make([]*Delivery, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(deliveriesBucket).ForEach(func(k, v []byte) error {
+			delivery, err := decodeDelivery(v)
+			if err != nil {
+				return err
+			}
+			deliveries = append(deliveries, delivery)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// decodeDelivery reads a delivery stored by Save
+func decodeDelivery(data []byte) (*Delivery, error) {
+	stored := storedDelivery{Delivery: &Delivery{}}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+	stored.Delivery.payload = stored.Payload
+	return stored.Delivery, nil
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress is returned when a callback URL resolves to an address
+// callbacks may not be sent to
+var ErrForbiddenAddress = errors.New("callback address is not allowed")
+
+// nonPublic are ranges that aren't caught by the netip predicates: "this
+// network" and the carrier-grade NAT range
+var nonPublic = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// newTransport returns a transport that refuses to connect to loopback,
+// private, link-local (including cloud metadata services), multicast and
+// unspecified addresses unless they are in allowed. The check runs on the
+// address actually dialed, so a host name resolving to such an address, or a
+// redirect to one, is refused too.
+func newTransport(allowed []netip.Prefix) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip, err := netip.ParseAddr(host)
+			if err != nil {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+			}
+			if !allowedAddr(ip.Unmap(), allowed) {
+				return fmt.Errorf("%w: %s", ErrForbiddenAddress, ip)
+			}
+			return nil
+		},
+	}
+	return &http.Transport{
+		// A proxy would make the dialed address the proxy's
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// allowedAddr reports whether callbacks may be sent to ip
+func allowedAddr(ip netip.Addr, allowed []netip.Prefix) bool {
+	for _, prefix := range allowed {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublic {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,134 @@
+package webhook
+
+import (
+	"sort"
+	"sync"
+)
+
+// Store keeps deliveries, so their status and unsent callbacks survive a restart
+type Store interface {
+	// Save stores a delivery, replacing the one with the same ID
+	Save(delivery *Delivery) error
+	Get(id string) (*Delivery, error)
+	// List returns up to limit deliveries, newest first
+	List(limit int) ([]*Delivery, error)
+	// Unfinished returns the deliveries that are neither delivered nor failed, oldest first
+	Unfinished() ([]*Delivery, error)
+	// Prune removes the oldest finished deliveries until at most keep are left.
+	// Deliveries still in progress are kept.
+	Prune(keep int) error
+	Close() error
+}
+
+// MemoryStore keeps deliveries in memory. They don't survive a restart; it is
+// meant for tests and deployments without a writable disk.
+type MemoryStore struct {
+	mu         sync.Mutex
+	deliveries map[string]*Delivery
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		deliveries: make(map[string]*Delivery),
+	}
+}
+
+// Save stores a delivery, replacing the one with the same ID
+func (s *MemoryStore) Save(delivery *Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deliveries[delivery.ID] = delivery.copy()
+	return nil
+}
+
+// Get returns a delivery by ID
+func (s *MemoryStore) Get(id string) (*Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delivery, ok := s.deliveries[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return delivery.copy(), nil
+}
+
+// List returns up to limit deliveries, newest first
+func (s *MemoryStore) List(limit int) ([]*Delivery, error) {
+	deliveries := s.oldestFirst()
+	if limit <= 0 || limit > len(deliveries) {
+		limit = len(deliveries)
+	}
+	newest := make([]*Delivery, 0, limit)
+	for i := len(deliveries) - 1; i >= 0 && len(newest) < limit; i-- {
+		newest = append(newest, deliveries[i])
+	}
+	return newest, nil
+}
+
+// Unfinished returns the deliveries that are neither delivered nor failed, oldest first
+func (s *MemoryStore) Unfinished() ([]*Delivery, error) {
+	unfinished := make([]*Delivery, 0)
+	for _, delivery := range s.oldestFirst() {
+		if !delivery.finished() {
+			unfinished = append(unfinished, delivery)
+		}
+	}
+	return unfinished, nil
+}
+
+// Prune removes the oldest finished deliveries until at most keep are left
+func (s *MemoryStore) Prune(keep int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range pruned(s.sorted(), keep) {
+		delete(s.deliveries, id)
+	}
+	return nil
+}
+
+// Close does nothing
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// oldestFirst returns copies of every delivery, oldest first
+func (s *MemoryStore) oldestFirst() []*Delivery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deliveries := s.sorted()
+	for i, delivery := range deliveries {
+		deliveries[i] = delivery.copy()
+	}
+	return deliveries
+}
+
+// sorted returns the stored deliveries, oldest first. Must be called with mu held.
+func (s *MemoryStore) sorted() []*Delivery {
+	deliveries := make([]*Delivery, 0, len(s.deliveries))
+	for _, delivery := range s.deliveries {
+		deliveries = append(deliveries, delivery)
+	}
+	// IDs start with the creation time, so they sort like the bolt keys
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID < deliveries[j].ID })
+	return deliveries
+}
+
+// pruned returns the IDs of the oldest finished deliveries to remove so that at
+// most keep are left; deliveries must be sorted oldest first
+func pruned(deliveries []*Delivery, keep int) []string {
+	ids := make([]string, 0)
+	for _, delivery := range deliveries {
+		if len(deliveries)-len(ids) <= keep {
+			break
+		}
+		if delivery.finished() {
+			ids = append(ids, delivery.ID)
+		}
+	}
+	return ids
+}
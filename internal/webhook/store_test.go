@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// storeTests runs the same checks against every Store implementation
+func storeTests(t *testing.T, newStore func(t *testing.T) Store) {
+	// newDelivery creates a delivery created i seconds after the first one
+	start := time.Now()
+	newDelivery := func(t *testing.T, i int, status string) *Delivery {
+		id, err := newID(start.Add(time.Duration(i) * time.Second))
+		if err != nil {
+			t.Fatalf("newID() error = %v", err)
+		}
+		return &Delivery{ID: id, URL: "https://example.org/hook", Status: status, Attempts: []Attempt{}}
+	}
+
+	t.Run("deliveries keep their payload", func(t *testing.T) {
+		store := newStore(t)
+		delivery := newDelivery(t, 0, StatusPending)
+		delivery.payload = []byte(`{"email":"john@example.org"}`)
+		if err := store.Save(delivery); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		got, err := store.Get(delivery.ID)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if got.Status != StatusPending || string(got.payload) != `{"email":"john@example.org"}` {
+			t.Errorf("Get() = %+v with payload %s, want the pending delivery and its payload", got, got.payload)
+		}
+	})
+
+	t.Run("list is newest first and unfinished is oldest first", func(t *testing.T) {
+		store := newStore(t)
+		statuses := []string{StatusPending, StatusDelivered, StatusProcessing, StatusFailed}
+		deliveries := make([]*Delivery, len(statuses))
+		for i, status := range statuses {
+			deliveries[i] = newDelivery(t, i, status)
+			store.Save(deliveries[i])
+		}
+
+		list, err := store.List(3)
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if len(list) != 3 || list[0].ID != deliveries[3].ID || list[2].ID != deliveries[1].ID {
+			t.Errorf("List(3) returned %d deliveries, want the newest 3 newest first", len(list))
+		}
+
+		unfinished, err := store.Unfinished()
+		if err != nil {
+			t.Fatalf("Unfinished() error = %v", err)
+		}
+		if len(unfinished) != 2 || unfinished[0].ID != deliveries[0].ID || unfinished[1].ID != deliveries[2].ID {
+			t.Errorf("Unfinished() returned %d deliveries, want the pending and the processing one", len(unfinished))
+		}
+	})
+
+	t.Run("prune keeps unfinished deliveries", func(t *testing.T) {
+		store := newStore(t)
+		statuses := []string{StatusPending, StatusDelivered, StatusFailed, StatusDelivered}
+		deliveries := make([]*Delivery, len(statuses))
+		for i, status := range statuses {
+			deliveries[i] = newDelivery(t, i, status)
+			store.Save(deliveries[i])
+		}
+
+		if err := store.Prune(2); err != nil {
+			t.Fatalf("Prune() error = %v", err)
+		}
+		list, _ := store.List(0)
+		if len(list) != 2 || list[0].ID != deliveries[3].ID || list[1].ID != deliveries[0].ID {
+			t.Errorf("List() after Prune(2) returned %d deliveries, want the newest and the pending one", len(list))
+		}
+	})
+
+	t.Run("unknown delivery", func(t *testing.T) {
+		store := newStore(t)
+		if _, err := store.Get("missing"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestMemoryStore(t *testing.T) {
+	storeTests(t, func(t *testing.T) Store {
+		return NewMemoryStore()
+	})
+}
+
+func TestBoltStore(t *testing.T) {
+	storeTests(t, func(t *testing.T) Store {
+		store, err := OpenBoltStore(filepath.Join(t.TempDir(), "webhooks.db"))
+		if err != nil {
+			t.Fatalf("OpenBoltStore() error = %v", err)
+		}
+		t.Cleanup(func() { store.Close() })
+		return store
+	})
+}
@@ -0,0 +1,402 @@
+// Package webhook runs lookups in the background and POSTs their results to a
+// callback URL, signed with HMAC-SHA256 and retried with backoff
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"email-finder/internal/backoff"
+	"email-finder/internal/metrics"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Headers sent with every callback
+const (
+	// SignatureHeader holds "sha256=" and the hex HMAC-SHA256 of
+	// "<timestamp>.<body>", keyed with the webhook secret
+	SignatureHeader = "X-EmailFinder-Signature"
+	TimestampHeader = "X-EmailFinder-Timestamp"
+	EventHeader     = "X-EmailFinder-Event"
+	DeliveryHeader  = "X-EmailFinder-Delivery"
+)
+
+// Delivery statuses
+const (
+	// StatusProcessing means the lookup is still running
+	StatusProcessing = "processing"
+	// StatusPending means the callback is being sent or waits for a retry
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	StatusFailed    = "failed"
+)
+
+var (
+	// ErrClosed is returned when work is submitted during shutdown
+	ErrClosed = errors.New("webhook dispatcher is shutting down")
+	// ErrNotFound is returned for unknown or expired deliveries
+	ErrNotFound = errors.New("delivery not found")
+)
+
+// Config controls signing, retries and how many deliveries are remembered
+type Config struct {
+	Secret string
+	// MaxAttempts is the total number of attempts, including the first
+	MaxAttempts int
+	// BaseDelay is the backoff before the second attempt; it doubles every attempt
+	BaseDelay time.Duration
+	// MaxDelay caps a single backoff
+	MaxDelay time.Duration
+	// Timeout bounds a single attempt
+	Timeout time.Duration
+	// History is how many deliveries are kept; the oldest finished ones are dropped
+	History int
+	// AllowedNetworks are exempt from the ban on callbacks to loopback, private
+	// and link-local addresses, e.g. for receivers inside the cluster
+	AllowedNetworks []netip.Prefix
+}
+
+// Attempt records one POST to the callback URL
+type Attempt struct {
+	Number     int       `json:"number"`
+	At         time.Time `json:"at"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+}
+
+// Delivery tracks a background lookup and the callback sent for it
+type Delivery struct {
+	ID       string    `json:"id"`
+	Event    string    `json:"event,omitempty"`
+	URL      string    `json:"url"`
+	Status   string    `json:"status"`
+	Attempts []Attempt `json:"attempts"`
+	// Error explains a delivery that failed without an attempt
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	// payload is the callback body, kept to resend it after a restart
+	payload []byte
+}
+
+// Accepted is the response to a request whose result is delivered by callback
+type Accepted struct {
+	// ID identifies the delivery; it is also sent in the DeliveryHeader
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	// StatusURL is the API path reporting the delivery and its attempts
+	StatusURL string `json:"status_url"`
+}
+
+// copy returns a snapshot that can be read without the dispatcher's lock
+func (d *Delivery) copy() *Delivery {
+	c := *d
+	c.Attempts = append(make([]Attempt, 0, len(d.Attempts)), d.Attempts...)
+	return &c
+}
+
+// finished reports whether the delivery will not change anymore
+func (d *Delivery) finished() bool {
+	return d.Status == StatusDelivered || d.Status == StatusFailed
+}
+
+// Dispatcher runs work in the background and delivers its result by callback.
+// Deliveries are kept in a Store; Start resends the callbacks a restart
+// interrupted.
+type Dispatcher struct {
+	cfg    Config
+	store  Store
+	client *http.Client
+	logger *zap.Logger
+
+	// ctx is cancelled when Close gives up waiting, which stops the lookups
+	// and deliveries still running
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewDispatcher creates a new dispatcher keeping its deliveries in store.
+// Call Start to resume the deliveries of the previous run.
+func NewDispatcher(cfg Config, store Store, logger *zap.Logger) *Dispatcher {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.History <= 0 {
+		cfg.History = 1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		cfg:   cfg,
+		store: store,
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: newTransport(cfg.AllowedNetworks),
+		},
+		logger: logger,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start resends the callbacks the previous run didn't finish. Lookups it
+// didn't finish can't be resumed, so their deliveries fail.
+func (d *Dispatcher) Start() error {
+	deliveries, err := d.store.Unfinished()
+	if err != nil {
+		return fmt.Errorf("failed to load unfinished deliveries: %w", err)
+	}
+
+	resumed := 0
+	for _, delivery := range deliveries {
+		if delivery.Status == StatusProcessing || delivery.payload == nil {
+			delivery.Error = "the lookup was interrupted by a restart"
+			d.finish(delivery, StatusFailed)
+			continue
+		}
+		d.wg.Add(1)
+		go func(delivery *Delivery) {
+			defer d.wg.Done()
+			d.deliver(delivery)
+		}(delivery)
+		resumed++
+	}
+	if resumed > 0 {
+		d.logger.Info("resuming webhook deliveries", zap.Int("deliveries", resumed))
+	}
+	return nil
+}
+
+// Run starts work in the background and returns its delivery right away. Once
+// work finishes, its result is POSTed to callbackURL as "<event>.completed",
+// or an error body as "<event>.failed".
+func (d *Dispatcher) Run(callbackURL, event string, work func(ctx context.Context) (interface{}, error)) (*Delivery, error) {
+	id, err := newID(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+
+	delivery := &Delivery{
+		ID:        id,
+		URL:       callbackURL,
+		Status:    StatusProcessing,
+		Attempts:  []Attempt{},
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := d.store.Save(delivery); err != nil {
+		return nil, fmt.Errorf("failed to store delivery: %w", err)
+	}
+	if err := d.store.Prune(d.cfg.History); err != nil {
+		d.logger.Warn("failed to prune webhook deliveries", zap.Error(err))
+	}
+	accepted := delivery.copy()
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		result, err := work(d.ctx)
+		name := event + ".completed"
+		if err != nil {
+			name = event + ".failed"
+			result = map[string]string{"id": id, "error": err.Error()}
+		}
+		body, err := json.Marshal(result)
+		if err != nil {
+			d.logger.Error("failed to encode webhook payload", zap.String("delivery", id), zap.Error(err))
+			delivery.Error = "failed to encode the payload"
+			d.finish(delivery, StatusFailed)
+			return
+		}
+
+		delivery.Event = name
+		delivery.Status = StatusPending
+		delivery.payload = body
+		d.save(delivery)
+
+		d.deliver(delivery)
+	}()
+
+	return accepted, nil
+}
+
+// Get returns a delivery by ID
+func (d *Dispatcher) Get(id string) (*Delivery, error) {
+	return d.store.Get(id)
+}
+
+// List returns up to limit deliveries, newest first
+func (d *Dispatcher) List(limit int) ([]*Delivery, error) {
+	return d.store.List(limit)
+}
+
+// Close stops accepting work and waits for running lookups and deliveries until
+// ctx is done, then cancels the rest. Callbacks that weren't delivered are
+// resent by Start after a restart.
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.mu.Lock()
+	d.closed = true
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		d.cancel()
+		return nil
+	case <-ctx.Done():
+		d.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// deliver POSTs the payload of delivery until it is accepted, the attempts are
+// used up or the receiver rejects it permanently
+func (d *Dispatcher) deliver(delivery *Delivery) {
+	for attempt := len(delivery.Attempts) + 1; ; attempt++ {
+		outcome, err := d.post(delivery, attempt)
+		if outcome.StatusCode >= 200 && outcome.StatusCode < 300 {
+			d.finish(delivery, StatusDelivered)
+			return
+		}
+		if attempt >= d.cfg.MaxAttempts || errors.Is(err, ErrForbiddenAddress) || !retryable(outcome.StatusCode) {
+			d.logger.Warn("webhook delivery failed",
+				zap.String("delivery", delivery.ID),
+				zap.String("url", delivery.URL),
+				zap.Int("attempts", attempt),
+				zap.Int("status_code", outcome.StatusCode),
+				zap.String("error", outcome.Error),
+			)
+			d.finish(delivery, StatusFailed)
+			return
+		}
+
+		select {
+		case <-time.After(backoff.Exponential(d.cfg.BaseDelay, d.cfg.MaxDelay, attempt)):
+		case <-d.ctx.Done():
+			// Left pending, so the next Start resends it
+			return
+		}
+	}
+}
+
+// post sends one attempt and records it on the delivery
+func (d *Dispatcher) post(delivery *Delivery, number int) (Attempt, error) {
+	attempt := Attempt{Number: number, At: time.Now().UTC()}
+
+	timestamp := strconv.FormatInt(attempt.At.Unix(), 10)
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.payload))
+	if err == nil {
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(EventHeader, delivery.Event)
+		req.Header.Set(DeliveryHeader, delivery.ID)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, Sign(d.cfg.Secret, timestamp, delivery.payload))
+
+		var resp *http.Response
+		resp, err = d.client.Do(req)
+		if err == nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+			attempt.StatusCode = resp.StatusCode
+			if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+				attempt.Error = fmt.Sprintf("receiver responded with HTTP %d", resp.StatusCode)
+			}
+		}
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+	attempt.DurationMS = time.Since(attempt.At).Milliseconds()
+
+	delivery.Attempts = append(delivery.Attempts, attempt)
+	d.save(delivery)
+
+	return attempt, err
+}
+
+// finish records the final status of a delivery
+func (d *Dispatcher) finish(delivery *Delivery, status string) {
+	metrics.ObserveWebhookDelivery(status)
+
+	now := time.Now().UTC()
+	delivery.Status = status
+	delivery.CompletedAt = &now
+	d.save(delivery)
+}
+
+// save stores the current state of a delivery
+func (d *Dispatcher) save(delivery *Delivery) {
+	if err := d.store.Save(delivery); err != nil {
+		d.logger.Error("failed to store webhook delivery",
+			zap.String("delivery", delivery.ID),
+			zap.String("status", delivery.Status),
+			zap.Error(err),
+		)
+	}
+}
+
+// retryable reports whether an attempt that got statusCode may succeed later.
+// Network errors (status 0), timeouts, rate limiting and server errors are
+// retried; other client errors are not.
+func retryable(statusCode int) bool {
+	return statusCode == 0 ||
+		statusCode == http.StatusRequestTimeout ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode >= 500
+}
+
+// Sign returns the signature header value for body sent at timestamp
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the valid signature of body sent at
+// timestamp. Receivers should also reject old timestamps to prevent replays.
+func Verify(secret, timestamp, signature string, body []byte) bool {
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body)))
+}
+
+// newID returns a random delivery ID that sorts by creation time
+func newID(createdAt time.Time) (string, error) {
+	b := make([]byte, 16)
+	binary.BigEndian.PutUint64(b, uint64(createdAt.UnixNano()))
+	if _, err := rand.Read(b[8:]); err != nil {
+		return "", fmt.Errorf("failed to generate delivery ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
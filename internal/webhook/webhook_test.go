@@ -0,0 +1,298 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// receiver is a callback endpoint that answers with the given status codes in turn
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	status := r.statuses[min(len(r.requests), len(r.statuses)-1)]
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(status)
+}
+
+// count returns how many callbacks were received
+func (r *receiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.requests)
+}
+
+func testConfig() Config {
+	return Config{
+		Secret:      "s3cret",
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+		Timeout:     time.Second,
+		History:     10,
+		// The test receivers listen on loopback
+		AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")},
+	}
+}
+
+// waitFinished waits until the delivery is delivered or failed
+func waitFinished(t *testing.T, d *Dispatcher, id string) *Delivery {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		delivery, err := d.Get(id)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if delivery.finished() {
+			return delivery
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("delivery did not finish in time")
+	return nil
+}
+
+func TestDispatcher_RetriesAndSigns(t *testing.T) {
+	recv := &receiver{statuses: []int{http.StatusServiceUnavailable, http.StatusOK}}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	d := NewDispatcher(testConfig(), NewMemoryStore(), zap.NewNop())
+	delivery, err := d.Run(server.URL, "find_email", func(ctx context.Context) (interface{}, error) {
+		return map[string]string{"email": "john@example.org"}, nil
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if delivery.Status != StatusProcessing {
+		t.Errorf("Status = %q right after Run, want %q", delivery.Status, StatusProcessing)
+	}
+
+	delivery = waitFinished(t, d, delivery.ID)
+	if delivery.Status != StatusDelivered || len(delivery.Attempts) != 2 {
+		t.Fatalf("Status = %q after %d attempts, want delivered after 2", delivery.Status, len(delivery.Attempts))
+	}
+	if delivery.Attempts[0].StatusCode != http.StatusServiceUnavailable || delivery.Attempts[0].Error == "" {
+		t.Errorf("first attempt = %+v, want the 503 recorded", delivery.Attempts[0])
+	}
+
+	req, body := recv.requests[1], recv.bodies[1]
+	if string(body) != `{"email":"john@example.org"}` {
+		t.Errorf("body = %s, want the work result", body)
+	}
+	if req.Header.Get(EventHeader) != "find_email.completed" || req.Header.Get(DeliveryHeader) != delivery.ID {
+		t.Errorf("event = %q, delivery = %q", req.Header.Get(EventHeader), req.Header.Get(DeliveryHeader))
+	}
+	if !Verify("s3cret", req.Header.Get(TimestampHeader), req.Header.Get(SignatureHeader), body) {
+		t.Error("signature does not verify with the secret")
+	}
+	if Verify("other", req.Header.Get(TimestampHeader), req.Header.Get(SignatureHeader), body) {
+		t.Error("signature verifies with the wrong secret")
+	}
+}
+
+func TestDispatcher_PermanentFailureNotRetried(t *testing.T) {
+	recv := &receiver{statuses: []int{http.StatusGone}}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	d := NewDispatcher(testConfig(), NewMemoryStore(), zap.NewNop())
+	delivery, err := d.Run(server.URL, "find_email", func(ctx context.Context) (interface{}, error) {
+		return nil, errors.New("lookup failed")
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	delivery = waitFinished(t, d, delivery.ID)
+	if delivery.Status != StatusFailed || len(delivery.Attempts) != 1 {
+		t.Errorf("Status = %q after %d attempts, want failed after 1", delivery.Status, len(delivery.Attempts))
+	}
+	if delivery.Event != "find_email.failed" {
+		t.Errorf("Event = %q, want find_email.failed", delivery.Event)
+	}
+}
+
+func TestDispatcher_GivesUpAfterMaxAttempts(t *testing.T) {
+	recv := &receiver{statuses: []int{http.StatusInternalServerError}}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	d := NewDispatcher(testConfig(), NewMemoryStore(), zap.NewNop())
+	delivery, _ := d.Run(server.URL, "bulk", func(ctx context.Context) (interface{}, error) {
+		return struct{}{}, nil
+	})
+
+	delivery = waitFinished(t, d, delivery.ID)
+	if delivery.Status != StatusFailed || len(delivery.Attempts) != 3 {
+		t.Errorf("Status = %q after %d attempts, want failed after 3", delivery.Status, len(delivery.Attempts))
+	}
+}
+
+func TestDispatcher_HistoryKeepsNewest(t *testing.T) {
+	recv := &receiver{statuses: []int{http.StatusOK}}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	cfg := testConfig()
+	cfg.History = 2
+	d := NewDispatcher(cfg, NewMemoryStore(), zap.NewNop())
+
+	ids := make([]string, 0, 3)
+	for i := 0; i < 3; i++ {
+		delivery, _ := d.Run(server.URL, "find_email", func(ctx context.Context) (interface{}, error) {
+			return struct{}{}, nil
+		})
+		waitFinished(t, d, delivery.ID)
+		ids = append(ids, delivery.ID)
+	}
+
+	if _, err := d.Get(ids[0]); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get(oldest) error = %v, want ErrNotFound", err)
+	}
+	list, err := d.List(0)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(list) != 2 || list[0].ID != ids[2] || list[1].ID != ids[1] {
+		t.Errorf("List() returned %d deliveries, want the two newest, newest first", len(list))
+	}
+}
+
+func TestDispatcher_CloseRejectsNewWorkAndCancels(t *testing.T) {
+	d := NewDispatcher(testConfig(), NewMemoryStore(), zap.NewNop())
+
+	started := make(chan struct{})
+	d.Run("http://127.0.0.1:1", "find_email", func(ctx context.Context) (interface{}, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := d.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Close() error = %v, want DeadlineExceeded", err)
+	}
+
+	if _, err := d.Run("http://127.0.0.1:1", "find_email", nil); !errors.Is(err, ErrClosed) {
+		t.Errorf("Run() after Close error = %v, want ErrClosed", err)
+	}
+}
+
+func TestDispatcher_RefusesPrivateAddresses(t *testing.T) {
+	recv := &receiver{statuses: []int{http.StatusOK}}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	cfg := testConfig()
+	cfg.AllowedNetworks = nil
+	d := NewDispatcher(cfg, NewMemoryStore(), zap.NewNop())
+	delivery, _ := d.Run(server.URL, "find_email", func(ctx context.Context) (interface{}, error) {
+		return struct{}{}, nil
+	})
+
+	delivery = waitFinished(t, d, delivery.ID)
+	if delivery.Status != StatusFailed || len(delivery.Attempts) != 1 || !strings.Contains(delivery.Attempts[0].Error, ErrForbiddenAddress.Error()) {
+		t.Errorf("delivery = %+v, want failed after one refused attempt", delivery)
+	}
+	if n := recv.count(); n != 0 {
+		t.Errorf("receiver got %d requests, want none", n)
+	}
+}
+
+func TestAllowedAddr(t *testing.T) {
+	allowed := []netip.Prefix{netip.MustParsePrefix("10.1.0.0/16")}
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.0.0.1", false},
+		{"10.1.2.3", true},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00:ec2::254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"224.0.0.1", false},
+	}
+	for _, tt := range tests {
+		if got := allowedAddr(netip.MustParseAddr(tt.addr), allowed); got != tt.want {
+			t.Errorf("allowedAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestDispatcher_ResumesAfterRestart(t *testing.T) {
+	recv := &receiver{statuses: []int{http.StatusServiceUnavailable, http.StatusOK}}
+	server := httptest.NewServer(recv)
+	defer server.Close()
+
+	store, err := OpenBoltStore(filepath.Join(t.TempDir(), "webhooks.db"))
+	if err != nil {
+		t.Fatalf("OpenBoltStore() error = %v", err)
+	}
+	defer store.Close()
+
+	// The first run stops while it waits to retry
+	cfg := testConfig()
+	cfg.BaseDelay, cfg.MaxDelay = time.Minute, time.Minute
+	d := NewDispatcher(cfg, store, zap.NewNop())
+	delivery, _ := d.Run(server.URL, "find_email", func(ctx context.Context) (interface{}, error) {
+		return map[string]string{"email": "john@example.org"}, nil
+	})
+	for recv.count() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	d.Close(ctx)
+
+	// A lookup that was still running can't be resumed
+	interrupted := &Delivery{ID: "00" + delivery.ID[2:], URL: server.URL, Status: StatusProcessing, Attempts: []Attempt{}}
+	store.Save(interrupted)
+
+	d = NewDispatcher(testConfig(), store, zap.NewNop())
+	if err := d.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	resumed := waitFinished(t, d, delivery.ID)
+	if resumed.Status != StatusDelivered || len(resumed.Attempts) != 2 || resumed.Attempts[1].Number != 2 {
+		t.Errorf("delivery = %+v, want delivered by a second attempt", resumed)
+	}
+	if string(recv.bodies[1]) != `{"email":"john@example.org"}` {
+		t.Errorf("resent body = %s, want the original payload", recv.bodies[1])
+	}
+	if got, _ := d.Get(interrupted.ID); got.Status != StatusFailed || got.Error == "" {
+		t.Errorf("interrupted delivery = %+v, want failed with an error", got)
+	}
+}
@@ -0,0 +1,310 @@
+// Package client is a Go client for the email finder HTTP API
+//
+//	c, err := client.New("http://email-finder:8080", client.WithToken(token))
+//	if err != nil {
+//		return err
+//	}
+//	result, err := c.FindEmail(ctx, client.FindEmailRequest{
+//		FirstName: "John",
+//		LastName:  "Doe",
+//		Company:   "Acme",
+//	})
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults for a new client
+const (
+	DefaultTimeout    = 5 * time.Minute // lookups verify many patterns over SMTP
+	DefaultMaxRetries = 3
+	DefaultBaseDelay  = 500 * time.Millisecond
+	DefaultMaxDelay   = 10 * time.Second
+)
+
+// Client calls the email finder HTTP API. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	token      string
+	headers    http.Header
+	userAgent  string
+
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken sends token in an "Authorization: Bearer" header
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithHeader sends an extra header with every request, e.g. an API key for a gateway
+func WithHeader(name, value string) Option {
+	return func(c *Client) {
+		c.headers.Add(name, value)
+	}
+}
+
+// WithUserAgent sets the User-Agent header
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithRetries sets how often a request is retried after a network error, a 429
+// or a 502/503/504 response, and the backoff before the first retry, which is
+// doubled each retry. 0 retries disables retrying. Only requests that are safe
+// to repeat are retried: lookups, queries and reads. Requests that start work,
+// like SubmitJob, FindEmailAsync and FindEmailsBulkAsync, are sent once, since
+// a failed attempt may still have been accepted.
+func WithRetries(maxRetries int, baseDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.baseDelay = baseDelay
+	}
+}
+
+// WithMaxDelay caps the backoff between retries
+func WithMaxDelay(maxDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxDelay = maxDelay
+	}
+}
+
+// New creates a client for the API served at baseURL
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme must be http or https", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+		headers:    make(http.Header),
+		userAgent:  "email-finder-go-client",
+		maxRetries: DefaultMaxRetries,
+		baseDelay:  DefaultBaseDelay,
+		maxDelay:   DefaultMaxDelay,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// APIError is returned when the API responds with an error status
+type APIError struct {
+	StatusCode int
+	Message    string `json:"error"`
+	Details    string `json:"details"`
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("email finder API: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Details != "" {
+		msg += " (" + e.Details + ")"
+	}
+	return msg
+}
+
+// IsUnavailable reports whether err means the verification backend is
+// temporarily unavailable and the call can be retried later
+func IsUnavailable(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusServiceUnavailable
+}
+
+// FindEmail finds and verifies the email address of a person at a company.
+// Use FindEmailAsync for lookups with a callback URL.
+func (c *Client) FindEmail(ctx context.Context, req FindEmailRequest) (*FindEmailResponse, error) {
+	if req.CallbackURL != "" {
+		return nil, errors.New("FindEmail does not accept a callback URL, use FindEmailAsync")
+	}
+	var resp FindEmailResponse
+	if err := c.query(ctx, "/api/v1/find-email", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Verify verifies the given addresses as-is, returning one result per email in order
+func (c *Client) Verify(ctx context.Context, emails ...string) ([]*VerificationResult, error) {
+	var resp VerifyEmailsResponse
+	req := VerifyEmailsRequest{Emails: emails}
+	if err := c.query(ctx, "/api/v1/verify", req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+// ResolveDomain looks up the email domain of a company
+func (c *Client) ResolveDomain(ctx context.Context, company string) (*DomainResult, error) {
+	var resp DomainResult
+	req := ResolveDomainRequest{Company: company}
+	if err := c.query(ctx, "/api/v1/resolve-domain", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// InferPattern infers a company's email pattern from known addresses. Later
+// lookups for the company's domain only try the inferred patterns.
+func (c *Client) InferPattern(ctx context.Context, req InferPatternRequest) (*InferPatternResponse, error) {
+	var resp InferPatternResponse
+	if err := c.query(ctx, "/api/v1/infer-pattern", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// do sends a JSON request and decodes the response into out. Transient
+// failures are retried for GET and HEAD requests only.
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	return c.request(ctx, method, path, in, out, method == http.MethodGet || method == http.MethodHead)
+}
+
+// query POSTs a request the server answers without starting any work that
+// outlives it, so it is retried like a GET
+func (c *Client) query(ctx context.Context, path string, in, out interface{}) error {
+	return c.request(ctx, http.MethodPost, path, in, out, true)
+}
+
+// request sends a JSON request, retrying transient failures if retry is set,
+// and decodes the response into out
+func (c *Client) request(ctx context.Context, method, path string, in, out interface{}, retry bool) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		retryAfter, err := c.send(ctx, method, path, body, out)
+		if err == nil || !retry || retryAfter < 0 || attempt >= c.maxRetries {
+			return err
+		}
+
+		delay := c.backoff(attempt)
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+// send makes one attempt. retryAfter is negative if the request must not be
+// retried, else the minimum wait the server asked for.
+func (c *Client) send(ctx context.Context, method, path string, body []byte, out interface{}) (retryAfter time.Duration, err error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL.String()+path, reader)
+	if err != nil {
+		return -1, fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, values := range c.headers {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return -1, ctx.Err()
+		}
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		if json.Unmarshal(data, apiErr) != nil {
+			apiErr.Message = strings.TrimSpace(string(data))
+		}
+
+		switch resp.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return parseRetryAfter(resp.Header.Get("Retry-After")), apiErr
+		}
+		return -1, apiErr
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return -1, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return -1, nil
+}
+
+// backoff returns the delay before retry attempt+1: exponential with full jitter
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.baseDelay << attempt
+	if delay <= 0 || (c.maxDelay > 0 && delay > c.maxDelay) {
+		delay = c.maxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,152 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_FindEmail(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/find-email" {
+			t.Errorf("request = %s %s, want POST /api/v1/find-email", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q, want Bearer secret", got)
+		}
+		if got := r.Header.Get("X-Api-Key"); got != "key" {
+			t.Errorf("X-Api-Key = %q, want key", got)
+		}
+
+		var req FindEmailRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		json.NewEncoder(w).Encode(FindEmailResponse{
+			FoundEmails: []EmailResult{{Email: "john.doe@acme.com", IsReachable: "safe", Score: 95}},
+			TotalFound:  1,
+			Domain:      "acme.com",
+			Request:     req,
+		})
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL, WithToken("secret"), WithHeader("X-Api-Key", "key"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	resp, err := c.FindEmail(context.Background(), FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Acme"})
+	if err != nil {
+		t.Fatalf("FindEmail() error = %v", err)
+	}
+	if resp.TotalFound != 1 || resp.FoundEmails[0].Email != "john.doe@acme.com" || resp.Request.Company != "Acme" {
+		t.Errorf("FindEmail() = %+v, want john.doe@acme.com for Acme", resp)
+	}
+}
+
+func TestClient_RetriesUnavailable(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Email verification backend is unavailable"})
+			return
+		}
+		json.NewEncoder(w).Encode(DomainResult{Domain: "acme.com", Resolved: true, Method: "pattern"})
+	}))
+	defer server.Close()
+
+	c, _ := New(server.URL, WithRetries(3, time.Millisecond))
+
+	result, err := c.ResolveDomain(context.Background(), "Acme")
+	if err != nil {
+		t.Fatalf("ResolveDomain() error = %v", err)
+	}
+	if result.Domain != "acme.com" {
+		t.Errorf("ResolveDomain() domain = %q, want acme.com", result.Domain)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("server called %d times, want 3", got)
+	}
+}
+
+func TestClient_DoesNotRetryWork(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c, _ := New(server.URL, WithRetries(3, time.Millisecond))
+
+	// A job may have been queued before the 503, so submitting it again could run it twice
+	_, err := c.SubmitJob(context.Background(), []FindEmailRequest{{FirstName: "John", LastName: "Doe", Company: "Acme"}}, "")
+	if !IsUnavailable(err) {
+		t.Fatalf("SubmitJob() error = %v, want unavailable", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server called %d times, want 1 since a POST starting work is not retried", got)
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{
+			"error":   "Invalid emails",
+			"details": "invalid request: at least one email is required",
+		})
+	}))
+	defer server.Close()
+
+	c, _ := New(server.URL, WithRetries(3, time.Millisecond))
+
+	_, err := c.Verify(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Verify() error = %v, want an APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusBadRequest || apiErr.Message != "Invalid emails" {
+		t.Errorf("APIError = %+v, want 400 Invalid emails", apiErr)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server called %d times, want 1 since 400 is not retried", got)
+	}
+}
+
+func TestClient_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c, _ := New(server.URL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.Verify(ctx, "john@acme.com")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Verify() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Verify() took %s, want it to stop waiting when the context ends", elapsed)
+	}
+}
+
+func TestNew_InvalidURL(t *testing.T) {
+	if _, err := New("localhost:8080"); err == nil {
+		t.Error("New() without a scheme succeeded, want an error")
+	}
+}
@@ -0,0 +1,16 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Domain returns what the server learned about a domain from past lookups
+func (c *Client) Domain(ctx context.Context, domain string) (*DomainProfile, error) {
+	var resp DomainProfile
+	if err := c.do(ctx, http.MethodGet, "/api/v1/domains/"+url.PathEscape(domain), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
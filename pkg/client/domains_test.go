@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Domain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/domains/acme.com" {
+			http.NotFound(w, r)
+			return
+		}
+		catchAll := false
+		json.NewEncoder(w).Encode(DomainProfile{
+			Domain:    "acme.com",
+			MXRecords: []string{"aspmx.l.google.com."},
+			Provider:  "google_workspace",
+			CatchAll:  &catchAll,
+		})
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	profile, err := c.Domain(context.Background(), "acme.com")
+	if err != nil {
+		t.Fatalf("Domain() error = %v", err)
+	}
+	if profile.Provider != "google_workspace" || profile.CatchAll == nil || *profile.CatchAll {
+		t.Errorf("Domain() = %+v, want a Google Workspace domain that isn't catch-all", profile)
+	}
+
+	if _, err := c.Domain(context.Background(), "unknown.com"); err == nil {
+		t.Error("Domain() for an unknown domain succeeded, want an error")
+	}
+}
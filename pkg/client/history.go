@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// History returns the server's records of past lookups selected by q, newest
+// first. A zero Limit uses the server's default.
+func (c *Client) History(ctx context.Context, q HistoryQuery) ([]*HistoryRecord, error) {
+	params := url.Values{}
+	for name, value := range map[string]string{"email": q.Email, "domain": q.Domain, "company": q.Company} {
+		if value != "" {
+			params.Set(name, value)
+		}
+	}
+	if !q.From.IsZero() {
+		params.Set("from", q.From.Format(time.RFC3339))
+	}
+	if !q.To.IsZero() {
+		params.Set("to", q.To.Format(time.RFC3339))
+	}
+	if q.Limit > 0 {
+		params.Set("limit", strconv.Itoa(q.Limit))
+	}
+
+	path := "/api/v1/history"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	var resp struct {
+		Records []*HistoryRecord `json:"records"`
+	}
+	if err := c.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Records, nil
+}
+
+// HistoryRecord returns the record of one past lookup
+func (c *Client) HistoryRecord(ctx context.Context, id string) (*HistoryRecord, error) {
+	var resp HistoryRecord
+	if err := c.do(ctx, http.MethodGet, "/api/v1/history/"+url.PathEscape(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
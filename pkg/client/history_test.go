@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_History(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/history" {
+			http.NotFound(w, r)
+			return
+		}
+		query := r.URL.Query()
+		if query.Get("domain") != "acme.com" || query.Get("from") != "2026-10-01T00:00:00Z" || query.Get("limit") != "5" || query.Has("email") {
+			t.Errorf("query = %q, want domain, from and limit only", r.URL.RawQuery)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"records": []*HistoryRecord{{ID: "r1", LookupRecord: LookupRecord{Domain: "acme.com"}}},
+		})
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	records, err := c.History(context.Background(), HistoryQuery{
+		Domain: "acme.com",
+		From:   time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		Limit:  5,
+	})
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(records) != 1 || records[0].ID != "r1" || records[0].Domain != "acme.com" {
+		t.Errorf("History() = %+v, want record r1", records)
+	}
+
+	if _, err := c.HistoryRecord(context.Background(), "missing"); err == nil {
+		t.Error("HistoryRecord() for an unknown record succeeded, want an error")
+	}
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"email-finder/internal/jobs"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Job states, as reported in Job.Status and JobRow.State
+const (
+	JobPending   = jobs.JobPending
+	JobRunning   = jobs.JobRunning
+	JobCompleted = jobs.JobCompleted
+
+	RowPending   = jobs.RowPending
+	RowResolving = jobs.RowResolving
+	RowVerifying = jobs.RowVerifying
+	RowDone      = jobs.RowDone
+	RowFailed    = jobs.RowFailed
+)
+
+// SubmitJob queues a bulk enrichment job. The server keeps its progress across
+// restarts; poll Job for the status, or pass a callbackURL to be notified when
+// every row has finished.
+func (c *Client) SubmitJob(ctx context.Context, requests []FindEmailRequest, callbackURL string) (*Job, error) {
+	var resp Job
+	req := jobs.SubmitRequest{Requests: requests, CallbackURL: callbackURL}
+	if err := c.do(ctx, http.MethodPost, "/api/v1/jobs", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Job returns the status and row counts of a job
+func (c *Client) Job(ctx context.Context, id string) (*Job, error) {
+	var resp Job
+	if err := c.do(ctx, http.MethodGet, "/api/v1/jobs/"+url.PathEscape(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// JobResults returns up to limit rows of a job in request order, starting at offset
+func (c *Client) JobResults(ctx context.Context, id string, offset, limit int) (*JobResults, error) {
+	var resp JobResults
+	path := fmt.Sprintf("/api/v1/jobs/%s/results?offset=%d&limit=%d", url.PathEscape(id), offset, limit)
+	if err := c.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
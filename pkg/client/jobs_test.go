@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_Jobs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/v1/jobs":
+			var req struct {
+				Requests    []FindEmailRequest `json:"requests"`
+				CallbackURL string             `json:"callback_url"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("failed to decode request: %v", err)
+			}
+			w.WriteHeader(http.StatusAccepted)
+			json.NewEncoder(w).Encode(Job{ID: "job1", Status: JobPending, Total: len(req.Requests), CallbackURL: req.CallbackURL})
+		case "GET /api/v1/jobs/job1/results":
+			if got := r.URL.RawQuery; got != "offset=1&limit=1" {
+				t.Errorf("query = %q, want offset=1&limit=1", got)
+			}
+			json.NewEncoder(w).Encode(JobResults{
+				Job:    &Job{ID: "job1", Status: JobCompleted, Total: 2},
+				Offset: 1,
+				Rows:   []*JobRow{{JobID: "job1", Index: 1, State: RowDone}},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	requests := []FindEmailRequest{
+		{FirstName: "John", LastName: "Doe", Company: "Acme"},
+		{FirstName: "Jane", LastName: "Roe", Company: "Acme"},
+	}
+	job, err := c.SubmitJob(context.Background(), requests, "https://hooks.example.org/jobs")
+	if err != nil {
+		t.Fatalf("SubmitJob() error = %v", err)
+	}
+	if job.ID != "job1" || job.Total != 2 || job.CallbackURL != "https://hooks.example.org/jobs" {
+		t.Errorf("SubmitJob() = %+v, want job1 with 2 rows and the callback", job)
+	}
+
+	results, err := c.JobResults(context.Background(), "job1", 1, 1)
+	if err != nil {
+		t.Fatalf("JobResults() error = %v", err)
+	}
+	if results.Job.Status != JobCompleted || len(results.Rows) != 1 || results.Rows[0].State != RowDone {
+		t.Errorf("JobResults() = %+v, want the done second row of the completed job", results)
+	}
+
+	if _, err := c.Job(context.Background(), "missing"); err == nil {
+		t.Error("Job() for an unknown job succeeded, want an error")
+	}
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"email-finder/internal/domains"
+	"email-finder/internal/generator"
+	"email-finder/internal/history"
+	"email-finder/internal/jobs"
+	"email-finder/internal/resolver"
+	"email-finder/internal/service"
+	"email-finder/internal/verifier"
+	"email-finder/internal/webhook"
+)
+
+// The request and response types are the ones the server encodes, so the
+// client can't drift from the API
+
+// FindEmailRequest is the input of FindEmail
+type FindEmailRequest = service.FindEmailRequest
+
+// FindEmailResponse is the result of FindEmail
+type FindEmailResponse = service.FindEmailResponse
+
+// EmailResult is a found email with its verification details and score
+type EmailResult = service.EmailResult
+
+// ScoreBreakdown explains the score of an EmailResult
+type ScoreBreakdown = service.ScoreBreakdown
+
+// InferPatternRequest is the input of InferPattern
+type InferPatternRequest = service.InferPatternRequest
+
+// InferPatternResponse is the result of InferPattern
+type InferPatternResponse = service.InferPatternResponse
+
+// SampleAddress is a known address of a company, used to infer its pattern
+type SampleAddress = generator.SampleAddress
+
+// PatternMatch is a pattern that explains some of the sample addresses
+type PatternMatch = generator.PatternMatch
+
+// VerifyEmailsRequest is the input of the verify endpoint
+type VerifyEmailsRequest = service.VerifyEmailsRequest
+
+// VerifyEmailsResponse is the result of the verify endpoint
+type VerifyEmailsResponse = service.VerifyEmailsResponse
+
+// ResolveDomainRequest is the input of the resolve-domain endpoint
+type ResolveDomainRequest = service.ResolveDomainRequest
+
+// VerificationResult is the result of verifying one email
+type VerificationResult = verifier.VerificationResult
+
+// Verification details reported by check-if-email-exists
+type (
+	SyntaxDetails = verifier.SyntaxDetails
+	MXDetails     = verifier.MXDetails
+	SMTPDetails   = verifier.SMTPDetails
+	MiscDetails   = verifier.MiscDetails
+	CheckError    = verifier.CheckError
+	Failure       = verifier.Failure
+)
+
+// DomainResult is the result of ResolveDomain
+type DomainResult = resolver.DomainResult
+
+// MaxSyncBulkRequests is the most lookups FindEmailsBulk accepts; larger
+// batches are submitted with SubmitJob or FindEmailsBulkAsync
+const MaxSyncBulkRequests = service.MaxSyncBulkRequests
+
+// BulkFindRequest is the input of FindEmailsBulk
+type BulkFindRequest = service.BulkFindRequest
+
+// BulkFindResponse is the result of FindEmailsBulk
+type BulkFindResponse = service.BulkFindResponse
+
+// BulkFindResult is the outcome of one lookup of a bulk request
+type BulkFindResult = service.BulkFindResult
+
+// Accepted is returned for lookups whose result is delivered to a callback URL
+type Accepted = webhook.Accepted
+
+// Delivery reports a callback and its delivery attempts
+type Delivery = webhook.Delivery
+
+// Attempt is one attempt to deliver a callback
+type Attempt = webhook.Attempt
+
+// Job is a bulk enrichment job run from the server's durable queue
+type Job = jobs.Job
+
+// JobRow is one lookup of a job, with its state and result
+type JobRow = jobs.Row
+
+// JobResults is a page of the rows of a job
+type JobResults = jobs.Results
+
+// HistoryRecord is the server's record of a past lookup
+type HistoryRecord = history.Record
+
+// HistoryQuery selects records of past lookups. Empty fields match every record.
+type HistoryQuery = history.Query
+
+// LookupRecord is what a lookup resolved, tried, verified and returned
+type LookupRecord = service.LookupRecord
+
+// PatternTried is a candidate address generated by a lookup
+type PatternTried = service.PatternTried
+
+// DomainProfile is what the server learned about a domain
+type DomainProfile = domains.Profile
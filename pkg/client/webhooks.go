@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"email-finder/internal/webhook"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Headers sent with every callback
+const (
+	SignatureHeader = webhook.SignatureHeader
+	TimestampHeader = webhook.TimestampHeader
+	EventHeader     = webhook.EventHeader
+	DeliveryHeader  = webhook.DeliveryHeader
+)
+
+// FindEmailAsync starts a lookup whose result is POSTed to callbackURL when it
+// finishes. Poll Delivery with the returned ID to follow the callback.
+func (c *Client) FindEmailAsync(ctx context.Context, req FindEmailRequest, callbackURL string) (*Accepted, error) {
+	req.CallbackURL = callbackURL
+	var resp Accepted
+	if err := c.do(ctx, http.MethodPost, "/api/v1/find-email", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// FindEmailsBulk runs up to MaxSyncBulkRequests lookups and waits for all of
+// them. Use FindEmailsBulkAsync for a callback, or SubmitJob for more lookups.
+func (c *Client) FindEmailsBulk(ctx context.Context, requests []FindEmailRequest) (*BulkFindResponse, error) {
+	if len(requests) > MaxSyncBulkRequests {
+		return nil, fmt.Errorf("at most %d requests can be looked up at once, use SubmitJob for more", MaxSyncBulkRequests)
+	}
+	var resp BulkFindResponse
+	if err := c.query(ctx, "/api/v1/find-email/bulk", BulkFindRequest{Requests: requests}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// FindEmailsBulkAsync queues several lookups as a job; the job is POSTed to
+// callbackURL once all of them have finished, and its results are read with
+// JobResults
+func (c *Client) FindEmailsBulkAsync(ctx context.Context, requests []FindEmailRequest, callbackURL string) (*Job, error) {
+	var resp Job
+	req := BulkFindRequest{Requests: requests, CallbackURL: callbackURL}
+	if err := c.do(ctx, http.MethodPost, "/api/v1/find-email/bulk", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Delivery reports the status of an asynchronous lookup and its callback attempts
+func (c *Client) Delivery(ctx context.Context, id string) (*Delivery, error) {
+	var resp Delivery
+	if err := c.do(ctx, http.MethodGet, "/api/v1/webhooks/deliveries/"+url.PathEscape(id), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// VerifyWebhook checks the signature of a callback received from the email
+// finder, and that it was sent less than tolerance ago. body is the raw request body.
+func VerifyWebhook(secret string, header http.Header, body []byte, tolerance time.Duration) error {
+	timestamp := header.Get(TimestampHeader)
+	sent, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid %s header %q", TimestampHeader, timestamp)
+	}
+	if age := time.Since(time.Unix(sent, 0)); tolerance > 0 && (age > tolerance || age < -tolerance) {
+		return fmt.Errorf("webhook timestamp is %s old, outside the tolerance of %s", age.Round(time.Second), tolerance)
+	}
+	if !webhook.Verify(secret, timestamp, header.Get(SignatureHeader), body) {
+		return errors.New("invalid webhook signature")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"email-finder/internal/webhook"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestClient_FindEmailAsync(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req FindEmailRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.CallbackURL != "https://hooks.example.org/lookup" {
+			t.Errorf("CallbackURL = %q, want the callback", req.CallbackURL)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(Accepted{ID: "abc", Status: "processing", StatusURL: "/api/v1/webhooks/deliveries/abc"})
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	req := FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Acme"}
+	accepted, err := c.FindEmailAsync(context.Background(), req, "https://hooks.example.org/lookup")
+	if err != nil {
+		t.Fatalf("FindEmailAsync() error = %v", err)
+	}
+	if accepted.ID != "abc" || accepted.Status != "processing" {
+		t.Errorf("FindEmailAsync() = %+v, want delivery abc processing", accepted)
+	}
+
+	req.CallbackURL = "https://hooks.example.org/lookup"
+	if _, err := c.FindEmail(context.Background(), req); err == nil {
+		t.Error("FindEmail() with a callback URL succeeded, want an error")
+	}
+}
+
+func TestClient_FindEmailsBulkTooLarge(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	requests := make([]FindEmailRequest, MaxSyncBulkRequests+1)
+	if _, err := c.FindEmailsBulk(context.Background(), requests); err == nil {
+		t.Error("FindEmailsBulk() with too many requests succeeded, want an error")
+	}
+	if called {
+		t.Error("FindEmailsBulk() sent a request the server would queue as a job")
+	}
+}
+
+func TestVerifyWebhook(t *testing.T) {
+	body := []byte(`{"total_found":1}`)
+	sign := func(secret string, sent time.Time) http.Header {
+		timestamp := strconv.FormatInt(sent.Unix(), 10)
+		header := http.Header{}
+		header.Set(TimestampHeader, timestamp)
+		header.Set(SignatureHeader, webhook.Sign(secret, timestamp, body))
+		return header
+	}
+
+	if err := VerifyWebhook("s3cret", sign("s3cret", time.Now()), body, 5*time.Minute); err != nil {
+		t.Errorf("VerifyWebhook() error = %v, want nil", err)
+	}
+	if err := VerifyWebhook("s3cret", sign("other", time.Now()), body, 5*time.Minute); err == nil {
+		t.Error("VerifyWebhook() accepted a signature made with another secret")
+	}
+	if err := VerifyWebhook("s3cret", sign("s3cret", time.Now().Add(-time.Hour)), body, 5*time.Minute); err == nil {
+		t.Error("VerifyWebhook() accepted a callback sent an hour ago")
+	}
+	if err := VerifyWebhook("s3cret", sign("s3cret", time.Now()), []byte(`{"total_found":2}`), 5*time.Minute); err == nil {
+		t.Error("VerifyWebhook() accepted a tampered body")
+	}
+}
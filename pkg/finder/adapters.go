@@ -0,0 +1,135 @@
+package finder
+
+import (
+	"context"
+	"email-finder/internal/resolver"
+	"email-finder/internal/service"
+	"email-finder/internal/verifier"
+	"fmt"
+)
+
+// verifierAdapter lets the service use a Verifier
+type verifierAdapter struct {
+	v Verifier
+}
+
+func (a verifierAdapter) VerifyEmail(ctx context.Context, email string) (*verifier.VerificationResult, error) {
+	result, err := a.v.VerifyEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	return toVerifierResult(result), nil
+}
+
+func (a verifierAdapter) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*verifier.VerificationResult, error) {
+	results, err := a.v.VerifyEmailsBatch(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(emails) {
+		return nil, fmt.Errorf("finder: verifier returned %d results for %d emails", len(results), len(emails))
+	}
+	return convertAll(results, toVerifierResult), nil
+}
+
+// builtinVerifier is a Verifier returned by NewHTTPVerifier or NewCLIVerifier
+type builtinVerifier struct {
+	v verifier.Verifier
+}
+
+func (b builtinVerifier) VerifyEmail(ctx context.Context, email string) (*VerificationResult, error) {
+	result, err := b.v.VerifyEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	return fromVerifierResult(result), nil
+}
+
+func (b builtinVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*VerificationResult, error) {
+	results, err := b.v.VerifyEmailsBatch(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+	return convertAll(results, fromVerifierResult), nil
+}
+
+// HealthCheck reports whether the check-if-email-exists backend is reachable
+func (b builtinVerifier) HealthCheck(ctx context.Context) error {
+	if hc, ok := b.v.(verifier.HealthChecker); ok {
+		return hc.HealthCheck(ctx)
+	}
+	return nil
+}
+
+// internalVerifier returns the verifier the service uses for v, skipping the
+// conversions for the built-in verifiers
+func internalVerifier(v Verifier) verifier.Verifier {
+	if b, ok := v.(builtinVerifier); ok {
+		return b.v
+	}
+	return verifierAdapter{v: v}
+}
+
+// resolverAdapter lets the service use a DomainResolver
+type resolverAdapter struct {
+	r DomainResolver
+}
+
+func (a resolverAdapter) ResolveDomain(ctx context.Context, companyName string) *resolver.DomainResult {
+	return toResolverResult(a.r.ResolveDomain(ctx, companyName))
+}
+
+// AddCompanyDomain passes learned domains on when the resolver can remember them
+func (a resolverAdapter) AddCompanyDomain(companyName, domain string) {
+	if recorder, ok := a.r.(CompanyDomainRecorder); ok {
+		recorder.AddCompanyDomain(companyName, domain)
+	}
+}
+
+// builtinResolver is the DomainResolver returned by NewDomainResolver
+type builtinResolver struct {
+	r *resolver.DomainResolver
+}
+
+func (b builtinResolver) ResolveDomain(ctx context.Context, companyName string) *DomainResult {
+	return fromResolverResult(b.r.ResolveDomain(ctx, companyName))
+}
+
+// AddCompanyDomain makes later lookups of companyName use domain
+func (b builtinResolver) AddCompanyDomain(companyName, domain string) {
+	b.r.AddCompanyDomain(companyName, domain)
+}
+
+// internalResolver returns the resolver the service uses for r
+func internalResolver(r DomainResolver) service.DomainResolver {
+	if b, ok := r.(builtinResolver); ok {
+		return b.r
+	}
+	return resolverAdapter{r: r}
+}
+
+// recorderAdapter lets the service use a Recorder
+type recorderAdapter struct {
+	r Recorder
+}
+
+func (a recorderAdapter) Record(rec *service.LookupRecord) {
+	a.r.Record(fromLookupRecord(rec))
+}
+
+// profilesAdapter lets the service use DomainProfiles
+type profilesAdapter struct {
+	p DomainProfiles
+}
+
+func (a profilesAdapter) ObserveVerifications(domain string, results []*verifier.VerificationResult, pattern string) {
+	a.p.ObserveVerifications(domain, convertAll(results, fromVerifierResult), pattern)
+}
+
+func (a profilesAdapter) ObservePatterns(domain string, patterns []string) {
+	a.p.ObservePatterns(domain, patterns)
+}
+
+func (a profilesAdapter) LearnedPatterns(domain string) []string {
+	return a.p.LearnedPatterns(domain)
+}
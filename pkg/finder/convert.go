@@ -0,0 +1,259 @@
+package finder
+
+import (
+	"email-finder/internal/generator"
+	"email-finder/internal/resolver"
+	"email-finder/internal/service"
+	"email-finder/internal/verifier"
+)
+
+// The exported types are copies of the server's, so that the server can
+// change without changing this package. These functions convert between them.
+
+// convertAll converts every element of in, keeping nil slices nil
+func convertAll[T, U any](in []T, convert func(T) U) []U {
+	if in == nil {
+		return nil
+	}
+	out := make([]U, len(in))
+	for i, v := range in {
+		out[i] = convert(v)
+	}
+	return out
+}
+
+func toServiceRequest(r FindEmailRequest) service.FindEmailRequest {
+	return service.FindEmailRequest{
+		FirstName:        r.FirstName,
+		LastName:         r.LastName,
+		Company:          r.Company,
+		SkipVerification: r.SkipVerification,
+		Include:          r.Include,
+		CallbackURL:      r.CallbackURL,
+		Refresh:          r.Refresh,
+	}
+}
+
+func fromServiceRequest(r service.FindEmailRequest) FindEmailRequest {
+	return FindEmailRequest{
+		FirstName:        r.FirstName,
+		LastName:         r.LastName,
+		Company:          r.Company,
+		SkipVerification: r.SkipVerification,
+		Include:          r.Include,
+		CallbackURL:      r.CallbackURL,
+		Refresh:          r.Refresh,
+	}
+}
+
+func fromServiceResponse(r *service.FindEmailResponse) *FindEmailResponse {
+	if r == nil {
+		return nil
+	}
+	resp := &FindEmailResponse{
+		FoundEmails:      convertAll(r.FoundEmails, fromServiceEmail),
+		TotalChecked:     r.TotalChecked,
+		TotalFound:       r.TotalFound,
+		Domain:           r.Domain,
+		DomainResolved:   r.DomainResolved,
+		Request:          fromServiceRequest(r.Request),
+		InferredPatterns: r.InferredPatterns,
+		Verified:         r.Verified,
+		StatusBreakdown:  r.StatusBreakdown,
+		CachedAt:         r.CachedAt,
+	}
+	if r.BestGuess != nil {
+		guess := fromServiceEmail(*r.BestGuess)
+		resp.BestGuess = &guess
+	}
+	return resp
+}
+
+func fromServiceEmail(e service.EmailResult) EmailResult {
+	return EmailResult{
+		Email:         e.Email,
+		Pattern:       e.Pattern,
+		IsReachable:   e.IsReachable,
+		IsValid:       e.IsValid,
+		IsDeliverable: e.IsDeliverable,
+		Confidence:    e.Confidence,
+		Score:         e.Score,
+		ScoreBreakdown: ScoreBreakdown{
+			Status:           e.ScoreBreakdown.Status,
+			Deliverability:   e.ScoreBreakdown.Deliverability,
+			CatchAll:         e.ScoreBreakdown.CatchAll,
+			PatternPrior:     e.ScoreBreakdown.PatternPrior,
+			DomainResolution: e.ScoreBreakdown.DomainResolution,
+		},
+		MXRecords:      e.MXRecords,
+		CanConnectSMTP: e.CanConnectSMTP,
+		IsCatchAll:     e.IsCatchAll,
+		IsDisabled:     e.IsDisabled,
+		HasFullInbox:   e.HasFullInbox,
+		IsDisposable:   e.IsDisposable,
+		IsRoleAccount:  e.IsRoleAccount,
+		SMTPError:      fromCheckError(e.SMTPError),
+		MXError:        fromCheckError(e.MXError),
+	}
+}
+
+func fromServiceBulkResponse(r *service.BulkFindResponse) *BulkFindResponse {
+	return &BulkFindResponse{
+		Results: convertAll(r.Results, func(res service.BulkFindResult) BulkFindResult {
+			return BulkFindResult{Index: res.Index, Result: fromServiceResponse(res.Result), Error: res.Error}
+		}),
+		Total:  r.Total,
+		Failed: r.Failed,
+	}
+}
+
+func toServiceInferRequest(r InferPatternRequest) service.InferPatternRequest {
+	return service.InferPatternRequest{
+		Company: r.Company,
+		Samples: convertAll(r.Samples, toGeneratorSample),
+	}
+}
+
+func fromServiceInferResponse(r *service.InferPatternResponse) *InferPatternResponse {
+	return &InferPatternResponse{
+		Domain:           r.Domain,
+		InferredPatterns: r.InferredPatterns,
+		Candidates:       convertAll(r.Candidates, fromGeneratorMatch),
+		TotalSamples:     r.TotalSamples,
+		Request: InferPatternRequest{
+			Company: r.Request.Company,
+			Samples: convertAll(r.Request.Samples, func(s generator.SampleAddress) SampleAddress {
+				return SampleAddress{FirstName: s.FirstName, LastName: s.LastName, Email: s.Email}
+			}),
+		},
+	}
+}
+
+func toGeneratorSample(s SampleAddress) generator.SampleAddress {
+	return generator.SampleAddress{FirstName: s.FirstName, LastName: s.LastName, Email: s.Email}
+}
+
+func fromGeneratorMatch(m generator.PatternMatch) PatternMatch {
+	return PatternMatch{Pattern: m.Pattern, Matches: m.Matches}
+}
+
+func fromLookupRecord(r *service.LookupRecord) *LookupRecord {
+	return &LookupRecord{
+		Request:        fromServiceRequest(r.Request),
+		Domain:         r.Domain,
+		DomainMethod:   r.DomainMethod,
+		DomainResolved: r.DomainResolved,
+		Patterns: convertAll(r.Patterns, func(p service.PatternTried) PatternTried {
+			return PatternTried{Email: p.Email, Pattern: p.Pattern}
+		}),
+		Verifications: convertAll(r.Verifications, fromVerifierResult),
+		Response:      fromServiceResponse(r.Response),
+		Error:         r.Error,
+		StartedAt:     r.StartedAt,
+		DurationMS:    r.DurationMS,
+	}
+}
+
+func toResolverResult(r *DomainResult) *resolver.DomainResult {
+	if r == nil {
+		return nil
+	}
+	return &resolver.DomainResult{Domain: r.Domain, Resolved: r.Resolved, Method: r.Method, Candidates: r.Candidates}
+}
+
+func fromResolverResult(r *resolver.DomainResult) *DomainResult {
+	if r == nil {
+		return nil
+	}
+	return &DomainResult{Domain: r.Domain, Resolved: r.Resolved, Method: r.Method, Candidates: r.Candidates}
+}
+
+func fromVerifierResult(r *verifier.VerificationResult) *VerificationResult {
+	if r == nil {
+		return nil
+	}
+	result := &VerificationResult{
+		Email:         r.Email,
+		IsReachable:   r.IsReachable,
+		IsValid:       r.IsValid,
+		IsDeliverable: r.IsDeliverable,
+		Details:       r.Details,
+		MXError:       fromCheckError(r.MXError),
+		SMTPError:     fromCheckError(r.SMTPError),
+		MiscError:     fromCheckError(r.MiscError),
+	}
+	if r.Syntax != nil {
+		result.Syntax = &SyntaxDetails{Address: r.Syntax.Address, Domain: r.Syntax.Domain, Username: r.Syntax.Username, IsValidSyntax: r.Syntax.IsValidSyntax}
+	}
+	if r.MX != nil {
+		result.MX = &MXDetails{AcceptsMail: r.MX.AcceptsMail, Records: r.MX.Records}
+	}
+	if r.SMTP != nil {
+		result.SMTP = &SMTPDetails{
+			CanConnectSMTP: r.SMTP.CanConnectSMTP,
+			HasFullInbox:   r.SMTP.HasFullInbox,
+			IsCatchAll:     r.SMTP.IsCatchAll,
+			IsDeliverable:  r.SMTP.IsDeliverable,
+			IsDisabled:     r.SMTP.IsDisabled,
+		}
+	}
+	if r.Misc != nil {
+		result.Misc = &MiscDetails{IsDisposable: r.Misc.IsDisposable, IsRoleAccount: r.Misc.IsRoleAccount, GravatarURL: r.Misc.GravatarURL}
+	}
+	if r.Failure != nil {
+		result.Failure = &Failure{Kind: r.Failure.Kind, Message: r.Failure.Message, Transient: r.Failure.Transient, Greylisted: r.Failure.Greylisted}
+	}
+	return result
+}
+
+func toVerifierResult(r *VerificationResult) *verifier.VerificationResult {
+	if r == nil {
+		return nil
+	}
+	result := &verifier.VerificationResult{
+		Email:         r.Email,
+		IsReachable:   r.IsReachable,
+		IsValid:       r.IsValid,
+		IsDeliverable: r.IsDeliverable,
+		Details:       r.Details,
+		MXError:       toCheckError(r.MXError),
+		SMTPError:     toCheckError(r.SMTPError),
+		MiscError:     toCheckError(r.MiscError),
+	}
+	if r.Syntax != nil {
+		result.Syntax = &verifier.SyntaxDetails{Address: r.Syntax.Address, Domain: r.Syntax.Domain, Username: r.Syntax.Username, IsValidSyntax: r.Syntax.IsValidSyntax}
+	}
+	if r.MX != nil {
+		result.MX = &verifier.MXDetails{AcceptsMail: r.MX.AcceptsMail, Records: r.MX.Records}
+	}
+	if r.SMTP != nil {
+		result.SMTP = &verifier.SMTPDetails{
+			CanConnectSMTP: r.SMTP.CanConnectSMTP,
+			HasFullInbox:   r.SMTP.HasFullInbox,
+			IsCatchAll:     r.SMTP.IsCatchAll,
+			IsDeliverable:  r.SMTP.IsDeliverable,
+			IsDisabled:     r.SMTP.IsDisabled,
+		}
+	}
+	if r.Misc != nil {
+		result.Misc = &verifier.MiscDetails{IsDisposable: r.Misc.IsDisposable, IsRoleAccount: r.Misc.IsRoleAccount, GravatarURL: r.Misc.GravatarURL}
+	}
+	if r.Failure != nil {
+		result.Failure = &verifier.Failure{Kind: r.Failure.Kind, Message: r.Failure.Message, Transient: r.Failure.Transient, Greylisted: r.Failure.Greylisted}
+	}
+	return result
+}
+
+func fromCheckError(e *verifier.CheckError) *CheckError {
+	if e == nil {
+		return nil
+	}
+	return &CheckError{Type: e.Type, Message: e.Message, Code: e.Code}
+}
+
+func toCheckError(e *CheckError) *verifier.CheckError {
+	if e == nil {
+		return nil
+	}
+	return &verifier.CheckError{Type: e.Type, Message: e.Message, Code: e.Code}
+}
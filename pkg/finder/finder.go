@@ -0,0 +1,293 @@
+// Package finder finds and verifies people's email addresses: it resolves a
+// company's domain, generates the likely address patterns and verifies them
+// with check-if-email-exists. It is the library behind the email finder
+// server and can be embedded in other Go programs.
+//
+//	f, err := finder.New(
+//		finder.WithVerifier(finder.NewHTTPVerifier("http://localhost:8081")),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	result, err := f.FindEmails(ctx, finder.FindEmailRequest{
+//		FirstName: "John",
+//		LastName:  "Doe",
+//		Company:   "Acme",
+//	})
+//
+// The package follows semantic versioning; see Version.
+package finder
+
+import (
+	"context"
+	"email-finder/internal/generator"
+	"email-finder/internal/resolver"
+	"email-finder/internal/service"
+	"email-finder/internal/verifier"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Version is the version of this package's API. Within a major version,
+// exported identifiers are only added, never removed or changed.
+const Version = "1.0.0"
+
+// Finder looks up email addresses. It is safe for concurrent use.
+type Finder struct {
+	service *service.EmailFinderService
+}
+
+// Verifier checks whether email addresses exist. Implement it to plug in a
+// verification backend other than check-if-email-exists. VerifyEmailsBatch
+// returns one result per email, in order.
+type Verifier interface {
+	VerifyEmail(ctx context.Context, email string) (*VerificationResult, error)
+	VerifyEmailsBatch(ctx context.Context, emails []string) ([]*VerificationResult, error)
+}
+
+// HealthChecker is implemented by verifiers that can report whether their backend is reachable
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// DomainResolver finds the email domain of a company. Implement it to plug in
+// your own company database.
+type DomainResolver interface {
+	ResolveDomain(ctx context.Context, companyName string) *DomainResult
+}
+
+// CompanyDomainRecorder is implemented by domain resolvers that can remember
+// the domain of a company learned by InferPattern
+type CompanyDomainRecorder interface {
+	AddCompanyDomain(companyName, domain string)
+}
+
+// Recorder receives a LookupRecord for every finished lookup; pass it to
+// Finder.SetRecorder to keep an audit trail. Record must not block.
+type Recorder interface {
+	Record(rec *LookupRecord)
+}
+
+// DomainProfiles keeps what lookups learn about domains; pass it to
+// Finder.SetDomainProfiles to remember MX hosts, catch-all status and patterns
+type DomainProfiles interface {
+	// ObserveVerifications records the verification results of a lookup at
+	// the domain; pattern is that of the only address found deliverable, if any
+	ObserveVerifications(domain string, results []*VerificationResult, pattern string)
+	// ObservePatterns records the patterns inferred from sample addresses
+	ObservePatterns(domain string, patterns []string)
+	// LearnedPatterns returns the patterns recorded by ObservePatterns
+	LearnedPatterns(domain string) []string
+}
+
+// Errors
+var (
+	// ErrInvalidRequest is returned when a lookup is missing required input
+	ErrInvalidRequest = service.ErrInvalidRequest
+	// ErrInvalidSamples is returned when InferPattern can't use the sample addresses
+	ErrInvalidSamples = service.ErrInvalidSamples
+	// ErrCircuitOpen is returned while a verification backend is failing
+	ErrCircuitOpen = verifier.ErrCircuitOpen
+)
+
+// Defaults used when an option isn't given
+const (
+	DefaultMaxPatterns = 200
+	DefaultTimeout     = 30 * time.Second
+	DefaultConcurrency = 10
+)
+
+// options holds the settings of New
+type options struct {
+	verifier    Verifier
+	resolver    DomainResolver
+	logger      *zap.Logger
+	maxPatterns int
+	timeout     time.Duration
+
+	lookupCacheTTL  time.Duration
+	lookupCacheSize int
+	coalesce        bool
+}
+
+// Option configures a Finder
+type Option func(*options)
+
+// WithVerifier sets the verifier used to check generated addresses. It is required.
+func WithVerifier(v Verifier) Option {
+	return func(o *options) {
+		o.verifier = v
+	}
+}
+
+// WithDomainResolver replaces the built-in domain resolver
+func WithDomainResolver(r DomainResolver) Option {
+	return func(o *options) {
+		o.resolver = r
+	}
+}
+
+// WithLogger sets the logger; by default nothing is logged
+func WithLogger(logger *zap.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// WithMaxPatterns limits the patterns verified per lookup; 0 means no limit
+func WithMaxPatterns(n int) Option {
+	return func(o *options) {
+		o.maxPatterns = n
+	}
+}
+
+// WithResolverTimeout sets the DNS timeout of the built-in domain resolver
+func WithResolverTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithLookupCache reuses the verification results of a lookup of the same
+// person at the same domain for ttl, keeping up to maxEntries of them, and makes
+// identical lookups running at the same time share one verification run. With
+// a ttl of 0, lookups are only shared. A request can bypass the cache with Refresh.
+func WithLookupCache(ttl time.Duration, maxEntries int) Option {
+	return func(o *options) {
+		o.lookupCacheTTL = ttl
+		o.lookupCacheSize = maxEntries
+		o.coalesce = true
+	}
+}
+
+// New creates a Finder
+func New(opts ...Option) (*Finder, error) {
+	o := &options{
+		logger:      zap.NewNop(),
+		maxPatterns: DefaultMaxPatterns,
+		timeout:     DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.verifier == nil {
+		return nil, errors.New("finder: a verifier is required, see WithVerifier")
+	}
+	if o.maxPatterns < 0 {
+		return nil, errors.New("finder: max patterns must not be negative")
+	}
+	if o.resolver == nil {
+		o.resolver = NewDomainResolver(o.timeout, o.logger)
+	}
+
+	if o.lookupCacheTTL < 0 || o.lookupCacheSize < 0 {
+		return nil, errors.New("finder: lookup cache ttl and size must not be negative")
+	}
+
+	s := service.NewEmailFinderService(internalVerifier(o.verifier), internalResolver(o.resolver), o.logger, o.maxPatterns)
+	if o.coalesce {
+		s.SetLookupCache(service.NewLookupCache(o.lookupCacheTTL, o.lookupCacheSize))
+	}
+	return &Finder{service: s}, nil
+}
+
+// FindEmails resolves the company's domain and verifies the likely addresses of the person
+func (f *Finder) FindEmails(ctx context.Context, req FindEmailRequest) (*FindEmailResponse, error) {
+	resp, err := f.service.FindEmails(ctx, toServiceRequest(req))
+	return fromServiceResponse(resp), err
+}
+
+// FindEmailsStream is FindEmails, calling onResult with each verification
+// result as soon as it is known
+func (f *Finder) FindEmailsStream(ctx context.Context, req FindEmailRequest, onResult func(*VerificationResult)) (*FindEmailResponse, error) {
+	resp, err := f.service.FindEmailsStream(ctx, toServiceRequest(req), func(result *verifier.VerificationResult) {
+		onResult(fromVerifierResult(result))
+	})
+	return fromServiceResponse(resp), err
+}
+
+// FindEmailsBulk looks up several people, a few at a time, and reports the
+// outcome of each lookup in request order
+func (f *Finder) FindEmailsBulk(ctx context.Context, req BulkFindRequest) (*BulkFindResponse, error) {
+	resp, err := f.service.FindEmailsBulk(ctx, service.BulkFindRequest{
+		Requests:    convertAll(req.Requests, toServiceRequest),
+		CallbackURL: req.CallbackURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return fromServiceBulkResponse(resp), nil
+}
+
+// VerifyEmails verifies the given addresses as-is
+func (f *Finder) VerifyEmails(ctx context.Context, req VerifyEmailsRequest) (*VerifyEmailsResponse, error) {
+	resp, err := f.service.VerifyEmails(ctx, service.VerifyEmailsRequest{Emails: req.Emails})
+	if err != nil {
+		return nil, err
+	}
+	return &VerifyEmailsResponse{Results: convertAll(resp.Results, fromVerifierResult)}, nil
+}
+
+// ResolveDomain finds the email domain of a company
+func (f *Finder) ResolveDomain(ctx context.Context, req ResolveDomainRequest) (*DomainResult, error) {
+	result, err := f.service.ResolveDomain(ctx, service.ResolveDomainRequest{Company: req.Company})
+	if err != nil {
+		return nil, err
+	}
+	return fromResolverResult(result), nil
+}
+
+// InferPattern works out which patterns a company uses from known sample
+// addresses; later lookups at the domain try those patterns first
+func (f *Finder) InferPattern(req InferPatternRequest) (*InferPatternResponse, error) {
+	resp, err := f.service.InferPattern(toServiceInferRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return fromServiceInferResponse(resp), nil
+}
+
+// LearnedPatterns returns the patterns inferred for domain, best first
+func (f *Finder) LearnedPatterns(domain string) []string {
+	return f.service.LearnedPatterns(domain)
+}
+
+// SetMaxPatterns changes the maximum number of patterns verified per lookup; 0 means no limit
+func (f *Finder) SetMaxPatterns(n int) {
+	f.service.SetMaxPatterns(n)
+}
+
+// SetRecorder records every lookup with r. It must be called before the
+// Finder is used.
+func (f *Finder) SetRecorder(r Recorder) {
+	f.service.SetRecorder(recorderAdapter{r: r})
+}
+
+// SetDomainProfiles makes lookups record what they learn about domains in p,
+// and keeps the patterns inferred from sample addresses there. It must be
+// called before the Finder is used.
+func (f *Finder) SetDomainProfiles(p DomainProfiles) {
+	f.service.SetDomainProfiles(profilesAdapter{p: p})
+}
+
+// NewDomainResolver creates the built-in domain resolver, which maps well-known
+// companies and otherwise checks the DNS of candidate domains
+func NewDomainResolver(timeout time.Duration, logger *zap.Logger) DomainResolver {
+	return builtinResolver{r: resolver.NewDomainResolver(logger, timeout)}
+}
+
+// GenerateEmailPatterns returns the candidate addresses for a person at domain,
+// most common patterns first
+func GenerateEmailPatterns(firstName, lastName, domain string) []EmailPattern {
+	return convertAll(generator.GenerateEmailPatterns(firstName, lastName, domain), func(p generator.EmailPattern) EmailPattern {
+		return EmailPattern{Email: p.Email, Pattern: p.Pattern}
+	})
+}
+
+// InferPatterns works out which patterns explain the sample addresses, best first
+func InferPatterns(samples []SampleAddress) []PatternMatch {
+	return convertAll(generator.InferPatterns(convertAll(samples, toGeneratorSample)), fromGeneratorMatch)
+}
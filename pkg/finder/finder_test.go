@@ -0,0 +1,154 @@
+package finder
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeVerifier accepts only the addresses in valid
+type fakeVerifier struct {
+	valid map[string]bool
+}
+
+func (f *fakeVerifier) VerifyEmail(ctx context.Context, email string) (*VerificationResult, error) {
+	if f.valid[email] {
+		return &VerificationResult{Email: email, IsReachable: "safe", IsValid: true, IsDeliverable: true}, nil
+	}
+	return &VerificationResult{Email: email, IsReachable: "invalid", IsValid: true}, nil
+}
+
+func (f *fakeVerifier) VerifyEmailsBatch(ctx context.Context, emails []string) ([]*VerificationResult, error) {
+	results := make([]*VerificationResult, 0, len(emails))
+	for _, email := range emails {
+		result, _ := f.VerifyEmail(ctx, email)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// fakeResolver maps every company to one domain
+type fakeResolver struct {
+	domain string
+}
+
+func (f *fakeResolver) ResolveDomain(ctx context.Context, companyName string) *DomainResult {
+	return &DomainResult{Domain: f.domain, Resolved: true, Method: "company_map"}
+}
+
+func TestNew_PluggableVerifierAndResolver(t *testing.T) {
+	f, err := New(
+		WithVerifier(&fakeVerifier{valid: map[string]bool{"john.doe@example.org": true}}),
+		WithDomainResolver(&fakeResolver{domain: "example.org"}),
+		WithMaxPatterns(5),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	resp, err := f.FindEmails(context.Background(), FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Example"})
+	if err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if resp.Domain != "example.org" {
+		t.Errorf("Domain = %q, want example.org from the custom resolver", resp.Domain)
+	}
+	if resp.TotalChecked != 5 {
+		t.Errorf("TotalChecked = %d, want 5", resp.TotalChecked)
+	}
+	if resp.TotalFound != 1 || resp.FoundEmails[0].Email != "john.doe@example.org" {
+		t.Errorf("FoundEmails = %+v, want only john.doe@example.org", resp.FoundEmails)
+	}
+}
+
+func TestNew_RequiresVerifier(t *testing.T) {
+	if _, err := New(); err == nil {
+		t.Error("New() without a verifier succeeded, want an error")
+	}
+}
+
+func TestFinder_VerifyEmailsInvalid(t *testing.T) {
+	f, err := New(WithVerifier(&fakeVerifier{}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	if _, err := f.VerifyEmails(context.Background(), VerifyEmailsRequest{}); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("VerifyEmails() with no emails error = %v, want ErrInvalidRequest", err)
+	}
+}
+
+// recordingResolver remembers the domains added by InferPattern
+type recordingResolver struct {
+	fakeResolver
+	added map[string]string
+}
+
+func (r *recordingResolver) AddCompanyDomain(companyName, domain string) {
+	r.added[companyName] = domain
+}
+
+// recorder keeps every lookup record
+type recorder struct {
+	records []*LookupRecord
+}
+
+func (r *recorder) Record(rec *LookupRecord) {
+	r.records = append(r.records, rec)
+}
+
+func TestFinder_ConvertsAtTheBoundary(t *testing.T) {
+	res := &recordingResolver{fakeResolver: fakeResolver{domain: "example.org"}, added: map[string]string{}}
+	rec := &recorder{}
+	f, err := New(
+		WithVerifier(&fakeVerifier{valid: map[string]bool{"john.doe@example.org": true}}),
+		WithDomainResolver(res),
+		WithMaxPatterns(3),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	f.SetRecorder(rec)
+
+	inferred, err := f.InferPattern(InferPatternRequest{
+		Company: "Example",
+		Samples: []SampleAddress{{FirstName: "Jane", LastName: "Roe", Email: "jane.roe@example.org"}},
+	})
+	if err != nil {
+		t.Fatalf("InferPattern() error = %v", err)
+	}
+	if len(inferred.InferredPatterns) == 0 || inferred.Request.Samples[0].Email != "jane.roe@example.org" {
+		t.Errorf("InferPattern() = %+v, want the inferred patterns and the request", inferred)
+	}
+	if res.added["Example"] != "example.org" {
+		t.Errorf("AddCompanyDomain() recorded %v, want Example mapped to example.org", res.added)
+	}
+
+	resp, err := f.FindEmails(context.Background(), FindEmailRequest{FirstName: "John", LastName: "Doe", Company: "Example"})
+	if err != nil {
+		t.Fatalf("FindEmails() error = %v", err)
+	}
+	if len(resp.FoundEmails) != 1 || resp.FoundEmails[0].ScoreBreakdown.Total() != resp.FoundEmails[0].Score {
+		t.Errorf("FoundEmails = %+v, want john.doe@example.org scored by its breakdown", resp.FoundEmails)
+	}
+
+	if len(rec.records) != 1 {
+		t.Fatalf("Record() called %d times, want 1", len(rec.records))
+	}
+	got := rec.records[0]
+	if got.Request.FirstName != "John" || got.Response == nil || len(got.Verifications) != resp.TotalChecked {
+		t.Errorf("LookupRecord = %+v, want the request, the response and every verification", got)
+	}
+}
+
+func TestGenerateAndInferPatterns(t *testing.T) {
+	patterns := GenerateEmailPatterns("John", "Doe", "example.org")
+	if len(patterns) == 0 || patterns[0].Email == "" || patterns[0].Pattern == "" {
+		t.Fatalf("GenerateEmailPatterns() = %+v, want candidate addresses", patterns)
+	}
+
+	matches := InferPatterns([]SampleAddress{{FirstName: "John", LastName: "Doe", Email: patterns[0].Email}})
+	if len(matches) == 0 || matches[0].Pattern != patterns[0].Pattern || matches[0].Matches != 1 {
+		t.Errorf("InferPatterns() = %+v, want %q explaining the sample", matches, patterns[0].Pattern)
+	}
+}
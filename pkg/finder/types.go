@@ -0,0 +1,283 @@
+package finder
+
+import "time"
+
+// FindEmailRequest is a person to look up
+type FindEmailRequest struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Company   string `json:"company"`
+
+	// SkipVerification returns the address built from the domain's inferred pattern
+	// without SMTP verification. It has no effect when no pattern has been inferred.
+	SkipVerification bool `json:"skip_verification,omitempty"`
+
+	// Include adds results with these is_reachable statuses to FoundEmails,
+	// on top of safe and deliverable risky ones: "risky", "unknown", "invalid"
+	Include []string `json:"include,omitempty"`
+
+	// CallbackURL is only used by the server, which POSTs the response to it
+	CallbackURL string `json:"callback_url,omitempty"`
+
+	// Refresh verifies the addresses again instead of reusing the results of a
+	// recent lookup of the same person at the same domain
+	Refresh bool `json:"refresh,omitempty"`
+}
+
+// EmailResult is a found email with its verification details
+type EmailResult struct {
+	Email         string `json:"email"`
+	Pattern       string `json:"pattern"`
+	IsReachable   string `json:"is_reachable"`
+	IsValid       bool   `json:"is_valid"`
+	IsDeliverable bool   `json:"is_deliverable"`
+	Confidence    string `json:"confidence"` // high, medium, low (derived from Score)
+
+	// Score is the probability-like confidence in the email, 0-100
+	Score          int            `json:"score"`
+	ScoreBreakdown ScoreBreakdown `json:"score_breakdown"`
+
+	// Verifier details, reported as-is from check-if-email-exists
+	MXRecords      []string    `json:"mx_records,omitempty"`
+	CanConnectSMTP bool        `json:"can_connect_smtp"`
+	IsCatchAll     bool        `json:"is_catch_all"`
+	IsDisabled     bool        `json:"is_disabled"`
+	HasFullInbox   bool        `json:"has_full_inbox"`
+	IsDisposable   bool        `json:"is_disposable"`
+	IsRoleAccount  bool        `json:"is_role_account"`
+	SMTPError      *CheckError `json:"smtp_error,omitempty"`
+	MXError        *CheckError `json:"mx_error,omitempty"`
+}
+
+// ScoreBreakdown documents the components that make up an email's score.
+// The components always sum to the score before it is clamped to 0-100.
+type ScoreBreakdown struct {
+	// Status reflects the verifier's is_reachable verdict:
+	// safe 45, risky 20, unknown 5, invalid 0
+	Status int `json:"status"`
+	// Deliverability is 20 when the SMTP server accepted the mailbox
+	Deliverability int `json:"deliverability"`
+	// CatchAll is -15 when the domain accepts mail for any address
+	CatchAll int `json:"catch_all"`
+	// PatternPrior is 0-20 depending on how common the pattern is in practice
+	PatternPrior int `json:"pattern_prior"`
+	// DomainResolution is 0-15 depending on how confidently the company
+	// was mapped to its domain
+	DomainResolution int `json:"domain_resolution"`
+}
+
+// Total returns the sum of all components clamped to 0-100
+func (b ScoreBreakdown) Total() int {
+	total := b.Status + b.Deliverability + b.CatchAll + b.PatternPrior + b.DomainResolution
+	return min(max(total, 0), 100)
+}
+
+// FindEmailResponse is the outcome of a lookup
+type FindEmailResponse struct {
+	FoundEmails    []EmailResult    `json:"found_emails"`
+	TotalChecked   int              `json:"total_checked"`
+	TotalFound     int              `json:"total_found"`
+	Domain         string           `json:"domain"`
+	DomainResolved bool             `json:"domain_resolved"`
+	Request        FindEmailRequest `json:"request"`
+
+	InferredPatterns []string `json:"inferred_patterns,omitempty"`
+	Verified         bool     `json:"verified"`
+
+	// StatusBreakdown counts every checked email by its is_reachable status
+	StatusBreakdown map[string]int `json:"status_breakdown"`
+	// BestGuess is the most likely address when no email could be verified
+	BestGuess *EmailResult `json:"best_guess,omitempty"`
+
+	// CachedAt is when the addresses were verified, if the results of an
+	// earlier lookup were reused
+	CachedAt *time.Time `json:"cached_at,omitempty"`
+}
+
+// BulkFindRequest is several lookups submitted together
+type BulkFindRequest struct {
+	Requests []FindEmailRequest `json:"requests"`
+
+	// CallbackURL is only used by the server, which POSTs the response to it
+	CallbackURL string `json:"callback_url,omitempty"`
+}
+
+// BulkFindResult is the outcome of one lookup of a bulk request
+type BulkFindResult struct {
+	// Index is the position of the lookup in the request
+	Index  int                `json:"index"`
+	Result *FindEmailResponse `json:"result,omitempty"`
+	Error  string             `json:"error,omitempty"`
+}
+
+// BulkFindResponse holds one result per lookup, in request order
+type BulkFindResponse struct {
+	Results []BulkFindResult `json:"results"`
+	Total   int              `json:"total"`
+	Failed  int              `json:"failed"`
+}
+
+// VerifyEmailsRequest is a list of addresses to verify as-is
+type VerifyEmailsRequest struct {
+	Emails []string `json:"emails"`
+}
+
+// VerifyEmailsResponse holds one verification result per requested email, in order
+type VerifyEmailsResponse struct {
+	Results []*VerificationResult `json:"results"`
+}
+
+// ResolveDomainRequest is a company whose email domain is looked up
+type ResolveDomainRequest struct {
+	Company string `json:"company"`
+}
+
+// DomainResult is the email domain found for a company
+type DomainResult struct {
+	Domain     string   `json:"domain"`
+	Resolved   bool     `json:"resolved"`
+	Method     string   `json:"method"` // "direct", "pattern", "dns_verified"
+	Candidates []string `json:"candidates,omitempty"`
+}
+
+// InferPatternRequest holds known addresses at a company
+type InferPatternRequest struct {
+	Company string          `json:"company,omitempty"`
+	Samples []SampleAddress `json:"samples"`
+}
+
+// InferPatternResponse is the patterns that explain the sample addresses
+type InferPatternResponse struct {
+	Domain           string              `json:"domain"`
+	InferredPatterns []string            `json:"inferred_patterns"`
+	Candidates       []PatternMatch      `json:"candidates"`
+	TotalSamples     int                 `json:"total_samples"`
+	Request          InferPatternRequest `json:"request"`
+}
+
+// EmailPattern is a candidate address and the pattern that built it
+type EmailPattern struct {
+	Email   string
+	Pattern string
+}
+
+// SampleAddress is a known address of a person
+type SampleAddress struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+}
+
+// PatternMatch describes how many sample addresses a pattern explains
+type PatternMatch struct {
+	Pattern string `json:"pattern"`
+	Matches int    `json:"matches"`
+}
+
+// LookupRecord is everything a lookup did: the domain it resolved, the
+// addresses it tried, every verification result and what it returned
+type LookupRecord struct {
+	Request        FindEmailRequest `json:"request"`
+	Domain         string           `json:"domain,omitempty"`
+	DomainMethod   string           `json:"domain_method,omitempty"`
+	DomainResolved bool             `json:"domain_resolved"`
+
+	Patterns      []PatternTried        `json:"patterns"`
+	Verifications []*VerificationResult `json:"verifications"`
+
+	Response *FindEmailResponse `json:"response,omitempty"`
+	Error    string             `json:"error,omitempty"`
+
+	StartedAt  time.Time `json:"started_at"`
+	DurationMS int64     `json:"duration_ms"`
+}
+
+// PatternTried is a candidate address generated by a lookup
+type PatternTried struct {
+	Email   string `json:"email"`
+	Pattern string `json:"pattern"`
+}
+
+// VerificationResult is the verdict of a Verifier on one address
+type VerificationResult struct {
+	Email         string                 `json:"email"`
+	IsReachable   string                 `json:"is_reachable"` // safe, risky, invalid, unknown
+	IsValid       bool                   `json:"is_valid"`
+	IsDeliverable bool                   `json:"is_deliverable"`
+	Details       map[string]interface{} `json:"details,omitempty"`
+
+	// Full check-if-email-exists sections; nil when the backend did not report them
+	Syntax *SyntaxDetails `json:"syntax,omitempty"`
+	MX     *MXDetails     `json:"mx,omitempty"`
+	SMTP   *SMTPDetails   `json:"smtp,omitempty"`
+	Misc   *MiscDetails   `json:"misc,omitempty"`
+
+	// Errors reported by check-if-email-exists in place of a section
+	MXError   *CheckError `json:"mx_error,omitempty"`
+	SMTPError *CheckError `json:"smtp_error,omitempty"`
+	MiscError *CheckError `json:"misc_error,omitempty"`
+
+	// Failure explains why the result is unknown; nil when the check completed
+	Failure *Failure `json:"failure,omitempty"`
+}
+
+// SyntaxDetails holds the syntax section of a check-if-email-exists result
+type SyntaxDetails struct {
+	Address       string `json:"address"`
+	Domain        string `json:"domain"`
+	Username      string `json:"username"`
+	IsValidSyntax bool   `json:"is_valid_syntax"`
+}
+
+// MXDetails holds the MX section of a check-if-email-exists result
+type MXDetails struct {
+	AcceptsMail bool     `json:"accepts_mail"`
+	Records     []string `json:"records"`
+}
+
+// SMTPDetails holds the SMTP section of a check-if-email-exists result
+type SMTPDetails struct {
+	CanConnectSMTP bool `json:"can_connect_smtp"`
+	HasFullInbox   bool `json:"has_full_inbox"`
+	IsCatchAll     bool `json:"is_catch_all"`
+	IsDeliverable  bool `json:"is_deliverable"`
+	IsDisabled     bool `json:"is_disabled"`
+}
+
+// MiscDetails holds the misc section of a check-if-email-exists result
+type MiscDetails struct {
+	IsDisposable  bool   `json:"is_disposable"`
+	IsRoleAccount bool   `json:"is_role_account"`
+	GravatarURL   string `json:"gravatar_url,omitempty"`
+}
+
+// CheckError is an error reported by check-if-email-exists for one section of
+// the result. Code holds the SMTP reply code when the message contains one.
+type CheckError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	Code    int    `json:"code,omitempty"`
+}
+
+// Failure kinds
+const (
+	FailureTimeout = "timeout" // the check did not finish in time
+	FailureBackend = "backend" // the backend failed
+	FailureSMTP    = "smtp"    // the recipient's mail server refused or deferred the check
+)
+
+// Failure describes why a verification could not reach a verdict
+type Failure struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+	// Transient failures may succeed if retried later
+	Transient bool `json:"transient"`
+	// Greylisted is set when the mail server temporarily deferred us (4xx reply)
+	Greylisted bool `json:"greylisted,omitempty"`
+}
+
+// IsTransientFailure reports whether the result is unknown because of a failure
+// that may go away when retried
+func (r *VerificationResult) IsTransientFailure() bool {
+	return r.Failure != nil && r.Failure.Transient
+}
@@ -0,0 +1,94 @@
+package finder
+
+import (
+	"email-finder/internal/verifier"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// verifierOptions holds the settings of the built-in verifiers
+type verifierOptions struct {
+	timeout          time.Duration
+	concurrency      int
+	endpoint         string
+	logger           *zap.Logger
+	breakerThreshold int
+	breakerTimeout   time.Duration
+}
+
+// VerifierOption configures a built-in verifier
+type VerifierOption func(*verifierOptions)
+
+// WithVerifierTimeout sets the timeout of a single verification
+func WithVerifierTimeout(timeout time.Duration) VerifierOption {
+	return func(o *verifierOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithVerifierConcurrency sets how many emails of a batch are verified at once
+func WithVerifierConcurrency(n int) VerifierOption {
+	return func(o *verifierOptions) {
+		o.concurrency = n
+	}
+}
+
+// WithVerifierEndpoint sets the path of the check-if-email-exists HTTP API
+func WithVerifierEndpoint(endpoint string) VerifierOption {
+	return func(o *verifierOptions) {
+		o.endpoint = endpoint
+	}
+}
+
+// WithVerifierLogger sets the logger of a verifier; by default nothing is logged
+func WithVerifierLogger(logger *zap.Logger) VerifierOption {
+	return func(o *verifierOptions) {
+		o.logger = logger
+	}
+}
+
+// WithCircuitBreaker stops calling the backend for openTimeout after threshold
+// consecutive failures, failing fast with ErrCircuitOpen instead
+func WithCircuitBreaker(threshold int, openTimeout time.Duration) VerifierOption {
+	return func(o *verifierOptions) {
+		o.breakerThreshold = threshold
+		o.breakerTimeout = openTimeout
+	}
+}
+
+func newVerifierOptions(opts []VerifierOption) *verifierOptions {
+	o := &verifierOptions{
+		timeout:     DefaultTimeout,
+		concurrency: DefaultConcurrency,
+		endpoint:    "/v0/check_email",
+		logger:      zap.NewNop(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+func (o *verifierOptions) breaker(name string) *verifier.CircuitBreaker {
+	if o.breakerThreshold <= 0 {
+		return nil
+	}
+	return verifier.NewCircuitBreaker(name, o.breakerThreshold, o.breakerTimeout, o.logger)
+}
+
+// NewHTTPVerifier creates a verifier that calls a check-if-email-exists HTTP API at apiURL
+func NewHTTPVerifier(apiURL string, opts ...VerifierOption) Verifier {
+	o := newVerifierOptions(opts)
+	v := verifier.NewHTTPVerifier(apiURL, o.endpoint, o.timeout, o.concurrency, o.logger)
+	v.SetCircuitBreaker(o.breaker(apiURL))
+	return builtinVerifier{v: v}
+}
+
+// NewCLIVerifier creates a verifier that runs the check-if-email-exists binary at path
+func NewCLIVerifier(path string, opts ...VerifierOption) Verifier {
+	o := newVerifierOptions(opts)
+	v := verifier.NewCLIVerifier(path, o.timeout, o.concurrency, o.logger)
+	v.SetCircuitBreaker(o.breaker("cli"))
+	return builtinVerifier{v: v}
+}